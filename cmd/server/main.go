@@ -8,17 +8,30 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // CronJob time zones must resolve in minimal images without zoneinfo
 
 	"github.com/gin-gonic/gin"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes" // Added this import
 
 	"k8s-glance-backend/internal/api/configmap"
+	"k8s-glance-backend/internal/api/cronjob"
+	"k8s-glance-backend/internal/api/daemonset"
 	"k8s-glance-backend/internal/api/deployment"
+	"k8s-glance-backend/internal/api/hpa"
 	"k8s-glance-backend/internal/api/ingress"
+	"k8s-glance-backend/internal/api/job"
 	"k8s-glance-backend/internal/api/namespace"
+	"k8s-glance-backend/internal/api/networkpolicy"
+	"k8s-glance-backend/internal/api/node"
 	"k8s-glance-backend/internal/api/pod"
+	"k8s-glance-backend/internal/api/rbac"
+	"k8s-glance-backend/internal/api/resources"
 	"k8s-glance-backend/internal/api/secret"
 	"k8s-glance-backend/internal/api/service"
+	"k8s-glance-backend/internal/api/serviceaccount"
+	"k8s-glance-backend/internal/api/statefulset"
+	"k8s-glance-backend/internal/api/storage"
 	"k8s-glance-backend/internal/config"
 	k8sclient "k8s-glance-backend/pkg/kubernetes" // Aliased to avoid confusion
 )
@@ -45,7 +58,7 @@ func main() {
 	// Add CORS middleware
 	router.Use(func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
 		c.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -55,7 +68,7 @@ func main() {
 	})
 
 	// Setup routes
-	setupRoutes(router, k8sClient.Clientset, logger)
+	setupRoutes(router, k8sClient.Clientset, k8sClient.Dynamic, cfg, logger)
 
 	// Create server with timeout configurations
 	srv := &http.Server{
@@ -90,7 +103,7 @@ func main() {
 	logger.Println("Server exiting")
 }
 
-func setupRoutes(router *gin.Engine, clientset *kubernetes.Clientset, logger *log.Logger) {
+func setupRoutes(router *gin.Engine, clientset *kubernetes.Clientset, dynamicClient dynamic.Interface, cfg *config.Config, logger *log.Logger) {
 	// Health check
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{
@@ -99,13 +112,24 @@ func setupRoutes(router *gin.Engine, clientset *kubernetes.Clientset, logger *lo
 	})
 
 	// Initialize handlers
-	namespaceHandler := namespace.NewHandler(clientset, logger)
+	namespaceHandler := namespace.NewHandler(clientset, logger, cfg.ProtectedNamespaces)
+	nodeHandler := node.NewHandler(clientset, logger)
 	podHandler := pod.NewHandler(clientset, logger)
 	deploymentHandler := deployment.NewHandler(clientset, logger)
+	statefulSetHandler := statefulset.NewHandler(clientset, logger)
+	daemonSetHandler := daemonset.NewHandler(clientset, logger)
+	jobHandler := job.NewHandler(clientset, logger)
+	cronJobHandler := cronjob.NewHandler(clientset, logger)
+	hpaHandler := hpa.NewHandler(clientset, logger)
+	storageHandler := storage.NewHandler(clientset, logger)
 	serviceHandler := service.NewHandler(clientset, logger)
+	networkPolicyHandler := networkpolicy.NewHandler(clientset, logger)
+	rbacHandler := rbac.NewHandler(clientset, logger)
+	serviceAccountHandler := serviceaccount.NewHandler(clientset, logger)
 	configMapHandler := configmap.NewHandler(clientset, logger)
 	secretHandler := secret.NewHandler(clientset, logger)
 	ingressHandler := ingress.NewHandler(clientset, logger)
+	resourceHandler := resources.NewHandler(clientset, dynamicClient, logger)
 
 	// API version group
 	v1 := router.Group("/api/v1")
@@ -114,8 +138,37 @@ func setupRoutes(router *gin.Engine, clientset *kubernetes.Clientset, logger *lo
 		namespaces := v1.Group("/namespaces")
 		{
 			namespaces.GET("", namespaceHandler.ListNamespaces)
-			namespaces.GET("/:name", namespaceHandler.GetNamespace)
-			namespaces.GET("/:name/metrics", namespaceHandler.GetNamespaceMetrics)
+			namespaces.POST("", namespaceHandler.CreateNamespace)
+			namespaces.GET("/:namespace", namespaceHandler.GetNamespace)
+			namespaces.PUT("/:namespace", namespaceHandler.UpdateNamespace)
+			namespaces.DELETE("/:namespace", namespaceHandler.DeleteNamespace)
+			namespaces.GET("/:namespace/metrics", namespaceHandler.GetNamespaceMetrics)
+			namespaces.GET("/:namespace/overview", namespaceHandler.GetNamespaceOverview)
+			namespaces.GET("/:namespace/topology", namespaceHandler.GetNamespaceTopology)
+			namespaces.GET("/:namespace/delete-preview", namespaceHandler.GetNamespaceDeletePreview)
+			namespaces.GET("/:namespace/termination", namespaceHandler.WatchNamespaceTermination)
+
+			namespaces.GET("/:namespace/resourcequotas", namespaceHandler.ListResourceQuotas)
+			namespaces.POST("/:namespace/resourcequotas", namespaceHandler.CreateResourceQuota)
+			namespaces.GET("/:namespace/resourcequotas/:name", namespaceHandler.GetResourceQuota)
+			namespaces.PUT("/:namespace/resourcequotas/:name", namespaceHandler.UpdateResourceQuota)
+			namespaces.DELETE("/:namespace/resourcequotas/:name", namespaceHandler.DeleteResourceQuota)
+
+			namespaces.GET("/:namespace/limitranges", namespaceHandler.ListLimitRanges)
+			namespaces.POST("/:namespace/limitranges", namespaceHandler.CreateLimitRange)
+			namespaces.GET("/:namespace/limitranges/:name", namespaceHandler.GetLimitRange)
+			namespaces.PUT("/:namespace/limitranges/:name", namespaceHandler.UpdateLimitRange)
+			namespaces.DELETE("/:namespace/limitranges/:name", namespaceHandler.DeleteLimitRange)
+		}
+
+		// Node routes
+		nodes := v1.Group("/nodes")
+		{
+			nodes.GET("", nodeHandler.ListNodes)
+			nodes.GET("/:name", nodeHandler.GetNode)
+			nodes.POST("/:name/cordon", nodeHandler.CordonNode)
+			nodes.POST("/:name/uncordon", nodeHandler.UncordonNode)
+			nodes.POST("/:name/drain", nodeHandler.DrainNode)
 		}
 
 		// Pod routes
@@ -124,6 +177,7 @@ func setupRoutes(router *gin.Engine, clientset *kubernetes.Clientset, logger *lo
 			pods.GET("/namespaces/:namespace", podHandler.ListPods)
 			pods.GET("/namespaces/:namespace/:name", podHandler.GetPod)
 			pods.GET("/namespaces/:namespace/:name/metrics", podHandler.GetPodMetrics)
+			pods.GET("/namespaces/:namespace/:name/logs", podHandler.GetPodLogs)
 			pods.DELETE("/namespaces/:namespace/:name", podHandler.DeletePod)
 		}
 
@@ -139,6 +193,75 @@ func setupRoutes(router *gin.Engine, clientset *kubernetes.Clientset, logger *lo
 			deployments.PUT("/namespaces/:namespace/:name/scale", deploymentHandler.ScaleDeployment)
 		}
 
+		// StatefulSet routes
+		statefulSets := v1.Group("/statefulsets")
+		{
+			statefulSets.GET("/namespaces/:namespace", statefulSetHandler.ListStatefulSets)
+			statefulSets.POST("/namespaces/:namespace", statefulSetHandler.CreateStatefulSet)
+			statefulSets.GET("/namespaces/:namespace/:name", statefulSetHandler.GetStatefulSet)
+			statefulSets.PUT("/namespaces/:namespace/:name", statefulSetHandler.UpdateStatefulSet)
+			statefulSets.GET("/namespaces/:namespace/:name/status", statefulSetHandler.GetStatefulSetStatus)
+			statefulSets.GET("/namespaces/:namespace/:name/revisions", statefulSetHandler.GetStatefulSetRevisions)
+			statefulSets.DELETE("/namespaces/:namespace/:name", statefulSetHandler.DeleteStatefulSet)
+			statefulSets.PUT("/namespaces/:namespace/:name/scale", statefulSetHandler.ScaleStatefulSet)
+			statefulSets.POST("/namespaces/:namespace/:name/restart", statefulSetHandler.RestartStatefulSet)
+			statefulSets.PUT("/namespaces/:namespace/:name/partition", statefulSetHandler.SetPartition)
+		}
+
+		// DaemonSet routes
+		daemonSets := v1.Group("/daemonsets")
+		{
+			daemonSets.GET("/namespaces/:namespace", daemonSetHandler.ListDaemonSets)
+			daemonSets.GET("/namespaces/:namespace/:name", daemonSetHandler.GetDaemonSet)
+			daemonSets.PUT("/namespaces/:namespace/:name", daemonSetHandler.UpdateDaemonSet)
+			daemonSets.GET("/namespaces/:namespace/:name/status", daemonSetHandler.GetDaemonSetStatus)
+			daemonSets.DELETE("/namespaces/:namespace/:name", daemonSetHandler.DeleteDaemonSet)
+			daemonSets.POST("/namespaces/:namespace/:name/restart", daemonSetHandler.RestartDaemonSet)
+		}
+
+		// Job routes
+		jobs := v1.Group("/jobs")
+		{
+			jobs.GET("/namespaces/:namespace", jobHandler.ListJobs)
+			jobs.GET("/namespaces/:namespace/:name", jobHandler.GetJob)
+			jobs.GET("/namespaces/:namespace/:name/status", jobHandler.GetJobStatus)
+			jobs.DELETE("/namespaces/:namespace/:name", jobHandler.DeleteJob)
+		}
+
+		// CronJob routes
+		cronJobs := v1.Group("/cronjobs")
+		{
+			cronJobs.GET("/namespaces/:namespace", cronJobHandler.ListCronJobs)
+			cronJobs.GET("/namespaces/:namespace/:name", cronJobHandler.GetCronJob)
+			cronJobs.GET("/namespaces/:namespace/:name/status", cronJobHandler.GetCronJobStatus)
+			cronJobs.GET("/namespaces/:namespace/:name/jobs", cronJobHandler.GetCronJobHistory)
+			cronJobs.DELETE("/namespaces/:namespace/:name", cronJobHandler.DeleteCronJob)
+			cronJobs.POST("/namespaces/:namespace/:name/suspend", cronJobHandler.SuspendCronJob)
+			cronJobs.POST("/namespaces/:namespace/:name/resume", cronJobHandler.ResumeCronJob)
+			cronJobs.POST("/namespaces/:namespace/:name/trigger", cronJobHandler.TriggerCronJob)
+		}
+
+		// HorizontalPodAutoscaler routes
+		hpas := v1.Group("/hpas")
+		{
+			hpas.GET("/namespaces/:namespace", hpaHandler.ListAutoscalers)
+			hpas.POST("/namespaces/:namespace", hpaHandler.CreateAutoscaler)
+			hpas.GET("/namespaces/:namespace/:name", hpaHandler.GetAutoscaler)
+			hpas.PUT("/namespaces/:namespace/:name", hpaHandler.UpdateAutoscaler)
+			hpas.GET("/namespaces/:namespace/:name/status", hpaHandler.GetAutoscalerStatus)
+			hpas.DELETE("/namespaces/:namespace/:name", hpaHandler.DeleteAutoscaler)
+		}
+
+		// Storage routes
+		storageGroup := v1.Group("/storage")
+		{
+			storageGroup.GET("/persistentvolumeclaims/namespaces/:namespace", storageHandler.ListPersistentVolumeClaims)
+			storageGroup.GET("/persistentvolumeclaims/namespaces/:namespace/:name", storageHandler.GetPersistentVolumeClaim)
+			storageGroup.PUT("/persistentvolumeclaims/namespaces/:namespace/:name/expand", storageHandler.ExpandPersistentVolumeClaim)
+			storageGroup.GET("/persistentvolumes", storageHandler.ListPersistentVolumes)
+			storageGroup.GET("/storageclasses", storageHandler.ListStorageClasses)
+		}
+
 		// Service routes
 		services := v1.Group("/services")
 		{
@@ -150,6 +273,39 @@ func setupRoutes(router *gin.Engine, clientset *kubernetes.Clientset, logger *lo
 			services.GET("/namespaces/:namespace/:name/status", serviceHandler.GetServiceStatus)
 		}
 
+		// NetworkPolicy routes
+		networkPolicies := v1.Group("/networkpolicies")
+		{
+			networkPolicies.GET("/namespaces/:namespace", networkPolicyHandler.ListNetworkPolicies)
+			networkPolicies.POST("/namespaces/:namespace", networkPolicyHandler.CreateNetworkPolicy)
+			networkPolicies.GET("/namespaces/:namespace/:name", networkPolicyHandler.GetNetworkPolicy)
+			networkPolicies.PUT("/namespaces/:namespace/:name", networkPolicyHandler.UpdateNetworkPolicy)
+			networkPolicies.DELETE("/namespaces/:namespace/:name", networkPolicyHandler.DeleteNetworkPolicy)
+			networkPolicies.POST("/analyze", networkPolicyHandler.AnalyzeReachability)
+		}
+
+		// RBAC routes
+		rbacGroup := v1.Group("/rbac")
+		{
+			rbacGroup.GET("/roles/namespaces/:namespace", rbacHandler.ListRoles)
+			rbacGroup.GET("/rolebindings/namespaces/:namespace", rbacHandler.ListRoleBindings)
+			rbacGroup.GET("/clusterroles", rbacHandler.ListClusterRoles)
+			rbacGroup.GET("/clusterrolebindings", rbacHandler.ListClusterRoleBindings)
+			rbacGroup.GET("/who-can", rbacHandler.WhoCan)
+			rbacGroup.GET("/subjects/:kind/:name", rbacHandler.GetSubjectPermissions)
+			rbacGroup.POST("/access-review", rbacHandler.ReviewSelfAccess)
+		}
+
+		// ServiceAccount routes
+		serviceAccounts := v1.Group("/serviceaccounts")
+		{
+			serviceAccounts.GET("/namespaces/:namespace", serviceAccountHandler.ListServiceAccounts)
+			serviceAccounts.POST("/namespaces/:namespace", serviceAccountHandler.CreateServiceAccount)
+			serviceAccounts.GET("/namespaces/:namespace/:name", serviceAccountHandler.GetServiceAccount)
+			serviceAccounts.DELETE("/namespaces/:namespace/:name", serviceAccountHandler.DeleteServiceAccount)
+			serviceAccounts.POST("/namespaces/:namespace/:name/token", serviceAccountHandler.CreateToken)
+		}
+
 		// ConfigMap routes
 		configMaps := v1.Group("/configmaps")
 		{
@@ -168,6 +324,10 @@ func setupRoutes(router *gin.Engine, clientset *kubernetes.Clientset, logger *lo
 			secrets.GET("/namespaces/:namespace/:name", secretHandler.GetSecret)
 			secrets.PUT("/namespaces/:namespace/:name", secretHandler.UpdateSecret)
 			secrets.DELETE("/namespaces/:namespace/:name", secretHandler.DeleteSecret)
+			secrets.GET("/namespaces/:namespace/:name/keys", secretHandler.GetSecretKeys)
+			secrets.GET("/namespaces/:namespace/:name/usage", secretHandler.GetSecretUsage)
+			secrets.GET("/namespaces/:namespace/:name/certificate", secretHandler.GetSecretCertificate)
+			secrets.GET("/certificates/expiring", secretHandler.ListExpiringCertificates)
 		}
 
 		// Ingress routes (nested under namespaces)
@@ -180,5 +340,36 @@ func setupRoutes(router *gin.Engine, clientset *kubernetes.Clientset, logger *lo
 			ingresses.DELETE("/:name", ingressHandler.DeleteIngress)
 			ingresses.GET("/:name/status", ingressHandler.GetIngressStatus)
 		}
+
+		// Cluster-wide ingress routes
+		clusterIngresses := v1.Group("/ingresses")
+		{
+			clusterIngresses.GET("/conflicts", ingressHandler.ListIngressConflicts)
+			clusterIngresses.GET("/simulate", ingressHandler.SimulateRoute)
+		}
+
+		// IngressClass routes
+		ingressClasses := v1.Group("/ingressclasses")
+		{
+			ingressClasses.GET("", ingressHandler.ListIngressClasses)
+		}
+
+		// Generic resource routes backed by discovery and the dynamic client; the core group is addressed as "core"
+		resourceRoutes := v1.Group("/resources")
+		{
+			resourceRoutes.GET("", resourceHandler.ListAPIResources)
+			resourceRoutes.GET("/:group/:version/:resource", resourceHandler.ListResources)
+			resourceRoutes.POST("/:group/:version/:resource", resourceHandler.CreateResource)
+			resourceRoutes.GET("/:group/:version/:resource/:name", resourceHandler.GetResource)
+			resourceRoutes.PUT("/:group/:version/:resource/:name", resourceHandler.UpdateResource)
+			resourceRoutes.PATCH("/:group/:version/:resource/:name", resourceHandler.PatchResource)
+			resourceRoutes.DELETE("/:group/:version/:resource/:name", resourceHandler.DeleteResource)
+			resourceRoutes.GET("/:group/:version/:resource/namespaces/:namespace", resourceHandler.ListResources)
+			resourceRoutes.POST("/:group/:version/:resource/namespaces/:namespace", resourceHandler.CreateResource)
+			resourceRoutes.GET("/:group/:version/:resource/namespaces/:namespace/:name", resourceHandler.GetResource)
+			resourceRoutes.PUT("/:group/:version/:resource/namespaces/:namespace/:name", resourceHandler.UpdateResource)
+			resourceRoutes.PATCH("/:group/:version/:resource/namespaces/:namespace/:name", resourceHandler.PatchResource)
+			resourceRoutes.DELETE("/:group/:version/:resource/namespaces/:namespace/:name", resourceHandler.DeleteResource)
+		}
 	}
 }
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"io"
+	"log"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s-glance-backend/internal/config"
+)
+
+// TestSetupRoutes guards against conflicting wildcard segments, which make gin panic at startup
+func TestSetupRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	setupRoutes(router, &kubernetes.Clientset{}, nil, &config.Config{}, log.New(io.Discard, "", 0))
+
+	if len(router.Routes()) == 0 {
+		t.Fatal("no routes registered")
+	}
+}
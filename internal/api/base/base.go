@@ -13,12 +13,12 @@ import (
 
 // BaseAPI provides common functionality for all API services
 type BaseAPI struct {
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 	logger    *log.Logger
 }
 
 // NewBaseAPI creates a new instance of BaseAPI
-func NewBaseAPI(clientset *kubernetes.Clientset, logger *log.Logger) *BaseAPI {
+func NewBaseAPI(clientset kubernetes.Interface, logger *log.Logger) *BaseAPI {
 	if logger == nil {
 		logger = log.New(os.Stdout, "[BASE-API] ", log.LstdFlags)
 	}
@@ -30,7 +30,7 @@ func NewBaseAPI(clientset *kubernetes.Clientset, logger *log.Logger) *BaseAPI {
 }
 
 // GetClientset returns the kubernetes clientset
-func (b *BaseAPI) GetClientset() *kubernetes.Clientset {
+func (b *BaseAPI) GetClientset() kubernetes.Interface {
 	return b.clientset
 }
 
@@ -49,12 +49,17 @@ func (b *BaseAPI) LogInfo(ctx context.Context, operation string, message string)
 	b.logger.Printf("Info [%s]: %s", operation, message)
 }
 
+// LogAudit records a security-relevant action, such as minting credentials, on a separate log prefix
+func (b *BaseAPI) LogAudit(ctx context.Context, operation string, message string) {
+	b.logger.Printf("Audit [%s]: %s", operation, message)
+}
+
 // HandleError standardizes error handling across APIs
 func (b *BaseAPI) HandleError(err error, operation string) error {
 	if err != nil {
 		b.LogError(context.Background(), operation, err)
 		if statusErr, ok := err.(*errors.StatusError); ok {
-			return fmt.Errorf("%s failed: %v (Code: %d, Reason: %s)",
+			return fmt.Errorf("%s failed: %w (Code: %d, Reason: %s)",
 				operation, err, statusErr.ErrStatus.Code, statusErr.ErrStatus.Reason)
 		}
 		return fmt.Errorf("%s failed: %w", operation, err)
@@ -0,0 +1,49 @@
+package base
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ContainerEnv is the environment of one container of a Pod
+type ContainerEnv struct {
+	Name    string
+	Env     []corev1.EnvVar
+	EnvFrom []corev1.EnvFromSource
+}
+
+// GetContainerEnvs collects the environment of all containers in a Pod. Init and
+// ephemeral containers are prefixed with their kind.
+func GetContainerEnvs(pod *corev1.Pod) []ContainerEnv {
+	var result []ContainerEnv
+	for _, c := range pod.Spec.Containers {
+		result = append(result, ContainerEnv{Name: c.Name, Env: c.Env, EnvFrom: c.EnvFrom})
+	}
+	for _, c := range pod.Spec.InitContainers {
+		result = append(result, ContainerEnv{Name: "init:" + c.Name, Env: c.Env, EnvFrom: c.EnvFrom})
+	}
+	for _, c := range pod.Spec.EphemeralContainers {
+		result = append(result, ContainerEnv{Name: "ephemeral:" + c.Name, Env: c.Env, EnvFrom: c.EnvFrom})
+	}
+	return result
+}
+
+// AppendItemKeys adds the keys projected by a volume; without items the whole object ("*") is used
+func AppendItemKeys(keys []string, items []corev1.KeyToPath) []string {
+	if len(items) == 0 {
+		return AppendKey(keys, "*")
+	}
+	for _, item := range items {
+		keys = AppendKey(keys, item.Key)
+	}
+	return keys
+}
+
+// AppendKey adds a key unless it is already present
+func AppendKey(keys []string, key string) []string {
+	for _, k := range keys {
+		if k == key {
+			return keys
+		}
+	}
+	return append(keys, key)
+}
@@ -0,0 +1,47 @@
+package base
+
+import (
+	"math"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// PodEffectiveResources returns the larger of the summed app containers and the largest init container,
+// which is what the scheduler accounts for. pick selects the requests or limits of a container.
+func PodEffectiveResources(pod *corev1.Pod, pick func(corev1.ResourceRequirements) corev1.ResourceList) corev1.ResourceList {
+	total := corev1.ResourceList{}
+	for _, c := range pod.Spec.Containers {
+		AddResourceList(total, pick(c.Resources))
+	}
+	for _, c := range pod.Spec.InitContainers {
+		for name, quantity := range pick(c.Resources) {
+			if current, ok := total[name]; !ok || quantity.Cmp(current) > 0 {
+				total[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	return total
+}
+
+// AddResourceList adds every quantity of add to total
+func AddResourceList(total, add corev1.ResourceList) {
+	for name, quantity := range add {
+		if current, ok := total[name]; ok {
+			current.Add(quantity)
+			total[name] = current
+		} else {
+			total[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+// PercentOf returns used as a percentage of total, rounded to one decimal. Quantities are divided
+// as floats, so large values such as exabytes of storage do not overflow; a zero total yields 0.
+func PercentOf(used, total resource.Quantity) float64 {
+	if total.IsZero() {
+		return 0
+	}
+	percent := used.AsApproximateFloat64() / total.AsApproximateFloat64() * 100
+	return math.Round(percent*10) / 10
+}
@@ -0,0 +1,28 @@
+package base
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PrepareStream sets up a long-lived Server-Sent Events response. The server's
+// write timeout is lifted for this request only, so the stream is bounded by the
+// request context instead.
+func PrepareStream(c *gin.Context) {
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		c.Error(err)
+	}
+
+	c.Writer.Header().Set("Content-Type", "text/event-stream")
+	c.Writer.Header().Set("Cache-Control", "no-cache")
+	c.Writer.Header().Set("Connection", "keep-alive")
+	c.Writer.Header().Set("X-Accel-Buffering", "no")
+}
+
+// SendEvent writes a single Server-Sent Event and flushes it to the client
+func SendEvent(c *gin.Context, name string, data interface{}) {
+	c.SSEvent(name, data)
+	c.Writer.Flush()
+}
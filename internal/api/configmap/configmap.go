@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -101,51 +102,82 @@ func (api *ConfigMapAPI) GetConfigMapUsage(ctx context.Context, namespace, name
 	}
 
 	// Track pods using this ConfigMap
+	consumedKeys := make(map[string]bool)
 	var usingPods []map[string]interface{}
 
 	for _, pod := range pods.Items {
-		isUsed := false
-		usageDetails := make(map[string][]string)
-
-		// Check volume mounts
-		for _, volume := range pod.Spec.Volumes {
-			if volume.ConfigMap != nil && volume.ConfigMap.Name == name {
-				isUsed = true
-				usageDetails["volumeMounts"] = append(usageDetails["volumeMounts"], volume.Name)
-			}
-		}
+		usageDetails, keys := GetPodConfigMapReferences(&pod, name)
 
-		// Check environment variables
-		for _, container := range pod.Spec.Containers {
-			for _, env := range container.EnvFrom {
-				if env.ConfigMapRef != nil && env.ConfigMapRef.Name == name {
-					isUsed = true
-					usageDetails["envFrom"] = append(usageDetails["envFrom"], container.Name)
-				}
+		if len(usageDetails) > 0 {
+			for _, k := range keys {
+				consumedKeys[k] = true
 			}
-
-			for _, env := range container.Env {
-				if env.ValueFrom != nil && env.ValueFrom.ConfigMapKeyRef != nil &&
-					env.ValueFrom.ConfigMapKeyRef.Name == name {
-					isUsed = true
-					usageDetails["envVars"] = append(usageDetails["envVars"],
-						fmt.Sprintf("%s:%s", container.Name, env.Name))
-				}
-			}
-		}
-
-		if isUsed {
 			usingPods = append(usingPods, map[string]interface{}{
 				"name":   pod.Name,
 				"status": pod.Status.Phase,
 				"usage":  usageDetails,
+				"keys":   keys,
 			})
 		}
 	}
 
+	keys := make([]string, 0, len(consumedKeys))
+	for k := range consumedKeys {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
 	response := base.NewSuccessResponse(map[string]interface{}{
 		"podsUsingConfigMap": usingPods,
 		"totalPods":          len(usingPods),
+		"consumedKeys":       keys,
 	})
 	return &response, nil
 }
+
+// Helper functions
+
+// GetPodConfigMapReferences returns how a Pod references the named ConfigMap, grouped by
+// kind of reference, and the keys it consumes. A key of "*" means the whole ConfigMap.
+func GetPodConfigMapReferences(pod *corev1.Pod, name string) (map[string][]string, []string) {
+	usageDetails := make(map[string][]string)
+	var keys []string
+
+	// Check volume mounts
+	for _, volume := range pod.Spec.Volumes {
+		if volume.ConfigMap != nil && volume.ConfigMap.Name == name {
+			usageDetails["volumeMounts"] = append(usageDetails["volumeMounts"], volume.Name)
+			keys = base.AppendItemKeys(keys, volume.ConfigMap.Items)
+		}
+
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil && source.ConfigMap.Name == name {
+					usageDetails["projectedVolumes"] = append(usageDetails["projectedVolumes"], volume.Name)
+					keys = base.AppendItemKeys(keys, source.ConfigMap.Items)
+				}
+			}
+		}
+	}
+
+	// Check environment variables of regular, init and ephemeral containers
+	for _, container := range base.GetContainerEnvs(pod) {
+		for _, env := range container.EnvFrom {
+			if env.ConfigMapRef != nil && env.ConfigMapRef.Name == name {
+				usageDetails["envFrom"] = append(usageDetails["envFrom"], container.Name)
+				keys = base.AppendKey(keys, "*")
+			}
+		}
+
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.ConfigMapKeyRef != nil &&
+				env.ValueFrom.ConfigMapKeyRef.Name == name {
+				usageDetails["envVars"] = append(usageDetails["envVars"],
+					fmt.Sprintf("%s:%s", container.Name, env.Name))
+				keys = base.AppendKey(keys, env.ValueFrom.ConfigMapKeyRef.Key)
+			}
+		}
+	}
+
+	return usageDetails, keys
+}
@@ -0,0 +1,78 @@
+package configmap
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestGetPodConfigMapReferences(t *testing.T) {
+	ref := corev1.LocalObjectReference{Name: "app-config"}
+	keyRef := &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: ref, Key: "log.level"}}
+
+	tests := []struct {
+		name      string
+		spec      corev1.PodSpec
+		wantUsage map[string][]string
+		wantKeys  []string
+	}{
+		{
+			name: "volume",
+			spec: corev1.PodSpec{Volumes: []corev1.Volume{{Name: "config", VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: ref},
+			}}}},
+			wantUsage: map[string][]string{"volumeMounts": {"config"}},
+			wantKeys:  []string{"*"},
+		},
+		{
+			name: "projected volume with items",
+			spec: corev1.PodSpec{Volumes: []corev1.Volume{{Name: "all", VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{{
+					ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: ref, Items: []corev1.KeyToPath{{Key: "app.yaml"}, {Key: "app.yaml"}}},
+				}}},
+			}}}},
+			wantUsage: map[string][]string{"projectedVolumes": {"all"}},
+			wantKeys:  []string{"app.yaml"},
+		},
+		{
+			name:      "container env",
+			spec:      corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Env: []corev1.EnvVar{{Name: "LOG_LEVEL", ValueFrom: keyRef}}}}},
+			wantUsage: map[string][]string{"envVars": {"web:LOG_LEVEL"}},
+			wantKeys:  []string{"log.level"},
+		},
+		{
+			name:      "init container envFrom",
+			spec:      corev1.PodSpec{InitContainers: []corev1.Container{{Name: "setup", EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: ref}}}}}},
+			wantUsage: map[string][]string{"envFrom": {"init:setup"}},
+			wantKeys:  []string{"*"},
+		},
+		{
+			name: "ephemeral container env",
+			spec: corev1.PodSpec{EphemeralContainers: []corev1.EphemeralContainer{{
+				EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug", Env: []corev1.EnvVar{{Name: "LOG_LEVEL", ValueFrom: keyRef}}},
+			}}},
+			wantUsage: map[string][]string{"envVars": {"ephemeral:debug:LOG_LEVEL"}},
+			wantKeys:  []string{"log.level"},
+		},
+		{
+			name: "other configmap",
+			spec: corev1.PodSpec{Volumes: []corev1.Volume{{Name: "other", VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "other"}},
+			}}}},
+			wantUsage: map[string][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usage, keys := GetPodConfigMapReferences(&corev1.Pod{Spec: tt.spec}, "app-config")
+			if !reflect.DeepEqual(usage, tt.wantUsage) {
+				t.Errorf("usage = %v, want %v", usage, tt.wantUsage)
+			}
+			if !reflect.DeepEqual(keys, tt.wantKeys) {
+				t.Errorf("keys = %v, want %v", keys, tt.wantKeys)
+			}
+		})
+	}
+}
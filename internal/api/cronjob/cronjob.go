@@ -0,0 +1,315 @@
+package cronjob
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s-glance-backend/internal/api/base"
+	"k8s-glance-backend/internal/api/job"
+)
+
+// instantiateAnnotation marks jobs created by hand from a cronjob, as `kubectl create job --from` does
+const instantiateAnnotation = "cronjob.kubernetes.io/instantiate"
+
+// maxMissedSchedules bounds the walk over past schedule times, like the CronJob controller
+const maxMissedSchedules = 100
+
+// upcomingSchedules is the number of future run times reported in the status
+const upcomingSchedules = 5
+
+// CronJobAPI handles cronjob-related operations
+type CronJobAPI struct {
+	*base.BaseAPI
+}
+
+// NewCronJobAPI creates a new CronJobAPI instance
+func NewCronJobAPI(clientset kubernetes.Interface, logger *log.Logger) *CronJobAPI {
+	return &CronJobAPI{
+		BaseAPI: base.NewBaseAPI(clientset, logger),
+	}
+}
+
+// ListCronJobs returns all cronjobs in a namespace
+func (api *CronJobAPI) ListCronJobs(ctx context.Context, namespace string) (*batchv1.CronJobList, error) {
+	api.LogInfo(ctx, "ListCronJobs", fmt.Sprintf("Fetching cronjobs in namespace: %s", namespace))
+
+	cronJobs, err := api.GetClientset().BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListCronJobs", err)
+		return nil, api.HandleError(err, "list cronjobs")
+	}
+
+	return cronJobs, nil
+}
+
+// GetCronJob returns a specific cronjob
+func (api *CronJobAPI) GetCronJob(ctx context.Context, namespace, name string) (*batchv1.CronJob, error) {
+	api.LogInfo(ctx, "GetCronJob", fmt.Sprintf("Fetching cronjob %s in namespace %s", name, namespace))
+
+	cronJob, err := api.GetClientset().BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetCronJob", err)
+		return nil, api.HandleError(err, "get cronjob")
+	}
+
+	return cronJob, nil
+}
+
+// GetCronJobStatus returns the schedule of a cronjob together with its recent jobs
+func (api *CronJobAPI) GetCronJobStatus(ctx context.Context, namespace, name string, historyLimit int) (*base.APIResponse, error) {
+	api.LogInfo(ctx, "GetCronJobStatus", fmt.Sprintf("Fetching status for cronjob %s in namespace %s", name, namespace))
+
+	cronJob, err := api.GetCronJob(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := api.listOwnedJobs(ctx, cronJob)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	status := GetScheduleInfo(cronJob, now)
+	status["name"] = cronJob.Name
+	status["namespace"] = cronJob.Namespace
+	status["concurrencyPolicy"] = cronJob.Spec.ConcurrencyPolicy
+	status["active"] = getActiveJobs(cronJob)
+	status["history"] = getJobHistory(history, historyLimit, now)
+
+	response := base.NewSuccessResponse(status)
+	return &response, nil
+}
+
+// GetCronJobHistory returns the most recent jobs created by a cronjob, newest first
+func (api *CronJobAPI) GetCronJobHistory(ctx context.Context, namespace, name string, limit int) ([]map[string]interface{}, error) {
+	api.LogInfo(ctx, "GetCronJobHistory", fmt.Sprintf("Fetching job history for cronjob %s in namespace %s", name, namespace))
+
+	cronJob, err := api.GetCronJob(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := api.listOwnedJobs(ctx, cronJob)
+	if err != nil {
+		return nil, err
+	}
+
+	return getJobHistory(jobs, limit, time.Now()), nil
+}
+
+// SetCronJobSuspended suspends or resumes a cronjob
+func (api *CronJobAPI) SetCronJobSuspended(ctx context.Context, namespace, name string, suspend bool) (*batchv1.CronJob, error) {
+	api.LogInfo(ctx, "SetCronJobSuspended", fmt.Sprintf("Setting suspend=%t on cronjob %s in namespace %s", suspend, name, namespace))
+
+	patch := fmt.Sprintf(`{"spec":{"suspend":%t}}`, suspend)
+	result, err := api.GetClientset().BatchV1().CronJobs(namespace).Patch(ctx, name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	if err != nil {
+		api.LogError(ctx, "SetCronJobSuspended", err)
+		return nil, api.HandleError(err, "suspend cronjob")
+	}
+
+	return result, nil
+}
+
+// TriggerCronJob creates a job from the cronjob template right away, regardless of schedule
+// or suspension, like `kubectl create job --from=cronjob/<name>`
+func (api *CronJobAPI) TriggerCronJob(ctx context.Context, namespace, name string) (*batchv1.Job, error) {
+	api.LogInfo(ctx, "TriggerCronJob", fmt.Sprintf("Triggering cronjob %s in namespace %s", name, namespace))
+
+	cronJob, err := api.GetCronJob(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := api.GetClientset().BatchV1().Jobs(namespace).Create(ctx, NewJobFromCronJob(cronJob, time.Now()), metav1.CreateOptions{})
+	if err != nil {
+		api.LogError(ctx, "TriggerCronJob", err)
+		return nil, api.HandleError(err, "trigger cronjob")
+	}
+
+	return result, nil
+}
+
+// DeleteCronJob deletes a specific cronjob together with its jobs
+func (api *CronJobAPI) DeleteCronJob(ctx context.Context, namespace, name string) error {
+	api.LogInfo(ctx, "DeleteCronJob", fmt.Sprintf("Deleting cronjob %s in namespace %s", name, namespace))
+
+	propagation := metav1.DeletePropagationBackground
+	err := api.GetClientset().BatchV1().CronJobs(namespace).Delete(ctx, name, metav1.DeleteOptions{
+		PropagationPolicy: &propagation,
+	})
+	if err != nil {
+		api.LogError(ctx, "DeleteCronJob", err)
+		return api.HandleError(err, "delete cronjob")
+	}
+
+	return nil
+}
+
+func (api *CronJobAPI) listOwnedJobs(ctx context.Context, cronJob *batchv1.CronJob) ([]batchv1.Job, error) {
+	jobs, err := api.GetClientset().BatchV1().Jobs(cronJob.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "listOwnedJobs", err)
+		return nil, api.HandleError(err, "list cronjob jobs")
+	}
+
+	var owned []batchv1.Job
+	for _, item := range jobs.Items {
+		for _, owner := range item.OwnerReferences {
+			if owner.UID == cronJob.UID {
+				owned = append(owned, item)
+				break
+			}
+		}
+	}
+	return owned, nil
+}
+
+// NewJobFromCronJob builds a manually instantiated job from the cronjob template
+func NewJobFromCronJob(cronJob *batchv1.CronJob, now time.Time) *batchv1.Job {
+	suffix := fmt.Sprintf("-manual-%d", now.Unix())
+	prefix := cronJob.Name
+	if max := 63 - len(suffix); len(prefix) > max {
+		prefix = prefix[:max]
+	}
+
+	annotations := map[string]string{instantiateAnnotation: "manual"}
+	for key, value := range cronJob.Spec.JobTemplate.Annotations {
+		annotations[key] = value
+	}
+	labels := make(map[string]string, len(cronJob.Spec.JobTemplate.Labels))
+	for key, value := range cronJob.Spec.JobTemplate.Labels {
+		labels[key] = value
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        prefix + suffix,
+			Namespace:   cronJob.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cronJob, batchv1.SchemeGroupVersion.WithKind("CronJob")),
+			},
+		},
+		Spec: *cronJob.Spec.JobTemplate.Spec.DeepCopy(),
+	}
+}
+
+// GetScheduleInfo computes the previous and upcoming run times of a cronjob from its cron
+// expression and time zone. Runs expected since the last scheduled run are reported as missed.
+func GetScheduleInfo(cronJob *batchv1.CronJob, now time.Time) map[string]interface{} {
+	suspended := cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend
+
+	info := map[string]interface{}{
+		"schedule":           cronJob.Spec.Schedule,
+		"timeZone":           cronJob.Spec.TimeZone,
+		"suspend":            suspended,
+		"lastScheduleTime":   cronJob.Status.LastScheduleTime,
+		"lastSuccessfulTime": cronJob.Status.LastSuccessfulTime,
+	}
+
+	schedule, location, err := parseSchedule(cronJob)
+	if err != nil {
+		info["scheduleError"] = err.Error()
+		return info
+	}
+	now = now.In(location)
+
+	// Walk forward from the last run (or creation) to find the most recent expected run
+	start := cronJob.CreationTimestamp.Time
+	if cronJob.Status.LastScheduleTime != nil {
+		start = cronJob.Status.LastScheduleTime.Time
+	}
+	var previous *time.Time
+	missed := 0
+	for t := schedule.Next(start.In(location)); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+		expected := t
+		previous = &expected
+		missed++
+		if missed > maxMissedSchedules {
+			info["tooManyMissed"] = true
+			break
+		}
+	}
+	if previous == nil && cronJob.Status.LastScheduleTime != nil {
+		last := cronJob.Status.LastScheduleTime.Time.In(location)
+		previous = &last
+	}
+	if previous != nil {
+		info["previousSchedule"] = *previous
+	}
+	info["missedSchedules"] = missed
+
+	var upcoming []time.Time
+	for t := schedule.Next(now); !t.IsZero() && len(upcoming) < upcomingSchedules; t = schedule.Next(t) {
+		upcoming = append(upcoming, t)
+	}
+	if len(upcoming) > 0 && !suspended {
+		info["nextSchedule"] = upcoming[0]
+	}
+	info["upcoming"] = upcoming
+
+	return info
+}
+
+// Helper functions
+
+func parseSchedule(cronJob *batchv1.CronJob) (cron.Schedule, *time.Location, error) {
+	// Without a time zone the controller manager uses its own local zone, which is UTC in
+	// practically every cluster
+	location := time.UTC
+	if cronJob.Spec.TimeZone != nil && *cronJob.Spec.TimeZone != "" {
+		loaded, err := time.LoadLocation(*cronJob.Spec.TimeZone)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid time zone %q: %v", *cronJob.Spec.TimeZone, err)
+		}
+		location = loaded
+	}
+
+	schedule, err := cron.ParseStandard(cronJob.Spec.Schedule)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid schedule %q: %v", cronJob.Spec.Schedule, err)
+	}
+
+	return schedule, location, nil
+}
+
+func getActiveJobs(cronJob *batchv1.CronJob) []string {
+	active := make([]string, 0, len(cronJob.Status.Active))
+	for _, ref := range cronJob.Status.Active {
+		active = append(active, ref.Name)
+	}
+	return active
+}
+
+func getJobHistory(jobs []batchv1.Job, limit int, now time.Time) []map[string]interface{} {
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[j].CreationTimestamp.Before(&jobs[i].CreationTimestamp)
+	})
+	if limit > 0 && len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+
+	history := make([]map[string]interface{}, 0, len(jobs))
+	for i := range jobs {
+		summary := job.GetJobSummary(&jobs[i], now)
+		summary["manual"] = jobs[i].Annotations[instantiateAnnotation] == "manual"
+		summary["links"] = map[string]string{
+			"job":    fmt.Sprintf("/api/v1/jobs/namespaces/%s/%s", jobs[i].Namespace, jobs[i].Name),
+			"status": fmt.Sprintf("/api/v1/jobs/namespaces/%s/%s/status", jobs[i].Namespace, jobs[i].Name),
+		}
+		history = append(history, summary)
+	}
+	return history
+}
@@ -0,0 +1,100 @@
+package cronjob
+
+import (
+	"context"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestCronJob(schedule string) *batchv1.CronJob {
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "report",
+			Namespace:         "team-a",
+			UID:               "cronjob-uid",
+			CreationTimestamp: metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule: schedule,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "report"}},
+			},
+		},
+	}
+}
+
+func TestGetScheduleInfo(t *testing.T) {
+	cronJob := newTestCronJob("0 * * * *")
+	last := metav1.NewTime(time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC))
+	cronJob.Status.LastScheduleTime = &last
+	now := time.Date(2024, 1, 2, 11, 30, 0, 0, time.UTC)
+
+	info := GetScheduleInfo(cronJob, now)
+
+	if got := info["nextSchedule"].(time.Time); !got.Equal(time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("nextSchedule = %v", got)
+	}
+	if got := info["previousSchedule"].(time.Time); !got.Equal(time.Date(2024, 1, 2, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("previousSchedule = %v", got)
+	}
+	if info["missedSchedules"] != 2 {
+		t.Errorf("missedSchedules = %v, want 2", info["missedSchedules"])
+	}
+	if upcoming := info["upcoming"].([]time.Time); len(upcoming) != upcomingSchedules {
+		t.Errorf("got %d upcoming runs, want %d", len(upcoming), upcomingSchedules)
+	}
+}
+
+func TestGetScheduleInfoTimeZoneAndSuspend(t *testing.T) {
+	cronJob := newTestCronJob("0 9 * * *")
+	zone := "Europe/Berlin"
+	suspend := true
+	cronJob.Spec.TimeZone = &zone
+	cronJob.Spec.Suspend = &suspend
+
+	info := GetScheduleInfo(cronJob, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	if _, ok := info["nextSchedule"]; ok {
+		t.Error("suspended cronjob should not report a next schedule")
+	}
+	first := info["upcoming"].([]time.Time)[0]
+	if !first.UTC().Equal(time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("first upcoming run = %v, want 08:00 UTC", first.UTC())
+	}
+}
+
+func TestGetScheduleInfoInvalid(t *testing.T) {
+	info := GetScheduleInfo(newTestCronJob("not a schedule"), time.Now())
+	if _, ok := info["scheduleError"]; !ok {
+		t.Errorf("expected scheduleError, got %v", info)
+	}
+}
+
+func TestTriggerCronJob(t *testing.T) {
+	api := NewCronJobAPI(fake.NewSimpleClientset(newTestCronJob("0 * * * *")), log.New(io.Discard, "", 0))
+
+	created, err := api.TriggerCronJob(context.Background(), "team-a", "report")
+	if err != nil {
+		t.Fatalf("TriggerCronJob: %v", err)
+	}
+	if created.Annotations[instantiateAnnotation] != "manual" || created.Labels["app"] != "report" {
+		t.Errorf("job metadata = %v / %v", created.Annotations, created.Labels)
+	}
+	if len(created.OwnerReferences) != 1 || created.OwnerReferences[0].UID != "cronjob-uid" {
+		t.Errorf("owner references = %v", created.OwnerReferences)
+	}
+
+	history, err := api.GetCronJobHistory(context.Background(), "team-a", "report", 10)
+	if err != nil {
+		t.Fatalf("GetCronJobHistory: %v", err)
+	}
+	if len(history) != 1 || history[0]["manual"] != true {
+		t.Errorf("history = %v", history)
+	}
+}
@@ -0,0 +1,244 @@
+package cronjob
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/client-go/kubernetes"
+)
+
+// defaultHistoryLimit is the number of recent jobs returned when no limit is given
+const defaultHistoryLimit = 10
+
+type Handler struct {
+	api *CronJobAPI
+}
+
+func NewHandler(clientset *kubernetes.Clientset, logger *log.Logger) *Handler {
+	if logger == nil {
+		logger = log.New(gin.DefaultWriter, "[CRONJOB-API] ", log.LstdFlags)
+	}
+
+	return &Handler{
+		api: NewCronJobAPI(clientset, logger),
+	}
+}
+
+// ListCronJobs handles GET /api/v1/cronjobs/namespaces/:namespace
+func (h *Handler) ListCronJobs(c *gin.Context) {
+	namespace := c.Param("namespace")
+	cronJobs, err := h.api.ListCronJobs(c.Request.Context(), namespace)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	now := time.Now()
+	var response []map[string]interface{}
+	for i := range cronJobs.Items {
+		cronJob := &cronJobs.Items[i]
+		schedule := GetScheduleInfo(cronJob, now)
+		response = append(response, map[string]interface{}{
+			"name":             cronJob.Name,
+			"namespace":        cronJob.Namespace,
+			"schedule":         cronJob.Spec.Schedule,
+			"timeZone":         cronJob.Spec.TimeZone,
+			"suspend":          schedule["suspend"],
+			"active":           len(cronJob.Status.Active),
+			"lastScheduleTime": cronJob.Status.LastScheduleTime,
+			"nextSchedule":     schedule["nextSchedule"],
+			"creationTime":     cronJob.CreationTimestamp,
+			"labels":           cronJob.Labels,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    response,
+	})
+}
+
+// GetCronJob handles GET /api/v1/cronjobs/namespaces/:namespace/:name
+func (h *Handler) GetCronJob(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	cronJob, err := h.api.GetCronJob(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"name":                       cronJob.Name,
+			"namespace":                  cronJob.Namespace,
+			"schedule":                   cronJob.Spec.Schedule,
+			"timeZone":                   cronJob.Spec.TimeZone,
+			"suspend":                    cronJob.Spec.Suspend,
+			"concurrencyPolicy":          cronJob.Spec.ConcurrencyPolicy,
+			"startingDeadlineSeconds":    cronJob.Spec.StartingDeadlineSeconds,
+			"successfulJobsHistoryLimit": cronJob.Spec.SuccessfulJobsHistoryLimit,
+			"failedJobsHistoryLimit":     cronJob.Spec.FailedJobsHistoryLimit,
+			"creationTime":               cronJob.CreationTimestamp,
+			"labels":                     cronJob.Labels,
+			"annotations":                cronJob.Annotations,
+			"containers":                 cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers,
+		},
+	})
+}
+
+// GetCronJobStatus handles GET /api/v1/cronjobs/namespaces/:namespace/:name/status
+func (h *Handler) GetCronJobStatus(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	limit, ok := historyLimit(c)
+	if !ok {
+		return
+	}
+
+	status, err := h.api.GetCronJobStatus(c.Request.Context(), namespace, name, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// GetCronJobHistory handles GET /api/v1/cronjobs/namespaces/:namespace/:name/jobs
+func (h *Handler) GetCronJobHistory(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	limit, ok := historyLimit(c)
+	if !ok {
+		return
+	}
+
+	history, err := h.api.GetCronJobHistory(c.Request.Context(), namespace, name, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    history,
+	})
+}
+
+// SuspendCronJob handles POST /api/v1/cronjobs/namespaces/:namespace/:name/suspend
+func (h *Handler) SuspendCronJob(c *gin.Context) {
+	h.setSuspended(c, true)
+}
+
+// ResumeCronJob handles POST /api/v1/cronjobs/namespaces/:namespace/:name/resume
+func (h *Handler) ResumeCronJob(c *gin.Context) {
+	h.setSuspended(c, false)
+}
+
+func (h *Handler) setSuspended(c *gin.Context, suspend bool) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	result, err := h.api.SetCronJobSuspended(c.Request.Context(), namespace, name, suspend)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	schedule := GetScheduleInfo(result, time.Now())
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"name":         result.Name,
+			"namespace":    result.Namespace,
+			"suspend":      schedule["suspend"],
+			"nextSchedule": schedule["nextSchedule"],
+		},
+	})
+}
+
+// TriggerCronJob handles POST /api/v1/cronjobs/namespaces/:namespace/:name/trigger
+func (h *Handler) TriggerCronJob(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	result, err := h.api.TriggerCronJob(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"job":       result.Name,
+			"namespace": result.Namespace,
+			"status":    "created",
+		},
+	})
+}
+
+// DeleteCronJob handles DELETE /api/v1/cronjobs/namespaces/:namespace/:name
+func (h *Handler) DeleteCronJob(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	err := h.api.DeleteCronJob(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "CronJob deleted successfully",
+	})
+}
+
+// Helper functions
+
+func historyLimit(c *gin.Context) (int, bool) {
+	value := c.Query("limit")
+	if value == "" {
+		return defaultHistoryLimit, true
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid limit value",
+		})
+		return 0, false
+	}
+	return limit, true
+}
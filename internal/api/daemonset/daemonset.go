@@ -0,0 +1,430 @@
+package daemonset
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s-glance-backend/internal/api/base"
+)
+
+// restartedAtAnnotation is the pod template annotation used by `kubectl rollout restart`
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// Per-node rollout states reported in the daemonset status
+const (
+	nodeUpToDate     = "upToDate"
+	nodeOutdated     = "outdated"
+	nodeMissing      = "missing"
+	nodeNotEligible  = "notEligible"
+	nodeMisscheduled = "misscheduled"
+)
+
+// daemonSetTolerations are the tolerations the DaemonSet controller adds to every daemon pod
+var daemonSetTolerations = []corev1.Toleration{
+	{Key: corev1.TaintNodeNotReady, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+	{Key: corev1.TaintNodeUnreachable, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+	{Key: corev1.TaintNodeDiskPressure, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	{Key: corev1.TaintNodeMemoryPressure, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	{Key: corev1.TaintNodePIDPressure, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	{Key: corev1.TaintNodeUnschedulable, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+}
+
+// DaemonSetAPI handles daemonset-related operations
+type DaemonSetAPI struct {
+	*base.BaseAPI
+}
+
+// NewDaemonSetAPI creates a new DaemonSetAPI instance
+func NewDaemonSetAPI(clientset kubernetes.Interface, logger *log.Logger) *DaemonSetAPI {
+	return &DaemonSetAPI{
+		BaseAPI: base.NewBaseAPI(clientset, logger),
+	}
+}
+
+// ListDaemonSets returns all daemonsets in a namespace
+func (api *DaemonSetAPI) ListDaemonSets(ctx context.Context, namespace string) (*appsv1.DaemonSetList, error) {
+	api.LogInfo(ctx, "ListDaemonSets", fmt.Sprintf("Fetching daemonsets in namespace: %s", namespace))
+
+	daemonSets, err := api.GetClientset().AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListDaemonSets", err)
+		return nil, api.HandleError(err, "list daemonsets")
+	}
+
+	return daemonSets, nil
+}
+
+// GetDaemonSet returns a specific daemonset
+func (api *DaemonSetAPI) GetDaemonSet(ctx context.Context, namespace, name string) (*appsv1.DaemonSet, error) {
+	api.LogInfo(ctx, "GetDaemonSet", fmt.Sprintf("Fetching daemonset %s in namespace %s", name, namespace))
+
+	daemonSet, err := api.GetClientset().AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetDaemonSet", err)
+		return nil, api.HandleError(err, "get daemonset")
+	}
+
+	return daemonSet, nil
+}
+
+// GetDaemonSetStatus returns detailed status of a daemonset, including the rollout state on every node
+func (api *DaemonSetAPI) GetDaemonSetStatus(ctx context.Context, namespace, name string) (*base.APIResponse, error) {
+	api.LogInfo(ctx, "GetDaemonSetStatus", fmt.Sprintf("Fetching status for daemonset %s in namespace %s", name, namespace))
+
+	daemonSet, err := api.GetDaemonSet(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(daemonSet.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector: %v", err)
+	}
+
+	pods, err := api.GetClientset().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		api.LogError(ctx, "GetDaemonSetStatus", err)
+		return nil, api.HandleError(err, "list daemonset pods")
+	}
+
+	nodes, err := api.GetClientset().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetDaemonSetStatus", err)
+		return nil, api.HandleError(err, "list nodes")
+	}
+
+	revisions, err := api.GetClientset().AppsV1().ControllerRevisions(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		api.LogError(ctx, "GetDaemonSetStatus", err)
+		return nil, api.HandleError(err, "list controllerrevisions")
+	}
+
+	var owned []corev1.Pod
+	for _, pod := range pods.Items {
+		if isOwnedBy(pod.OwnerReferences, daemonSet.UID) {
+			owned = append(owned, pod)
+		}
+	}
+
+	nodeStatus := getNodeStatus(daemonSet, updateRevisionHash(daemonSet, revisions.Items), nodes.Items, owned)
+	summary := make(map[string]int)
+	for _, entry := range nodeStatus {
+		summary[entry["state"].(string)]++
+	}
+
+	status := map[string]interface{}{
+		"counts": map[string]int32{
+			"desired":      daemonSet.Status.DesiredNumberScheduled,
+			"current":      daemonSet.Status.CurrentNumberScheduled,
+			"ready":        daemonSet.Status.NumberReady,
+			"updated":      daemonSet.Status.UpdatedNumberScheduled,
+			"available":    daemonSet.Status.NumberAvailable,
+			"unavailable":  daemonSet.Status.NumberUnavailable,
+			"misscheduled": daemonSet.Status.NumberMisscheduled,
+		},
+		"conditions":     getDaemonSetConditions(daemonSet.Status.Conditions),
+		"updateStrategy": getUpdateStrategy(daemonSet),
+		"nodes":          nodeStatus,
+		"nodeSummary":    summary,
+		"age":            daemonSet.CreationTimestamp.Time,
+	}
+
+	response := base.NewSuccessResponse(status)
+	return &response, nil
+}
+
+// UpdateDaemonSet updates an existing daemonset
+func (api *DaemonSetAPI) UpdateDaemonSet(ctx context.Context, namespace string, daemonSet *appsv1.DaemonSet) (*appsv1.DaemonSet, error) {
+	api.LogInfo(ctx, "UpdateDaemonSet", fmt.Sprintf("Updating daemonset %s in namespace %s", daemonSet.Name, namespace))
+
+	result, err := api.GetClientset().AppsV1().DaemonSets(namespace).Update(ctx, daemonSet, metav1.UpdateOptions{})
+	if err != nil {
+		api.LogError(ctx, "UpdateDaemonSet", err)
+		return nil, api.HandleError(err, "update daemonset")
+	}
+
+	return result, nil
+}
+
+// DeleteDaemonSet deletes a specific daemonset
+func (api *DaemonSetAPI) DeleteDaemonSet(ctx context.Context, namespace, name string) error {
+	api.LogInfo(ctx, "DeleteDaemonSet", fmt.Sprintf("Deleting daemonset %s in namespace %s", name, namespace))
+
+	err := api.GetClientset().AppsV1().DaemonSets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		api.LogError(ctx, "DeleteDaemonSet", err)
+		return api.HandleError(err, "delete daemonset")
+	}
+
+	return nil
+}
+
+// RestartDaemonSet triggers a rolling restart by stamping the pod template, like `kubectl rollout restart`
+func (api *DaemonSetAPI) RestartDaemonSet(ctx context.Context, namespace, name string) error {
+	api.LogInfo(ctx, "RestartDaemonSet", fmt.Sprintf("Restarting daemonset %s in namespace %s", name, namespace))
+
+	patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`,
+		restartedAtAnnotation, time.Now().Format(time.RFC3339))
+
+	_, err := api.GetClientset().AppsV1().DaemonSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+	if err != nil {
+		api.LogError(ctx, "RestartDaemonSet", err)
+		return api.HandleError(err, "restart daemonset")
+	}
+
+	return nil
+}
+
+// Helper functions
+
+func isOwnedBy(owners []metav1.OwnerReference, uid types.UID) bool {
+	for _, owner := range owners {
+		if owner.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+func getUpdateStrategy(daemonSet *appsv1.DaemonSet) map[string]interface{} {
+	strategy := map[string]interface{}{
+		"type": daemonSet.Spec.UpdateStrategy.Type,
+	}
+	if ru := daemonSet.Spec.UpdateStrategy.RollingUpdate; ru != nil {
+		if ru.MaxUnavailable != nil {
+			strategy["maxUnavailable"] = ru.MaxUnavailable.String()
+		}
+		if ru.MaxSurge != nil {
+			strategy["maxSurge"] = ru.MaxSurge.String()
+		}
+	}
+	return strategy
+}
+
+// updateRevisionHash returns the hash of the newest ControllerRevision owned by the daemonset,
+// which is the revision every daemon pod converges to
+func updateRevisionHash(daemonSet *appsv1.DaemonSet, revisions []appsv1.ControllerRevision) string {
+	var latest *appsv1.ControllerRevision
+	for i := range revisions {
+		if !isOwnedBy(revisions[i].OwnerReferences, daemonSet.UID) {
+			continue
+		}
+		if latest == nil || revisions[i].Revision > latest.Revision {
+			latest = &revisions[i]
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+	return latest.Labels[appsv1.DefaultDaemonSetUniqueLabelKey]
+}
+
+// getNodeStatus returns one entry per node describing whether the node should run a daemon pod,
+// whether it does, and whether that pod is on the update revision
+func getNodeStatus(daemonSet *appsv1.DaemonSet, updateRevision string, nodes []corev1.Node, pods []corev1.Pod) []map[string]interface{} {
+	podsByNode := make(map[string][]corev1.Pod)
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" && pod.DeletionTimestamp == nil {
+			podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], pod)
+		}
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+
+	result := make([]map[string]interface{}, 0, len(nodes))
+	for i := range nodes {
+		node := &nodes[i]
+		eligible, reasons := nodeEligibility(&daemonSet.Spec.Template.Spec, node)
+
+		entry := map[string]interface{}{
+			"node":     node.Name,
+			"eligible": eligible,
+			"ready":    isNodeReady(node),
+		}
+
+		nodePods := podsByNode[node.Name]
+		switch {
+		case len(nodePods) == 0 && !eligible:
+			entry["state"] = nodeNotEligible
+		case len(nodePods) == 0:
+			entry["state"] = nodeMissing
+			if !isNodeReady(node) {
+				reasons = append(reasons, "node is not ready")
+			} else {
+				reasons = append(reasons, "daemon pod has not been created yet")
+			}
+		default:
+			pod := nodePods[0]
+			upToDate := updateRevision != "" && pod.Labels[appsv1.DefaultDaemonSetUniqueLabelKey] == updateRevision
+			podReady := false
+			for _, condition := range pod.Status.Conditions {
+				if condition.Type == corev1.PodReady {
+					podReady = condition.Status == corev1.ConditionTrue
+				}
+			}
+
+			entry["pod"] = pod.Name
+			entry["phase"] = pod.Status.Phase
+			entry["podReady"] = podReady
+			entry["upToDate"] = upToDate
+
+			switch {
+			case !eligible:
+				entry["state"] = nodeMisscheduled
+			case upToDate:
+				entry["state"] = nodeUpToDate
+			default:
+				entry["state"] = nodeOutdated
+			}
+		}
+
+		entry["reasons"] = reasons
+		result = append(result, entry)
+	}
+	return result
+}
+
+// nodeEligibility reports whether the DaemonSet controller would place a pod on the node,
+// and if not, why. It covers node name, node selector, required node affinity and taints.
+func nodeEligibility(spec *corev1.PodSpec, node *corev1.Node) (bool, []string) {
+	reasons := []string{}
+
+	if spec.NodeName != "" && spec.NodeName != node.Name {
+		reasons = append(reasons, fmt.Sprintf("pod template is pinned to node %s", spec.NodeName))
+	}
+
+	if len(spec.NodeSelector) > 0 && !labels.SelectorFromSet(spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		reasons = append(reasons, "node selector does not match node labels")
+	}
+
+	if spec.Affinity != nil && spec.Affinity.NodeAffinity != nil {
+		if required := spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil {
+			if !matchesNodeSelectorTerms(node, required.NodeSelectorTerms) {
+				reasons = append(reasons, "required node affinity does not match")
+			}
+		}
+	}
+
+	tolerations := append(append([]corev1.Toleration{}, spec.Tolerations...), daemonSetTolerations...)
+	if spec.HostNetwork {
+		tolerations = append(tolerations, corev1.Toleration{
+			Key:      corev1.TaintNodeNetworkUnavailable,
+			Operator: corev1.TolerationOpExists,
+			Effect:   corev1.TaintEffectNoSchedule,
+		})
+	}
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		if !toleratesTaint(tolerations, taint) {
+			reasons = append(reasons, fmt.Sprintf("untolerated taint %s", taint.ToString()))
+		}
+	}
+
+	return len(reasons) == 0, reasons
+}
+
+func toleratesTaint(tolerations []corev1.Toleration, taint *corev1.Taint) bool {
+	for i := range tolerations {
+		if tolerations[i].ToleratesTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesNodeSelectorTerms reports whether the node matches any of the terms. Terms are ORed,
+// requirements within a term are ANDed.
+func matchesNodeSelectorTerms(node *corev1.Node, terms []corev1.NodeSelectorTerm) bool {
+	for _, term := range terms {
+		if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+			continue
+		}
+		if matchesNodeSelectorTerm(node, term) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesNodeSelectorTerm(node *corev1.Node, term corev1.NodeSelectorTerm) bool {
+	for _, expression := range term.MatchExpressions {
+		requirement, err := nodeSelectorRequirement(expression)
+		if err != nil || !requirement.Matches(labels.Set(node.Labels)) {
+			return false
+		}
+	}
+	for _, field := range term.MatchFields {
+		if field.Key != "metadata.name" {
+			return false
+		}
+		requirement, err := nodeSelectorRequirement(field)
+		if err != nil || !requirement.Matches(labels.Set{"metadata.name": node.Name}) {
+			return false
+		}
+	}
+	return true
+}
+
+func nodeSelectorRequirement(expression corev1.NodeSelectorRequirement) (*labels.Requirement, error) {
+	var op selection.Operator
+	switch expression.Operator {
+	case corev1.NodeSelectorOpIn:
+		op = selection.In
+	case corev1.NodeSelectorOpNotIn:
+		op = selection.NotIn
+	case corev1.NodeSelectorOpExists:
+		op = selection.Exists
+	case corev1.NodeSelectorOpDoesNotExist:
+		op = selection.DoesNotExist
+	case corev1.NodeSelectorOpGt:
+		op = selection.GreaterThan
+	case corev1.NodeSelectorOpLt:
+		op = selection.LessThan
+	default:
+		return nil, fmt.Errorf("unsupported node selector operator %q", expression.Operator)
+	}
+	return labels.NewRequirement(expression.Key, op, expression.Values)
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func getDaemonSetConditions(conditions []appsv1.DaemonSetCondition) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, condition := range conditions {
+		result = append(result, map[string]interface{}{
+			"type":               condition.Type,
+			"status":             condition.Status,
+			"lastTransitionTime": condition.LastTransitionTime,
+			"reason":             condition.Reason,
+			"message":            condition.Message,
+		})
+	}
+	return result
+}
@@ -0,0 +1,106 @@
+package daemonset
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestNode(name string, nodeLabels map[string]string, taints ...corev1.Taint) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels},
+		Spec:       corev1.NodeSpec{Taints: taints},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func newTestPod(name, node, revision string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{appsv1.DefaultDaemonSetUniqueLabelKey: revision},
+		},
+		Spec: corev1.PodSpec{NodeName: node},
+	}
+}
+
+func TestGetNodeStatus(t *testing.T) {
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent"},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{NodeSelector: map[string]string{"kubernetes.io/os": "linux"}},
+			},
+		},
+	}
+	linux := map[string]string{"kubernetes.io/os": "linux"}
+	nodes := []corev1.Node{
+		newTestNode("a-current", linux),
+		newTestNode("b-outdated", linux),
+		newTestNode("c-missing", linux),
+		newTestNode("d-windows", map[string]string{"kubernetes.io/os": "windows"}),
+		newTestNode("e-tainted", linux, corev1.Taint{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}),
+		newTestNode("f-cordoned", linux, corev1.Taint{Key: corev1.TaintNodeUnschedulable, Effect: corev1.TaintEffectNoSchedule}),
+	}
+	pods := []corev1.Pod{
+		newTestPod("agent-1", "a-current", "v2"),
+		newTestPod("agent-2", "b-outdated", "v1"),
+		newTestPod("agent-3", "d-windows", "v2"),
+		newTestPod("agent-4", "f-cordoned", "v2"),
+	}
+
+	status := getNodeStatus(daemonSet, "v2", nodes, pods)
+
+	got := make(map[string]string)
+	for _, entry := range status {
+		got[entry["node"].(string)] = entry["state"].(string)
+	}
+	want := map[string]string{
+		"a-current":  nodeUpToDate,
+		"b-outdated": nodeOutdated,
+		"c-missing":  nodeMissing,
+		"d-windows":  nodeMisscheduled,
+		"e-tainted":  nodeNotEligible,
+		"f-cordoned": nodeUpToDate,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("states = %v, want %v", got, want)
+	}
+
+	reasons := status[4]["reasons"].([]string)
+	if len(reasons) != 1 || reasons[0] != "untolerated taint dedicated=gpu:NoSchedule" {
+		t.Errorf("tainted node reasons = %v", reasons)
+	}
+}
+
+func TestNodeEligibilityAffinity(t *testing.T) {
+	spec := &corev1.PodSpec{
+		Affinity: &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{{
+							Key:      "node-role.kubernetes.io/control-plane",
+							Operator: corev1.NodeSelectorOpDoesNotExist,
+						}},
+					}},
+				},
+			},
+		},
+	}
+
+	worker := newTestNode("worker", nil)
+	if eligible, reasons := nodeEligibility(spec, &worker); !eligible {
+		t.Errorf("worker not eligible: %v", reasons)
+	}
+
+	controlPlane := newTestNode("control-plane", map[string]string{"node-role.kubernetes.io/control-plane": ""})
+	if eligible, _ := nodeEligibility(spec, &controlPlane); eligible {
+		t.Error("control plane node should not be eligible")
+	}
+}
@@ -0,0 +1,260 @@
+package daemonset
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+)
+
+type Handler struct {
+	api *DaemonSetAPI
+}
+
+func NewHandler(clientset *kubernetes.Clientset, logger *log.Logger) *Handler {
+	if logger == nil {
+		logger = log.New(gin.DefaultWriter, "[DAEMONSET-API] ", log.LstdFlags)
+	}
+
+	return &Handler{
+		api: NewDaemonSetAPI(clientset, logger),
+	}
+}
+
+// ListDaemonSets handles GET /api/v1/daemonsets/namespaces/:namespace
+func (h *Handler) ListDaemonSets(c *gin.Context) {
+	namespace := c.Param("namespace")
+	daemonSets, err := h.api.ListDaemonSets(c.Request.Context(), namespace)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	var response []map[string]interface{}
+	for _, daemonSet := range daemonSets.Items {
+		response = append(response, map[string]interface{}{
+			"name":           daemonSet.Name,
+			"namespace":      daemonSet.Namespace,
+			"desired":        daemonSet.Status.DesiredNumberScheduled,
+			"current":        daemonSet.Status.CurrentNumberScheduled,
+			"ready":          daemonSet.Status.NumberReady,
+			"updated":        daemonSet.Status.UpdatedNumberScheduled,
+			"misscheduled":   daemonSet.Status.NumberMisscheduled,
+			"creationTime":   daemonSet.CreationTimestamp,
+			"labels":         daemonSet.Labels,
+			"updateStrategy": daemonSet.Spec.UpdateStrategy.Type,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    response,
+	})
+}
+
+// GetDaemonSet handles GET /api/v1/daemonsets/namespaces/:namespace/:name
+func (h *Handler) GetDaemonSet(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	daemonSet, err := h.api.GetDaemonSet(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"name":           daemonSet.Name,
+			"namespace":      daemonSet.Namespace,
+			"desired":        daemonSet.Status.DesiredNumberScheduled,
+			"ready":          daemonSet.Status.NumberReady,
+			"updateStrategy": getUpdateStrategy(daemonSet),
+			"selector":       daemonSet.Spec.Selector,
+			"nodeSelector":   daemonSet.Spec.Template.Spec.NodeSelector,
+			"tolerations":    daemonSet.Spec.Template.Spec.Tolerations,
+			"creationTime":   daemonSet.CreationTimestamp,
+			"labels":         daemonSet.Labels,
+			"annotations":    daemonSet.Annotations,
+			"containers":     daemonSet.Spec.Template.Spec.Containers,
+		},
+	})
+}
+
+// GetDaemonSetStatus handles GET /api/v1/daemonsets/namespaces/:namespace/:name/status
+func (h *Handler) GetDaemonSetStatus(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	status, err := h.api.GetDaemonSetStatus(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// UpdateDaemonSet handles PUT /api/v1/daemonsets/namespaces/:namespace/:name
+func (h *Handler) UpdateDaemonSet(c *gin.Context) {
+	var updateRequest struct {
+		Image          string              `json:"image"`
+		Labels         map[string]string   `json:"labels"`
+		Annotations    map[string]string   `json:"annotations"`
+		EnvVars        []map[string]string `json:"envVars"`
+		NodeSelector   map[string]string   `json:"nodeSelector"`
+		Tolerations    []corev1.Toleration `json:"tolerations"`
+		UpdateStrategy string              `json:"updateStrategy"`
+		MaxUnavailable string              `json:"maxUnavailable"`
+	}
+
+	if err := c.ShouldBindJSON(&updateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	// Get existing daemonset
+	existing, err := h.api.GetDaemonSet(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Update fields if provided
+	if updateRequest.UpdateStrategy != "" {
+		strategy := appsv1.DaemonSetUpdateStrategyType(updateRequest.UpdateStrategy)
+		if strategy != appsv1.RollingUpdateDaemonSetStrategyType && strategy != appsv1.OnDeleteDaemonSetStrategyType {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   fmt.Sprintf("updateStrategy must be %s or %s", appsv1.RollingUpdateDaemonSetStrategyType, appsv1.OnDeleteDaemonSetStrategyType),
+			})
+			return
+		}
+		existing.Spec.UpdateStrategy.Type = strategy
+		if strategy == appsv1.OnDeleteDaemonSetStrategyType {
+			existing.Spec.UpdateStrategy.RollingUpdate = nil
+		}
+	}
+	if updateRequest.MaxUnavailable != "" {
+		if existing.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   fmt.Sprintf("maxUnavailable requires the %s update strategy", appsv1.RollingUpdateDaemonSetStrategyType),
+			})
+			return
+		}
+		maxUnavailable := intstr.Parse(updateRequest.MaxUnavailable)
+		if existing.Spec.UpdateStrategy.RollingUpdate == nil {
+			existing.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateDaemonSet{}
+		}
+		existing.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable = &maxUnavailable
+	}
+	if updateRequest.Image != "" {
+		existing.Spec.Template.Spec.Containers[0].Image = updateRequest.Image
+	}
+	if updateRequest.Labels != nil {
+		existing.Labels = updateRequest.Labels
+	}
+	if updateRequest.Annotations != nil {
+		existing.Annotations = updateRequest.Annotations
+	}
+	if updateRequest.NodeSelector != nil {
+		existing.Spec.Template.Spec.NodeSelector = updateRequest.NodeSelector
+	}
+	if updateRequest.Tolerations != nil {
+		existing.Spec.Template.Spec.Tolerations = updateRequest.Tolerations
+	}
+	if len(updateRequest.EnvVars) > 0 {
+		var envVars []corev1.EnvVar
+		for _, env := range updateRequest.EnvVars {
+			envVars = append(envVars, corev1.EnvVar{
+				Name:  env["name"],
+				Value: env["value"],
+			})
+		}
+		existing.Spec.Template.Spec.Containers[0].Env = envVars
+	}
+
+	result, err := h.api.UpdateDaemonSet(c.Request.Context(), namespace, existing)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"name":           result.Name,
+			"namespace":      result.Namespace,
+			"updateStrategy": getUpdateStrategy(result),
+			"status":         "updated",
+		},
+	})
+}
+
+// DeleteDaemonSet handles DELETE /api/v1/daemonsets/namespaces/:namespace/:name
+func (h *Handler) DeleteDaemonSet(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	err := h.api.DeleteDaemonSet(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "DaemonSet deleted successfully",
+	})
+}
+
+// RestartDaemonSet handles POST /api/v1/daemonsets/namespaces/:namespace/:name/restart
+func (h *Handler) RestartDaemonSet(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	err := h.api.RestartDaemonSet(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "DaemonSet restart triggered",
+	})
+}
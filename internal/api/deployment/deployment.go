@@ -2,23 +2,29 @@ package deployment
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 
 	"k8s-glance-backend/internal/api/base"
+	"k8s-glance-backend/internal/api/hpa"
 )
 
+// ErrManagedByAutoscaler is returned when scaling a deployment whose replicas are owned by a HorizontalPodAutoscaler
+var ErrManagedByAutoscaler = errors.New("deployment is managed by a horizontal pod autoscaler")
+
 // DeploymentAPI handles deployment-related operations
 type DeploymentAPI struct {
 	*base.BaseAPI
 }
 
 // NewDeploymentAPI creates a new DeploymentAPI instance
-func NewDeploymentAPI(clientset *kubernetes.Clientset, logger *log.Logger) *DeploymentAPI {
+func NewDeploymentAPI(clientset kubernetes.Interface, logger *log.Logger) *DeploymentAPI {
 	return &DeploymentAPI{
 		BaseAPI: base.NewBaseAPI(clientset, logger),
 	}
@@ -59,6 +65,17 @@ func (api *DeploymentAPI) GetDeploymentStatus(ctx context.Context, namespace, na
 		return nil, err
 	}
 
+	autoscaler, err := hpa.FindAutoscalerForTarget(ctx, api.GetClientset(), namespace, "Deployment", name)
+	if err != nil {
+		api.LogError(ctx, "GetDeploymentStatus", err)
+		return nil, api.HandleError(err, "list horizontalpodautoscalers")
+	}
+
+	var autoscalerSummary map[string]interface{}
+	if autoscaler != nil {
+		autoscalerSummary = hpa.GetAutoscalerSummary(autoscaler)
+	}
+
 	status := map[string]interface{}{
 		"replicas": map[string]int32{
 			"desired":   *deployment.Spec.Replicas,
@@ -70,6 +87,7 @@ func (api *DeploymentAPI) GetDeploymentStatus(ctx context.Context, namespace, na
 		"conditions": getDeploymentConditions(deployment.Status.Conditions),
 		"strategy":   deployment.Spec.Strategy.Type,
 		"age":        deployment.CreationTimestamp.Time,
+		"autoscaler": autoscalerSummary,
 	}
 
 	response := base.NewSuccessResponse(status)
@@ -89,13 +107,20 @@ func (api *DeploymentAPI) DeleteDeployment(ctx context.Context, namespace, name
 	return nil
 }
 
-// ScaleDeployment scales a deployment to the specified number of replicas
-func (api *DeploymentAPI) ScaleDeployment(ctx context.Context, namespace, name string, replicas int32) error {
+// ScaleDeployment scales a deployment to the specified number of replicas.
+// If an autoscaler targets the deployment, ErrManagedByAutoscaler is returned unless force is set;
+// the autoscaler is returned either way so callers can warn that it will override the new count.
+func (api *DeploymentAPI) ScaleDeployment(ctx context.Context, namespace, name string, replicas int32, force bool) (*autoscalingv2.HorizontalPodAutoscaler, error) {
 	api.LogInfo(ctx, "ScaleDeployment", fmt.Sprintf("Scaling deployment %s in namespace %s to %d replicas", name, namespace, replicas))
 
 	deployment, err := api.GetDeployment(ctx, namespace, name)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	autoscaler, err := api.CheckAutoscaler(ctx, namespace, name, force)
+	if err != nil {
+		return autoscaler, err
 	}
 
 	deployment.Spec.Replicas = &replicas
@@ -103,10 +128,24 @@ func (api *DeploymentAPI) ScaleDeployment(ctx context.Context, namespace, name s
 	_, err = api.GetClientset().AppsV1().Deployments(namespace).Update(ctx, deployment, metav1.UpdateOptions{})
 	if err != nil {
 		api.LogError(ctx, "ScaleDeployment", err)
-		return api.HandleError(err, "scale deployment")
+		return autoscaler, api.HandleError(err, "scale deployment")
 	}
 
-	return nil
+	return autoscaler, nil
+}
+
+// CheckAutoscaler returns the autoscaler targeting a deployment, if any, before its replicas are
+// changed. ErrManagedByAutoscaler is returned together with the autoscaler unless force is set.
+func (api *DeploymentAPI) CheckAutoscaler(ctx context.Context, namespace, name string, force bool) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	autoscaler, err := hpa.FindAutoscalerForTarget(ctx, api.GetClientset(), namespace, "Deployment", name)
+	if err != nil {
+		api.LogError(ctx, "CheckAutoscaler", err)
+		return nil, api.HandleError(err, "list horizontalpodautoscalers")
+	}
+	if autoscaler != nil && !force {
+		return autoscaler, fmt.Errorf("%w %s; pass force=true to scale anyway", ErrManagedByAutoscaler, autoscaler.Name)
+	}
+	return autoscaler, nil
 }
 
 // CreateDeployment creates a new deployment
@@ -0,0 +1,65 @@
+package deployment
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestScaleDeploymentManagedByAutoscaler(t *testing.T) {
+	replicas := int32(2)
+	clientset := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		},
+		&autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-hpa", Namespace: "team-a"},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: "web"},
+				MaxReplicas:    5,
+			},
+		},
+	)
+	api := NewDeploymentAPI(clientset, log.New(io.Discard, "", 0))
+	ctx := context.Background()
+
+	if _, err := api.ScaleDeployment(ctx, "team-a", "web", 4, false); !errors.Is(err, ErrManagedByAutoscaler) {
+		t.Fatalf("err = %v, want ErrManagedByAutoscaler", err)
+	}
+	// Updates that change the replicas go through the same check
+	if autoscaler, err := api.CheckAutoscaler(ctx, "team-a", "web", false); !errors.Is(err, ErrManagedByAutoscaler) || autoscaler == nil {
+		t.Fatalf("CheckAutoscaler: autoscaler = %v, err = %v, want web-hpa and ErrManagedByAutoscaler", autoscaler, err)
+	}
+	if autoscaler, err := api.CheckAutoscaler(ctx, "team-a", "api", false); err != nil || autoscaler != nil {
+		t.Errorf("CheckAutoscaler without autoscaler: autoscaler = %v, err = %v", autoscaler, err)
+	}
+
+	autoscaler, err := api.ScaleDeployment(ctx, "team-a", "web", 4, true)
+	if err != nil {
+		t.Fatalf("forced ScaleDeployment: %v", err)
+	}
+	if autoscaler == nil || autoscaler.Name != "web-hpa" {
+		t.Errorf("autoscaler = %v, want web-hpa", autoscaler)
+	}
+
+	deployment, _ := api.GetDeployment(ctx, "team-a", "web")
+	if *deployment.Spec.Replicas != 4 {
+		t.Errorf("replicas = %d, want 4", *deployment.Spec.Replicas)
+	}
+
+	status, err := api.GetDeploymentStatus(ctx, "team-a", "web")
+	if err != nil {
+		t.Fatalf("GetDeploymentStatus: %v", err)
+	}
+	if summary := status.Data.(map[string]interface{})["autoscaler"].(map[string]interface{}); summary["name"] != "web-hpa" {
+		t.Errorf("autoscaler summary = %v", summary)
+	}
+}
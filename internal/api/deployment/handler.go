@@ -1,6 +1,7 @@
 package deployment
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -108,7 +110,8 @@ func (h *Handler) CreateDeployment(c *gin.Context) {
 	})
 }
 
-// UpdateDeployment handles PUT /api/v1/deployments/namespaces/:namespace/:name
+// UpdateDeployment handles PUT /api/v1/deployments/namespaces/:namespace/:name?force=.
+// Changing replicas is refused like ScaleDeployment when an autoscaler manages the deployment.
 func (h *Handler) UpdateDeployment(c *gin.Context) {
 	var updateRequest struct {
 		Image       string              `json:"image"`
@@ -139,6 +142,22 @@ func (h *Handler) UpdateDeployment(c *gin.Context) {
 		return
 	}
 
+	var autoscaler *autoscalingv2.HorizontalPodAutoscaler
+	if updateRequest.Replicas != nil {
+		autoscaler, err = h.api.CheckAutoscaler(c.Request.Context(), namespace, name, c.Query("force") == "true")
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, ErrManagedByAutoscaler) {
+				status = http.StatusConflict
+			}
+			c.JSON(status, gin.H{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+	}
+
 	// Update fields if provided
 	if updateRequest.Image != "" {
 		existing.Spec.Template.Spec.Containers[0].Image = updateRequest.Image
@@ -172,14 +191,19 @@ func (h *Handler) UpdateDeployment(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"success": true,
 		"data": map[string]interface{}{
 			"name":      result.Name,
 			"namespace": result.Namespace,
 			"status":    "updated",
 		},
-	})
+	}
+	if autoscaler != nil {
+		response["warning"] = autoscalerWarning(autoscaler)
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 func NewHandler(clientset *kubernetes.Clientset, logger *log.Logger) *Handler {
@@ -291,7 +315,7 @@ func (h *Handler) DeleteDeployment(c *gin.Context) {
 	})
 }
 
-// ScaleDeployment handles PUT /api/v1/deployments/namespaces/:namespace/:name/scale
+// ScaleDeployment handles PUT /api/v1/deployments/namespaces/:namespace/:name/scale?replicas=&force=
 func (h *Handler) ScaleDeployment(c *gin.Context) {
 	namespace := c.Param("namespace")
 	name := c.Param("name")
@@ -306,17 +330,34 @@ func (h *Handler) ScaleDeployment(c *gin.Context) {
 		return
 	}
 
-	err = h.api.ScaleDeployment(c.Request.Context(), namespace, name, int32(replicas))
+	force := c.Query("force") == "true"
+
+	autoscaler, err := h.api.ScaleDeployment(c.Request.Context(), namespace, name, int32(replicas), force)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrManagedByAutoscaler) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
 			"success": false,
 			"error":   err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"success": true,
 		"message": fmt.Sprintf("Deployment scaled to %d replicas", replicas),
-	})
+	}
+	if autoscaler != nil {
+		response["warning"] = autoscalerWarning(autoscaler)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Helper functions
+
+func autoscalerWarning(autoscaler *autoscalingv2.HorizontalPodAutoscaler) string {
+	return fmt.Sprintf("HorizontalPodAutoscaler %s manages this deployment and may override the replica count", autoscaler.Name)
 }
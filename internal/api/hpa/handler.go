@@ -0,0 +1,239 @@
+package hpa
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type Handler struct {
+	api *HPAAPI
+}
+
+func NewHandler(clientset *kubernetes.Clientset, logger *log.Logger) *Handler {
+	if logger == nil {
+		logger = log.New(gin.DefaultWriter, "[HPA-API] ", log.LstdFlags)
+	}
+
+	return &Handler{
+		api: NewHPAAPI(clientset, logger),
+	}
+}
+
+// ListAutoscalers handles GET /api/v1/hpas/namespaces/:namespace
+func (h *Handler) ListAutoscalers(c *gin.Context) {
+	namespace := c.Param("namespace")
+	autoscalers, err := h.api.ListAutoscalers(c.Request.Context(), namespace)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	var response []map[string]interface{}
+	for i := range autoscalers.Items {
+		summary := GetAutoscalerSummary(&autoscalers.Items[i])
+		summary["creationTime"] = autoscalers.Items[i].CreationTimestamp
+		response = append(response, summary)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    response,
+	})
+}
+
+// GetAutoscaler handles GET /api/v1/hpas/namespaces/:namespace/:name
+func (h *Handler) GetAutoscaler(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	autoscaler, err := h.api.GetAutoscaler(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	data := GetAutoscalerSummary(autoscaler)
+	data["metrics"] = autoscaler.Spec.Metrics
+	data["behavior"] = autoscaler.Spec.Behavior
+	data["labels"] = autoscaler.Labels
+	data["annotations"] = autoscaler.Annotations
+	data["creationTime"] = autoscaler.CreationTimestamp
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+	})
+}
+
+// GetAutoscalerStatus handles GET /api/v1/hpas/namespaces/:namespace/:name/status
+func (h *Handler) GetAutoscalerStatus(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	status, err := h.api.GetAutoscalerStatus(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// CreateAutoscaler handles POST /api/v1/hpas/namespaces/:namespace
+func (h *Handler) CreateAutoscaler(c *gin.Context) {
+	var createRequest struct {
+		Name string `json:"name" binding:"required"`
+		AutoscalerRequest
+	}
+
+	if err := c.ShouldBindJSON(&createRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	if createRequest.TargetName == "" || createRequest.MaxReplicas == nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "targetName and maxReplicas are required",
+		})
+		return
+	}
+	if err := createRequest.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	namespace := c.Param("namespace")
+
+	autoscaler := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      createRequest.Name,
+			Namespace: namespace,
+		},
+	}
+	if err := createRequest.Apply(autoscaler); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	result, err := h.api.CreateAutoscaler(c.Request.Context(), namespace, autoscaler)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"name":      result.Name,
+			"namespace": result.Namespace,
+			"status":    "created",
+		},
+	})
+}
+
+// UpdateAutoscaler handles PUT /api/v1/hpas/namespaces/:namespace/:name
+func (h *Handler) UpdateAutoscaler(c *gin.Context) {
+	var updateRequest AutoscalerRequest
+
+	if err := c.ShouldBindJSON(&updateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	if err := updateRequest.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	// Get existing autoscaler
+	existing, err := h.api.GetAutoscaler(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if err := updateRequest.Apply(existing); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	result, err := h.api.UpdateAutoscaler(c.Request.Context(), namespace, existing)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"name":      result.Name,
+			"namespace": result.Namespace,
+			"status":    "updated",
+		},
+	})
+}
+
+// DeleteAutoscaler handles DELETE /api/v1/hpas/namespaces/:namespace/:name
+func (h *Handler) DeleteAutoscaler(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	err := h.api.DeleteAutoscaler(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "HorizontalPodAutoscaler deleted successfully",
+	})
+}
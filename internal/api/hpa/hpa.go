@@ -0,0 +1,415 @@
+package hpa
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s-glance-backend/internal/api/base"
+)
+
+// scaleTargetKinds are the workload kinds an autoscaler can be created for
+var scaleTargetKinds = map[string]string{
+	"Deployment":  "apps/v1",
+	"StatefulSet": "apps/v1",
+	"ReplicaSet":  "apps/v1",
+}
+
+// HPAAPI handles HorizontalPodAutoscaler operations
+type HPAAPI struct {
+	*base.BaseAPI
+}
+
+// NewHPAAPI creates a new HPAAPI instance
+func NewHPAAPI(clientset kubernetes.Interface, logger *log.Logger) *HPAAPI {
+	return &HPAAPI{
+		BaseAPI: base.NewBaseAPI(clientset, logger),
+	}
+}
+
+// AutoscalerRequest describes an autoscaler to create or the fields of one to update.
+// CPUUtilization and MemoryUtilization are shorthands for average utilization resource
+// metrics; Metrics replaces the metric list as a whole.
+type AutoscalerRequest struct {
+	TargetKind        string                     `json:"targetKind"`
+	TargetName        string                     `json:"targetName"`
+	MinReplicas       *int32                     `json:"minReplicas"`
+	MaxReplicas       *int32                     `json:"maxReplicas"`
+	CPUUtilization    *int32                     `json:"cpuUtilization"`
+	MemoryUtilization *int32                     `json:"memoryUtilization"`
+	Metrics           []autoscalingv2.MetricSpec `json:"metrics"`
+	Labels            map[string]string          `json:"labels"`
+	Annotations       map[string]string          `json:"annotations"`
+}
+
+// Validate checks the request fields that are set
+func (r *AutoscalerRequest) Validate() error {
+	if r.TargetKind != "" {
+		if _, ok := scaleTargetKinds[r.TargetKind]; !ok {
+			return fmt.Errorf("targetKind must be one of Deployment, StatefulSet or ReplicaSet")
+		}
+	}
+	if r.MinReplicas != nil && *r.MinReplicas < 1 {
+		return fmt.Errorf("minReplicas must be at least 1")
+	}
+	if r.MaxReplicas != nil && *r.MaxReplicas < 1 {
+		return fmt.Errorf("maxReplicas must be at least 1")
+	}
+	if r.MinReplicas != nil && r.MaxReplicas != nil && *r.MinReplicas > *r.MaxReplicas {
+		return fmt.Errorf("minReplicas must not exceed maxReplicas")
+	}
+	for field, value := range map[string]*int32{"cpuUtilization": r.CPUUtilization, "memoryUtilization": r.MemoryUtilization} {
+		if value != nil && *value < 1 {
+			return fmt.Errorf("%s must be a positive percentage", field)
+		}
+	}
+	return nil
+}
+
+// Apply sets the fields of the request on an autoscaler
+func (r *AutoscalerRequest) Apply(hpa *autoscalingv2.HorizontalPodAutoscaler) error {
+	if r.TargetKind != "" || r.TargetName != "" {
+		kind := r.TargetKind
+		if kind == "" {
+			kind = hpa.Spec.ScaleTargetRef.Kind
+		}
+		if kind == "" {
+			kind = "Deployment"
+		}
+		name := r.TargetName
+		if name == "" {
+			name = hpa.Spec.ScaleTargetRef.Name
+		}
+		hpa.Spec.ScaleTargetRef = autoscalingv2.CrossVersionObjectReference{
+			APIVersion: scaleTargetKinds[kind],
+			Kind:       kind,
+			Name:       name,
+		}
+	}
+	if r.MinReplicas != nil {
+		hpa.Spec.MinReplicas = r.MinReplicas
+	}
+	if r.MaxReplicas != nil {
+		hpa.Spec.MaxReplicas = *r.MaxReplicas
+	}
+	if r.Metrics != nil {
+		hpa.Spec.Metrics = r.Metrics
+	}
+	if r.CPUUtilization != nil {
+		hpa.Spec.Metrics = setResourceUtilization(hpa.Spec.Metrics, corev1.ResourceCPU, *r.CPUUtilization)
+	}
+	if r.MemoryUtilization != nil {
+		hpa.Spec.Metrics = setResourceUtilization(hpa.Spec.Metrics, corev1.ResourceMemory, *r.MemoryUtilization)
+	}
+	if r.Labels != nil {
+		hpa.Labels = r.Labels
+	}
+	if r.Annotations != nil {
+		hpa.Annotations = r.Annotations
+	}
+
+	min := int32(1)
+	if hpa.Spec.MinReplicas != nil {
+		min = *hpa.Spec.MinReplicas
+	}
+	if hpa.Spec.MaxReplicas < min {
+		return fmt.Errorf("maxReplicas (%d) must not be lower than minReplicas (%d)", hpa.Spec.MaxReplicas, min)
+	}
+	return nil
+}
+
+// ListAutoscalers returns all autoscalers in a namespace
+func (api *HPAAPI) ListAutoscalers(ctx context.Context, namespace string) (*autoscalingv2.HorizontalPodAutoscalerList, error) {
+	api.LogInfo(ctx, "ListAutoscalers", fmt.Sprintf("Fetching horizontalpodautoscalers in namespace: %s", namespace))
+
+	autoscalers, err := api.GetClientset().AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListAutoscalers", err)
+		return nil, api.HandleError(err, "list horizontalpodautoscalers")
+	}
+
+	return autoscalers, nil
+}
+
+// GetAutoscaler returns a specific autoscaler
+func (api *HPAAPI) GetAutoscaler(ctx context.Context, namespace, name string) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	api.LogInfo(ctx, "GetAutoscaler", fmt.Sprintf("Fetching horizontalpodautoscaler %s in namespace %s", name, namespace))
+
+	autoscaler, err := api.GetClientset().AutoscalingV2().HorizontalPodAutoscalers(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetAutoscaler", err)
+		return nil, api.HandleError(err, "get horizontalpodautoscaler")
+	}
+
+	return autoscaler, nil
+}
+
+// GetAutoscalerStatus returns the replica counts, metrics and conditions of an autoscaler
+func (api *HPAAPI) GetAutoscalerStatus(ctx context.Context, namespace, name string) (*base.APIResponse, error) {
+	api.LogInfo(ctx, "GetAutoscalerStatus", fmt.Sprintf("Fetching status for horizontalpodautoscaler %s in namespace %s", name, namespace))
+
+	autoscaler, err := api.GetAutoscaler(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	status := GetAutoscalerSummary(autoscaler)
+	status["metrics"] = getMetrics(autoscaler)
+	status["conditions"] = getAutoscalerConditions(autoscaler.Status.Conditions)
+	status["lastScaleTime"] = autoscaler.Status.LastScaleTime
+
+	response := base.NewSuccessResponse(status)
+	return &response, nil
+}
+
+// CreateAutoscaler creates a new autoscaler
+func (api *HPAAPI) CreateAutoscaler(ctx context.Context, namespace string, autoscaler *autoscalingv2.HorizontalPodAutoscaler) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	api.LogInfo(ctx, "CreateAutoscaler", fmt.Sprintf("Creating horizontalpodautoscaler %s in namespace %s", autoscaler.Name, namespace))
+
+	result, err := api.GetClientset().AutoscalingV2().HorizontalPodAutoscalers(namespace).Create(ctx, autoscaler, metav1.CreateOptions{})
+	if err != nil {
+		api.LogError(ctx, "CreateAutoscaler", err)
+		return nil, api.HandleError(err, "create horizontalpodautoscaler")
+	}
+
+	return result, nil
+}
+
+// UpdateAutoscaler updates an existing autoscaler
+func (api *HPAAPI) UpdateAutoscaler(ctx context.Context, namespace string, autoscaler *autoscalingv2.HorizontalPodAutoscaler) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	api.LogInfo(ctx, "UpdateAutoscaler", fmt.Sprintf("Updating horizontalpodautoscaler %s in namespace %s", autoscaler.Name, namespace))
+
+	result, err := api.GetClientset().AutoscalingV2().HorizontalPodAutoscalers(namespace).Update(ctx, autoscaler, metav1.UpdateOptions{})
+	if err != nil {
+		api.LogError(ctx, "UpdateAutoscaler", err)
+		return nil, api.HandleError(err, "update horizontalpodautoscaler")
+	}
+
+	return result, nil
+}
+
+// DeleteAutoscaler deletes a specific autoscaler
+func (api *HPAAPI) DeleteAutoscaler(ctx context.Context, namespace, name string) error {
+	api.LogInfo(ctx, "DeleteAutoscaler", fmt.Sprintf("Deleting horizontalpodautoscaler %s in namespace %s", name, namespace))
+
+	err := api.GetClientset().AutoscalingV2().HorizontalPodAutoscalers(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		api.LogError(ctx, "DeleteAutoscaler", err)
+		return api.HandleError(err, "delete horizontalpodautoscaler")
+	}
+
+	return nil
+}
+
+// FindAutoscalerForTarget returns the autoscaler that scales the given workload, or nil if there is none
+func FindAutoscalerForTarget(ctx context.Context, clientset kubernetes.Interface, namespace, kind, name string) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	autoscalers, err := clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(autoscalers.Items, func(i, j int) bool {
+		return autoscalers.Items[i].Name < autoscalers.Items[j].Name
+	})
+	for i := range autoscalers.Items {
+		ref := autoscalers.Items[i].Spec.ScaleTargetRef
+		if ref.Kind == kind && ref.Name == name {
+			return &autoscalers.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// GetAutoscalerSummary returns the target and replica counts of an autoscaler
+func GetAutoscalerSummary(autoscaler *autoscalingv2.HorizontalPodAutoscaler) map[string]interface{} {
+	min := int32(1)
+	if autoscaler.Spec.MinReplicas != nil {
+		min = *autoscaler.Spec.MinReplicas
+	}
+
+	return map[string]interface{}{
+		"name":      autoscaler.Name,
+		"namespace": autoscaler.Namespace,
+		"target": map[string]string{
+			"kind": autoscaler.Spec.ScaleTargetRef.Kind,
+			"name": autoscaler.Spec.ScaleTargetRef.Name,
+		},
+		"minReplicas":     min,
+		"maxReplicas":     autoscaler.Spec.MaxReplicas,
+		"currentReplicas": autoscaler.Status.CurrentReplicas,
+		"desiredReplicas": autoscaler.Status.DesiredReplicas,
+	}
+}
+
+// Helper functions
+
+func setResourceUtilization(metrics []autoscalingv2.MetricSpec, name corev1.ResourceName, utilization int32) []autoscalingv2.MetricSpec {
+	metric := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: &utilization,
+			},
+		},
+	}
+
+	for i := range metrics {
+		if metrics[i].Type == autoscalingv2.ResourceMetricSourceType && metrics[i].Resource != nil && metrics[i].Resource.Name == name {
+			metrics[i] = metric
+			return metrics
+		}
+	}
+	return append(metrics, metric)
+}
+
+// getMetrics pairs every metric of the spec with its current value from the status
+func getMetrics(autoscaler *autoscalingv2.HorizontalPodAutoscaler) []map[string]interface{} {
+	current := make(map[string]autoscalingv2.MetricStatus)
+	for _, status := range autoscaler.Status.CurrentMetrics {
+		current[metricStatusKey(status)] = status
+	}
+
+	result := make([]map[string]interface{}, 0, len(autoscaler.Spec.Metrics))
+	for _, spec := range autoscaler.Spec.Metrics {
+		name, target := describeMetricSpec(spec)
+		entry := map[string]interface{}{
+			"type":   spec.Type,
+			"name":   name,
+			"target": target,
+		}
+		if status, ok := current[string(spec.Type)+"/"+name]; ok {
+			entry["current"] = describeMetricStatus(status)
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+func describeMetricSpec(spec autoscalingv2.MetricSpec) (string, map[string]interface{}) {
+	switch spec.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if spec.Resource != nil {
+			return string(spec.Resource.Name), describeTarget(spec.Resource.Target)
+		}
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		if spec.ContainerResource != nil {
+			return spec.ContainerResource.Container + "/" + string(spec.ContainerResource.Name), describeTarget(spec.ContainerResource.Target)
+		}
+	case autoscalingv2.PodsMetricSourceType:
+		if spec.Pods != nil {
+			return spec.Pods.Metric.Name, describeTarget(spec.Pods.Target)
+		}
+	case autoscalingv2.ObjectMetricSourceType:
+		if spec.Object != nil {
+			return spec.Object.Metric.Name, describeTarget(spec.Object.Target)
+		}
+	case autoscalingv2.ExternalMetricSourceType:
+		if spec.External != nil {
+			return spec.External.Metric.Name, describeTarget(spec.External.Target)
+		}
+	}
+	return "", nil
+}
+
+func metricStatusKey(status autoscalingv2.MetricStatus) string {
+	name := ""
+	switch status.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if status.Resource != nil {
+			name = string(status.Resource.Name)
+		}
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		if status.ContainerResource != nil {
+			name = status.ContainerResource.Container + "/" + string(status.ContainerResource.Name)
+		}
+	case autoscalingv2.PodsMetricSourceType:
+		if status.Pods != nil {
+			name = status.Pods.Metric.Name
+		}
+	case autoscalingv2.ObjectMetricSourceType:
+		if status.Object != nil {
+			name = status.Object.Metric.Name
+		}
+	case autoscalingv2.ExternalMetricSourceType:
+		if status.External != nil {
+			name = status.External.Metric.Name
+		}
+	}
+	return string(status.Type) + "/" + name
+}
+
+func describeMetricStatus(status autoscalingv2.MetricStatus) map[string]interface{} {
+	switch status.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if status.Resource != nil {
+			return describeValue(status.Resource.Current)
+		}
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		if status.ContainerResource != nil {
+			return describeValue(status.ContainerResource.Current)
+		}
+	case autoscalingv2.PodsMetricSourceType:
+		if status.Pods != nil {
+			return describeValue(status.Pods.Current)
+		}
+	case autoscalingv2.ObjectMetricSourceType:
+		if status.Object != nil {
+			return describeValue(status.Object.Current)
+		}
+	case autoscalingv2.ExternalMetricSourceType:
+		if status.External != nil {
+			return describeValue(status.External.Current)
+		}
+	}
+	return nil
+}
+
+func describeTarget(target autoscalingv2.MetricTarget) map[string]interface{} {
+	result := map[string]interface{}{"type": target.Type}
+	if target.AverageUtilization != nil {
+		result["averageUtilization"] = *target.AverageUtilization
+	}
+	if target.AverageValue != nil {
+		result["averageValue"] = target.AverageValue.String()
+	}
+	if target.Value != nil {
+		result["value"] = target.Value.String()
+	}
+	return result
+}
+
+func describeValue(value autoscalingv2.MetricValueStatus) map[string]interface{} {
+	result := map[string]interface{}{}
+	if value.AverageUtilization != nil {
+		result["averageUtilization"] = *value.AverageUtilization
+	}
+	if value.AverageValue != nil {
+		result["averageValue"] = value.AverageValue.String()
+	}
+	if value.Value != nil {
+		result["value"] = value.Value.String()
+	}
+	return result
+}
+
+func getAutoscalerConditions(conditions []autoscalingv2.HorizontalPodAutoscalerCondition) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, condition := range conditions {
+		result = append(result, map[string]interface{}{
+			"type":               condition.Type,
+			"status":             condition.Status,
+			"reason":             condition.Reason,
+			"message":            condition.Message,
+			"lastTransitionTime": condition.LastTransitionTime,
+		})
+	}
+	return result
+}
@@ -0,0 +1,173 @@
+package hpa
+
+import (
+	"context"
+	"io"
+	"log"
+	"testing"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func newTestAutoscaler(name, kind, target string) *autoscalingv2.HorizontalPodAutoscaler {
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: kind, Name: target},
+			MinReplicas:    int32Ptr(2),
+			MaxReplicas:    10,
+		},
+	}
+}
+
+func TestAutoscalerRequest(t *testing.T) {
+	invalid := []AutoscalerRequest{
+		{TargetKind: "Pod"},
+		{MinReplicas: int32Ptr(0)},
+		{MinReplicas: int32Ptr(5), MaxReplicas: int32Ptr(3)},
+		{CPUUtilization: int32Ptr(0)},
+	}
+	for _, req := range invalid {
+		if err := req.Validate(); err == nil {
+			t.Errorf("Validate(%+v) should fail", req)
+		}
+	}
+
+	autoscaler := newTestAutoscaler("web", "Deployment", "web")
+	req := AutoscalerRequest{CPUUtilization: int32Ptr(70), MemoryUtilization: int32Ptr(80)}
+	if err := req.Apply(autoscaler); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	req = AutoscalerRequest{CPUUtilization: int32Ptr(60)}
+	if err := req.Apply(autoscaler); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if len(autoscaler.Spec.Metrics) != 2 {
+		t.Fatalf("metrics = %d, want 2", len(autoscaler.Spec.Metrics))
+	}
+	if got := *autoscaler.Spec.Metrics[0].Resource.Target.AverageUtilization; got != 60 {
+		t.Errorf("cpu utilization = %d, want 60", got)
+	}
+
+	// Lowering maxReplicas below the existing minReplicas is rejected
+	req = AutoscalerRequest{MaxReplicas: int32Ptr(1)}
+	if err := req.Apply(autoscaler); err == nil {
+		t.Error("Apply should reject maxReplicas below minReplicas")
+	}
+}
+
+func TestGetAutoscalerStatus(t *testing.T) {
+	autoscaler := newTestAutoscaler("web", "Deployment", "web")
+	autoscaler.Spec.Metrics = []autoscalingv2.MetricSpec{
+		{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name:   corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: int32Ptr(70)},
+			},
+		},
+		{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: "requests_per_second"},
+				Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: resource.NewQuantity(100, resource.DecimalSI)},
+			},
+		},
+	}
+	autoscaler.Status = autoscalingv2.HorizontalPodAutoscalerStatus{
+		CurrentReplicas: 3,
+		DesiredReplicas: 4,
+		CurrentMetrics: []autoscalingv2.MetricStatus{
+			{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricStatus{
+					Name:    corev1.ResourceCPU,
+					Current: autoscalingv2.MetricValueStatus{AverageUtilization: int32Ptr(85)},
+				},
+			},
+		},
+		Conditions: []autoscalingv2.HorizontalPodAutoscalerCondition{
+			{Type: autoscalingv2.AbleToScale, Status: corev1.ConditionTrue, Reason: "SucceededRescale"},
+		},
+	}
+
+	api := NewHPAAPI(fake.NewSimpleClientset(autoscaler), log.New(io.Discard, "", 0))
+	response, err := api.GetAutoscalerStatus(context.Background(), "team-a", "web")
+	if err != nil {
+		t.Fatalf("GetAutoscalerStatus: %v", err)
+	}
+
+	status := response.Data.(map[string]interface{})
+	if status["currentReplicas"] != int32(3) || status["desiredReplicas"] != int32(4) {
+		t.Errorf("replicas = %v/%v, want 3/4", status["currentReplicas"], status["desiredReplicas"])
+	}
+
+	metrics := status["metrics"].([]map[string]interface{})
+	if len(metrics) != 2 {
+		t.Fatalf("metrics = %v", metrics)
+	}
+	if current := metrics[0]["current"].(map[string]interface{}); current["averageUtilization"] != int32(85) {
+		t.Errorf("cpu current = %v, want 85", current)
+	}
+	if _, ok := metrics[1]["current"]; ok {
+		t.Error("pods metric without status should have no current value")
+	}
+	if target := metrics[1]["target"].(map[string]interface{}); target["averageValue"] != "100" {
+		t.Errorf("pods target = %v", target)
+	}
+	if conditions := status["conditions"].([]map[string]interface{}); len(conditions) != 1 {
+		t.Errorf("conditions = %v", conditions)
+	}
+}
+
+func TestFindAutoscalerForTarget(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		newTestAutoscaler("db", "StatefulSet", "web"),
+		newTestAutoscaler("web", "Deployment", "web"),
+	)
+	ctx := context.Background()
+
+	autoscaler, err := FindAutoscalerForTarget(ctx, clientset, "team-a", "Deployment", "web")
+	if err != nil {
+		t.Fatalf("FindAutoscalerForTarget: %v", err)
+	}
+	if autoscaler == nil || autoscaler.Name != "web" {
+		t.Errorf("autoscaler = %v, want web", autoscaler)
+	}
+
+	autoscaler, err = FindAutoscalerForTarget(ctx, clientset, "team-a", "Deployment", "api")
+	if err != nil || autoscaler != nil {
+		t.Errorf("untargeted deployment: autoscaler = %v, err = %v", autoscaler, err)
+	}
+}
+
+func TestDescribeMetricStatusWithoutSource(t *testing.T) {
+	for _, metricType := range []autoscalingv2.MetricSourceType{
+		autoscalingv2.ResourceMetricSourceType,
+		autoscalingv2.ContainerResourceMetricSourceType,
+		autoscalingv2.PodsMetricSourceType,
+		autoscalingv2.ObjectMetricSourceType,
+		autoscalingv2.ExternalMetricSourceType,
+	} {
+		if current := describeMetricStatus(autoscalingv2.MetricStatus{Type: metricType}); current != nil {
+			t.Errorf("%s: current = %v, want nil", metricType, current)
+		}
+	}
+
+	autoscaler := newTestAutoscaler("web", "Deployment", "web")
+	autoscaler.Spec.Metrics = []autoscalingv2.MetricSpec{{Type: autoscalingv2.ExternalMetricSourceType}}
+	autoscaler.Status.CurrentMetrics = []autoscalingv2.MetricStatus{{Type: autoscalingv2.ExternalMetricSourceType}}
+	metrics := getMetrics(autoscaler)
+	if len(metrics) != 1 {
+		t.Fatalf("metrics = %v", metrics)
+	}
+	if current, _ := metrics[0]["current"].(map[string]interface{}); current != nil {
+		t.Errorf("current = %v, want nil", current)
+	}
+}
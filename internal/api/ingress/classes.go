@@ -0,0 +1,78 @@
+package ingress
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ListIngressClasses returns the ingress classes of the cluster and the name of the default class.
+// The default is empty when no class carries the default annotation.
+func (api *IngressAPI) ListIngressClasses(ctx context.Context) ([]map[string]interface{}, string, error) {
+	api.LogInfo(ctx, "ListIngressClasses", "Fetching ingressclasses")
+
+	classes, err := api.GetClientset().NetworkingV1().IngressClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListIngressClasses", err)
+		return nil, "", api.HandleError(err, "list ingressclasses")
+	}
+
+	defaultClass := selectDefaultClass(classes.Items)
+	result := make([]map[string]interface{}, 0, len(classes.Items))
+	for _, class := range classes.Items {
+		result = append(result, map[string]interface{}{
+			"name":         class.Name,
+			"controller":   class.Spec.Controller,
+			"parameters":   class.Spec.Parameters,
+			"isDefault":    isDefaultClass(&class),
+			"creationTime": class.CreationTimestamp,
+		})
+	}
+
+	name := ""
+	if defaultClass != nil {
+		name = defaultClass.Name
+	}
+	return result, name, nil
+}
+
+// GetDefaultIngressClass returns the class that ingresses without a class name are assigned to,
+// or nil when the cluster has none
+func (api *IngressAPI) GetDefaultIngressClass(ctx context.Context) (*networkingv1.IngressClass, error) {
+	api.LogInfo(ctx, "GetDefaultIngressClass", "Looking up the default ingressclass")
+
+	classes, err := api.GetClientset().NetworkingV1().IngressClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetDefaultIngressClass", err)
+		return nil, api.HandleError(err, "list ingressclasses")
+	}
+
+	defaultClass := selectDefaultClass(classes.Items)
+	if defaultClass != nil {
+		api.LogInfo(ctx, "GetDefaultIngressClass", fmt.Sprintf("Default ingressclass is %s", defaultClass.Name))
+	}
+	return defaultClass, nil
+}
+
+// Helper functions
+
+func isDefaultClass(class *networkingv1.IngressClass) bool {
+	return class.Annotations[networkingv1.AnnotationIsDefaultIngressClass] == "true"
+}
+
+// selectDefaultClass picks the most recently created class marked as default, which is
+// what the DefaultIngressClass admission plugin does when several are marked
+func selectDefaultClass(classes []networkingv1.IngressClass) *networkingv1.IngressClass {
+	var result *networkingv1.IngressClass
+	for i := range classes {
+		if !isDefaultClass(&classes[i]) {
+			continue
+		}
+		if result == nil || classes[i].CreationTimestamp.After(result.CreationTimestamp.Time) {
+			result = &classes[i]
+		}
+	}
+	return result
+}
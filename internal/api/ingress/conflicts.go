@@ -0,0 +1,265 @@
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Conflict types reported by FindIngressConflicts
+const (
+	// ConflictExact means two ingresses claim the same host, path and path type
+	ConflictExact = "exact"
+	// ConflictOverlap means a prefix of one namespace covers a path claimed in another namespace
+	ConflictOverlap = "overlap"
+)
+
+// legacyClassAnnotation is the pre-IngressClass way of selecting a controller
+const legacyClassAnnotation = "kubernetes.io/ingress.class"
+
+// IngressPathRef is one host and path claimed by an ingress
+type IngressPathRef struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Class     string `json:"class"`
+	Host      string `json:"host"`
+	Path      string `json:"path"`
+	PathType  string `json:"pathType"`
+	Backend   string `json:"backend"`
+	Canary    bool   `json:"canary,omitempty"`
+}
+
+// IngressConflict describes two ingress paths that compete for the same requests.
+// Conflicts between different ingress classes only matter when the controllers share an address.
+type IngressConflict struct {
+	Type      string           `json:"type"`
+	Host      string           `json:"host"`
+	SameClass bool             `json:"sameClass"`
+	Paths     []IngressPathRef `json:"paths"`
+	Message   string           `json:"message"`
+}
+
+// IngressConflictReport is the result of ListIngressConflicts
+type IngressConflictReport struct {
+	Ingresses int               `json:"ingresses"`
+	Conflicts []IngressConflict `json:"conflicts"`
+}
+
+// ListIngressConflicts indexes the ingresses of all namespaces by host and path and reports conflicts
+func (api *IngressAPI) ListIngressConflicts(ctx context.Context) (*IngressConflictReport, error) {
+	api.LogInfo(ctx, "ListIngressConflicts", "Indexing ingresses of all namespaces")
+
+	ingresses, err := api.GetClientset().NetworkingV1().Ingresses("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListIngressConflicts", err)
+		return nil, api.HandleError(err, "list ingresses")
+	}
+
+	conflicts := FindIngressConflicts(ingresses.Items)
+	return &IngressConflictReport{
+		Ingresses: len(ingresses.Items),
+		Conflicts: conflicts,
+	}, nil
+}
+
+// GetIngressConflicts returns the conflicts between the given ingress and all other ingresses of the
+// cluster. A stored version of the same ingress is replaced by the given one.
+func (api *IngressAPI) GetIngressConflicts(ctx context.Context, ingress *networkingv1.Ingress) ([]IngressConflict, error) {
+	api.LogInfo(ctx, "GetIngressConflicts", fmt.Sprintf("Checking ingress %s in namespace %s for conflicts", ingress.Name, ingress.Namespace))
+
+	ingresses, err := api.GetClientset().NetworkingV1().Ingresses("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetIngressConflicts", err)
+		return nil, api.HandleError(err, "list ingresses")
+	}
+
+	candidate := []networkingv1.Ingress{*ingress}
+	for _, other := range ingresses.Items {
+		if other.Namespace == ingress.Namespace && other.Name == ingress.Name {
+			continue
+		}
+		candidate = append(candidate, other)
+	}
+
+	var result []IngressConflict
+	for _, conflict := range FindIngressConflicts(candidate) {
+		for _, ref := range conflict.Paths {
+			if ref.Namespace == ingress.Namespace && ref.Name == ingress.Name {
+				result = append(result, conflict)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// FindIngressConflicts reports pairs of paths of different ingresses on the same host that are
+// claimed identically, and Prefix paths in one namespace that cover a path of another namespace.
+// Overlaps inside a namespace, such as / and /api of one team, are deliberate and not reported,
+// and neither is an ingress-nginx canary claiming the path of its primary ingress.
+// ImplementationSpecific paths are compared as prefixes.
+func FindIngressConflicts(ingresses []networkingv1.Ingress) []IngressConflict {
+	byHost := make(map[string][]IngressPathRef)
+	for _, ref := range indexIngressPaths(ingresses) {
+		byHost[ref.Host] = append(byHost[ref.Host], ref)
+	}
+
+	hosts := make([]string, 0, len(byHost))
+	for host := range byHost {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	conflicts := []IngressConflict{}
+	for _, host := range hosts {
+		refs := byHost[host]
+		for i := 0; i < len(refs); i++ {
+			for j := i + 1; j < len(refs); j++ {
+				a, b := refs[i], refs[j]
+				if a.Namespace == b.Namespace && a.Name == b.Name {
+					continue
+				}
+				if conflict, ok := comparePaths(a, b); ok {
+					conflicts = append(conflicts, conflict)
+				}
+			}
+		}
+	}
+	return conflicts
+}
+
+// Helper functions
+
+// indexIngressPaths flattens the rules of the ingresses into host and path entries
+func indexIngressPaths(ingresses []networkingv1.Ingress) []IngressPathRef {
+	var refs []IngressPathRef
+	for _, ing := range ingresses {
+		class := getIngressClass(&ing)
+		for _, rule := range ing.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			for _, path := range rule.HTTP.Paths {
+				pathType := string(networkingv1.PathTypeImplementationSpecific)
+				if path.PathType != nil {
+					pathType = string(*path.PathType)
+				}
+				refs = append(refs, IngressPathRef{
+					Namespace: ing.Namespace,
+					Name:      ing.Name,
+					Class:     class,
+					Host:      rule.Host,
+					Path:      path.Path,
+					PathType:  pathType,
+					Backend:   backendString(&path.Backend),
+					Canary:    ing.Annotations[nginxCanary] == "true",
+				})
+			}
+		}
+	}
+	return refs
+}
+
+// comparePaths returns the conflict between two paths of the same host, if any
+func comparePaths(a, b IngressPathRef) (IngressConflict, bool) {
+	conflict := IngressConflict{
+		Host:      a.Host,
+		SameClass: a.Class == b.Class,
+		Paths:     []IngressPathRef{a, b},
+	}
+	host := a.Host
+	if host == "" {
+		host = "*"
+	}
+
+	// Exact paths must match literally, prefixes ignore a trailing slash
+	aExact := a.PathType == string(networkingv1.PathTypeExact)
+	bExact := b.PathType == string(networkingv1.PathTypeExact)
+	samePath := normalizePath(a.Path) == normalizePath(b.Path)
+	if aExact {
+		samePath = a.Path == b.Path
+	}
+	if aExact == bExact && samePath {
+		// A canary shares the host and path of its primary by design
+		if a.Canary != b.Canary {
+			return conflict, false
+		}
+		conflict.Type = ConflictExact
+		conflict.Message = fmt.Sprintf("%s%s is claimed by %s/%s and %s/%s", host, a.Path, a.Namespace, a.Name, b.Namespace, b.Name)
+		return conflict, true
+	}
+
+	if a.Namespace == b.Namespace {
+		return conflict, false
+	}
+	// A Prefix path covers the other path when it is a prefix of it; an Exact path takes precedence
+	// over a Prefix path of the same value, so that case is not a conflict
+	for _, pair := range [][2]IngressPathRef{{a, b}, {b, a}} {
+		prefix, covered := pair[0], pair[1]
+		if prefix.PathType == string(networkingv1.PathTypeExact) || normalizePath(prefix.Path) == normalizePath(covered.Path) {
+			continue
+		}
+		if pathHasPrefix(covered.Path, prefix.Path) {
+			conflict.Type = ConflictOverlap
+			conflict.Message = fmt.Sprintf("prefix %s%s of %s/%s covers %s of %s/%s", host, prefix.Path,
+				prefix.Namespace, prefix.Name, covered.Path, covered.Namespace, covered.Name)
+			return conflict, true
+		}
+	}
+	return conflict, false
+}
+
+// pathHasPrefix matches path against prefix element-wise as the Prefix path type does:
+// /foo matches /foo and /foo/bar but not /foobar. Trailing slashes are ignored.
+func pathHasPrefix(path, prefix string) bool {
+	path, prefix = normalizePath(path), normalizePath(prefix)
+	if prefix == "/" {
+		return true
+	}
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+func normalizePath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	if trimmed := strings.TrimRight(path, "/"); trimmed != "" {
+		return trimmed
+	}
+	return "/"
+}
+
+// getIngressClass returns the class of an ingress from spec.ingressClassName or the legacy annotation
+func getIngressClass(ing *networkingv1.Ingress) string {
+	if ing.Spec.IngressClassName != nil {
+		return *ing.Spec.IngressClassName
+	}
+	return ing.Annotations[legacyClassAnnotation]
+}
+
+func backendString(backend *networkingv1.IngressBackend) string {
+	switch {
+	case backend.Service != nil:
+		return fmt.Sprintf("service/%s:%s", backend.Service.Name, strings.Trim(backendPortString(backend.Service.Port), `"`))
+	case backend.Resource != nil:
+		return fmt.Sprintf("%s/%s", backend.Resource.Kind, backend.Resource.Name)
+	}
+	return ""
+}
+
+// conflictWarnings turns conflicts of a written ingress into response warnings
+func conflictWarnings(conflicts []IngressConflict) []string {
+	warnings := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		warning := conflict.Message
+		if !conflict.SameClass {
+			warning += " (different ingress classes)"
+		}
+		warnings = append(warnings, warning)
+	}
+	return warnings
+}
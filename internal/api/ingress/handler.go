@@ -1,6 +1,7 @@
 package ingress
 
 import (
+	"errors"
 	"log"
 	"net/http"
 
@@ -15,6 +16,25 @@ type Handler struct {
 }
 
 // Helper functions
+
+// respondWriteError maps validation failures of a create or update to 400 with the field errors
+func respondWriteError(c *gin.Context, err error) {
+	var fieldErrors FieldErrors
+	if errors.As(err, &fieldErrors) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":     false,
+			"error":       err.Error(),
+			"fieldErrors": fieldErrors,
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"success": false,
+		"error":   err.Error(),
+	})
+}
+
 func getTLSConfig(tls []networkingv1.IngressTLS) []map[string]interface{} {
 	var result []map[string]interface{}
 	for _, t := range tls {
@@ -51,12 +71,13 @@ func (h *Handler) ListIngresses(c *gin.Context) {
 	var response []map[string]interface{}
 	for _, ing := range ingresses.Items {
 		response = append(response, map[string]interface{}{
-			"name":         ing.Name,
-			"namespace":    ing.Namespace,
-			"className":    ing.Spec.IngressClassName,
-			"rules":        getIngressRules(ing.Spec.Rules),
-			"creationTime": ing.CreationTimestamp,
-			"labels":       ing.Labels,
+			"name":           ing.Name,
+			"namespace":      ing.Namespace,
+			"className":      ing.Spec.IngressClassName,
+			"defaultBackend": ing.Spec.DefaultBackend,
+			"rules":          getIngressRules(ing.Spec.Rules),
+			"creationTime":   ing.CreationTimestamp,
+			"labels":         ing.Labels,
 		})
 	}
 
@@ -69,23 +90,8 @@ func (h *Handler) ListIngresses(c *gin.Context) {
 // CreateIngress handles POST /api/v1/namespaces/:namespace/ingresses
 func (h *Handler) CreateIngress(c *gin.Context) {
 	var ingressRequest struct {
-		Name      string `json:"name" binding:"required"`
-		ClassName string `json:"className"`
-		Rules     []struct {
-			Host  string `json:"host"`
-			Paths []struct {
-				Path        string `json:"path" binding:"required"`
-				PathType    string `json:"pathType" binding:"required"`
-				ServiceName string `json:"serviceName" binding:"required"`
-				ServicePort int32  `json:"servicePort" binding:"required"`
-			} `json:"paths" binding:"required"`
-		} `json:"rules" binding:"required"`
-		TLS []struct {
-			Hosts      []string `json:"hosts"`
-			SecretName string   `json:"secretName"`
-		} `json:"tls"`
-		Annotations map[string]string `json:"annotations"`
-		Labels      map[string]string `json:"labels"`
+		Name string `json:"name" binding:"required"`
+		IngressRequest
 	}
 
 	if err := c.ShouldBindJSON(&ingressRequest); err != nil {
@@ -96,102 +102,60 @@ func (h *Handler) CreateIngress(c *gin.Context) {
 		return
 	}
 
-	namespace := c.Param("namespace")
-
-	// Create ingress rules
-	var rules []networkingv1.IngressRule
-	for _, rule := range ingressRequest.Rules {
-		var paths []networkingv1.HTTPIngressPath
-		for _, path := range rule.Paths {
-			pathType := networkingv1.PathType(path.PathType)
-			paths = append(paths, networkingv1.HTTPIngressPath{
-				Path:     path.Path,
-				PathType: &pathType,
-				Backend: networkingv1.IngressBackend{
-					Service: &networkingv1.IngressServiceBackend{
-						Name: path.ServiceName,
-						Port: networkingv1.ServiceBackendPort{
-							Number: path.ServicePort,
-						},
-					},
-				},
-			})
-		}
-
-		rules = append(rules, networkingv1.IngressRule{
-			Host: rule.Host,
-			IngressRuleValue: networkingv1.IngressRuleValue{
-				HTTP: &networkingv1.HTTPIngressRuleValue{
-					Paths: paths,
-				},
-			},
+	if err := ingressRequest.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
 		})
+		return
 	}
 
-	// Create TLS configuration
-	var tls []networkingv1.IngressTLS
-	for _, t := range ingressRequest.TLS {
-		tls = append(tls, networkingv1.IngressTLS{
-			Hosts:      t.Hosts,
-			SecretName: t.SecretName,
-		})
-	}
+	namespace := c.Param("namespace")
 
 	// Create ingress object
 	ingress := &networkingv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        ingressRequest.Name,
-			Namespace:   namespace,
-			Labels:      ingressRequest.Labels,
-			Annotations: ingressRequest.Annotations,
-		},
-		Spec: networkingv1.IngressSpec{
-			IngressClassName: &ingressRequest.ClassName,
-			Rules:            rules,
-			TLS:              tls,
+			Name:      ingressRequest.Name,
+			Namespace: namespace,
 		},
 	}
 
+	// Without a class name the default class is used. If it cannot be determined the class is
+	// left unset, so the DefaultIngressClass admission plugin can still assign one.
+	if ingressRequest.ClassName == "" {
+		if defaultClass, err := h.api.GetDefaultIngressClass(c.Request.Context()); err == nil && defaultClass != nil {
+			ingressRequest.ClassName = defaultClass.Name
+		}
+	}
+	ingressRequest.Apply(ingress)
+
 	result, err := h.api.CreateIngress(c.Request.Context(), namespace, ingress)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   err.Error(),
-		})
+		respondWriteError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
+	response := gin.H{
 		"success": true,
 		"data": map[string]interface{}{
-			"name":      result.Name,
-			"namespace": result.Namespace,
-			"className": result.Spec.IngressClassName,
-			"rules":     getIngressRules(result.Spec.Rules),
+			"name":           result.Name,
+			"namespace":      result.Namespace,
+			"className":      result.Spec.IngressClassName,
+			"defaultBackend": result.Spec.DefaultBackend,
+			"rules":          getIngressRules(result.Spec.Rules),
 		},
-	})
+	}
+	// Conflicts do not block the write; the ingress is already stored at this point
+	if conflicts, err := h.api.GetIngressConflicts(c.Request.Context(), result); err == nil && len(conflicts) > 0 {
+		response["warnings"] = conflictWarnings(conflicts)
+	}
+
+	c.JSON(http.StatusCreated, response)
 }
 
 // UpdateIngress handles PUT /api/v1/namespaces/:namespace/ingresses/:name
 func (h *Handler) UpdateIngress(c *gin.Context) {
-	var updateRequest struct {
-		ClassName string `json:"className"`
-		Rules     []struct {
-			Host  string `json:"host"`
-			Paths []struct {
-				Path        string `json:"path"`
-				PathType    string `json:"pathType"`
-				ServiceName string `json:"serviceName"`
-				ServicePort int32  `json:"servicePort"`
-			} `json:"paths"`
-		} `json:"rules"`
-		TLS []struct {
-			Hosts      []string `json:"hosts"`
-			SecretName string   `json:"secretName"`
-		} `json:"tls"`
-		Annotations map[string]string `json:"annotations"`
-		Labels      map[string]string `json:"labels"`
-	}
+	var updateRequest IngressRequest
 
 	if err := c.ShouldBindJSON(&updateRequest); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -201,6 +165,14 @@ func (h *Handler) UpdateIngress(c *gin.Context) {
 		return
 	}
 
+	if err := updateRequest.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
 	namespace := c.Param("namespace")
 	name := c.Param("name")
 
@@ -215,81 +187,30 @@ func (h *Handler) UpdateIngress(c *gin.Context) {
 	}
 
 	// Update fields if provided
-	if updateRequest.ClassName != "" {
-		existing.Spec.IngressClassName = &updateRequest.ClassName
-	}
-
-	if len(updateRequest.Rules) > 0 {
-		var rules []networkingv1.IngressRule
-		for _, rule := range updateRequest.Rules {
-			var paths []networkingv1.HTTPIngressPath
-			for _, path := range rule.Paths {
-				pathType := networkingv1.PathType(path.PathType)
-				paths = append(paths, networkingv1.HTTPIngressPath{
-					Path:     path.Path,
-					PathType: &pathType,
-					Backend: networkingv1.IngressBackend{
-						Service: &networkingv1.IngressServiceBackend{
-							Name: path.ServiceName,
-							Port: networkingv1.ServiceBackendPort{
-								Number: path.ServicePort,
-							},
-						},
-					},
-				},
-				)
-			}
-
-			rules = append(rules, networkingv1.IngressRule{
-				Host: rule.Host,
-				IngressRuleValue: networkingv1.IngressRuleValue{
-					HTTP: &networkingv1.HTTPIngressRuleValue{
-						Paths: paths,
-					},
-				},
-			})
-		}
-		existing.Spec.Rules = rules
-	}
-
-	if len(updateRequest.TLS) > 0 {
-		var tls []networkingv1.IngressTLS
-		for _, t := range updateRequest.TLS {
-			tls = append(tls, networkingv1.IngressTLS{
-				Hosts:      t.Hosts,
-				SecretName: t.SecretName,
-			})
-		}
-		existing.Spec.TLS = tls
-	}
-
-	if updateRequest.Labels != nil {
-		existing.Labels = updateRequest.Labels
-	}
-
-	if updateRequest.Annotations != nil {
-		existing.Annotations = updateRequest.Annotations
-	}
+	updateRequest.Apply(existing)
 
 	result, err := h.api.UpdateIngress(c.Request.Context(), namespace, existing)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   err.Error(),
-		})
+		respondWriteError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"success": true,
 		"data": map[string]interface{}{
-			"name":      result.Name,
-			"namespace": result.Namespace,
-			"className": result.Spec.IngressClassName,
-			"rules":     getIngressRules(result.Spec.Rules),
-			"status":    "updated",
+			"name":           result.Name,
+			"namespace":      result.Namespace,
+			"className":      result.Spec.IngressClassName,
+			"defaultBackend": result.Spec.DefaultBackend,
+			"rules":          getIngressRules(result.Spec.Rules),
+			"status":         "updated",
 		},
-	})
+	}
+	if conflicts, err := h.api.GetIngressConflicts(c.Request.Context(), result); err == nil && len(conflicts) > 0 {
+		response["warnings"] = conflictWarnings(conflicts)
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 // GetIngress handles GET /api/v1/namespaces/:namespace/ingresses/:name
@@ -309,14 +230,16 @@ func (h *Handler) GetIngress(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data": map[string]interface{}{
-			"name":         ingress.Name,
-			"namespace":    ingress.Namespace,
-			"className":    ingress.Spec.IngressClassName,
-			"rules":        getIngressRules(ingress.Spec.Rules),
-			"tls":          getTLSConfig(ingress.Spec.TLS),
-			"creationTime": ingress.CreationTimestamp,
-			"labels":       ingress.Labels,
-			"annotations":  ingress.Annotations,
+			"name":           ingress.Name,
+			"namespace":      ingress.Namespace,
+			"className":      ingress.Spec.IngressClassName,
+			"defaultBackend": ingress.Spec.DefaultBackend,
+			"rules":          getIngressRules(ingress.Spec.Rules),
+			"tls":            getTLSConfig(ingress.Spec.TLS),
+			"creationTime":   ingress.CreationTimestamp,
+			"labels":         ingress.Labels,
+			"annotations":    ingress.Annotations,
+			"nginx":          ParseNginxAnnotations(ingress.Annotations),
 		},
 	})
 }
@@ -357,3 +280,70 @@ func (h *Handler) GetIngressStatus(c *gin.Context) {
 
 	c.JSON(http.StatusOK, status)
 }
+
+// ListIngressConflicts handles GET /api/v1/ingresses/conflicts
+func (h *Handler) ListIngressConflicts(c *gin.Context) {
+	report, err := h.api.ListIngressConflicts(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    report,
+	})
+}
+
+// ListIngressClasses handles GET /api/v1/ingressclasses
+func (h *Handler) ListIngressClasses(c *gin.Context) {
+	classes, defaultClass, err := h.api.ListIngressClasses(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"classes":      classes,
+			"defaultClass": defaultClass,
+		},
+	})
+}
+
+// SimulateRoute handles GET /api/v1/ingresses/simulate?url=...&class=...
+func (h *Handler) SimulateRoute(c *gin.Context) {
+	rawURL := c.Query("url")
+	if rawURL == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "url query parameter is required",
+		})
+		return
+	}
+
+	match, err := h.api.SimulateRoute(c.Request.Context(), rawURL, c.Query("class"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidURL) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    match,
+	})
+}
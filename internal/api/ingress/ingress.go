@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 
 	"k8s-glance-backend/internal/api/base"
+	"k8s-glance-backend/internal/api/secret"
 )
 
 // IngressAPI handles ingress-related operations
@@ -18,12 +21,128 @@ type IngressAPI struct {
 }
 
 // NewIngressAPI creates a new IngressAPI instance
-func NewIngressAPI(clientset *kubernetes.Clientset, logger *log.Logger) *IngressAPI {
+func NewIngressAPI(clientset kubernetes.Interface, logger *log.Logger) *IngressAPI {
 	return &IngressAPI{
 		BaseAPI: base.NewBaseAPI(clientset, logger),
 	}
 }
 
+// IngressRequest describes an ingress to create or the fields of one to update; unset fields are
+// left unchanged. Rules and TLS replace the existing lists when set, so an explicit [] removes them,
+// and an empty defaultBackend object removes the default backend.
+type IngressRequest struct {
+	ClassName      string                       `json:"className"`
+	DefaultBackend *networkingv1.IngressBackend `json:"defaultBackend"`
+	Rules          []IngressRuleRequest         `json:"rules"`
+	TLS            []networkingv1.IngressTLS    `json:"tls"`
+	Annotations    map[string]string            `json:"annotations"`
+	Labels         map[string]string            `json:"labels"`
+	Nginx          *NginxAnnotations            `json:"nginx"`
+}
+
+// IngressRuleRequest is a host rule. A rule without paths has no HTTP section and only
+// claims the host, e.g. for TLS passthrough controllers.
+type IngressRuleRequest struct {
+	Host  string               `json:"host"`
+	Paths []IngressPathRequest `json:"paths"`
+}
+
+// IngressPathRequest is a path of a rule. Backend takes a service or resource backend in the
+// shape it is returned in; serviceName and servicePort are a shorthand for a service backend,
+// where servicePort is a port number or name.
+type IngressPathRequest struct {
+	Path        string                       `json:"path"`
+	PathType    string                       `json:"pathType"`
+	Backend     *networkingv1.IngressBackend `json:"backend,omitempty"`
+	ServiceName string                       `json:"serviceName,omitempty"`
+	ServicePort intstr.IntOrString           `json:"servicePort,omitempty"`
+}
+
+// Validate checks the request fields that the ingress validation cannot see
+func (r *IngressRequest) Validate() error {
+	for i, rule := range r.Rules {
+		for j, path := range rule.Paths {
+			if path.Backend != nil && path.ServiceName != "" {
+				return fmt.Errorf("rules[%d].paths[%d]: backend and serviceName are mutually exclusive", i, j)
+			}
+			if path.Backend == nil && path.ServiceName == "" {
+				return fmt.Errorf("rules[%d].paths[%d]: backend or serviceName is required", i, j)
+			}
+		}
+	}
+	if r.Nginx != nil {
+		return r.Nginx.Validate()
+	}
+	return nil
+}
+
+// Apply sets the requested fields on the ingress
+func (r *IngressRequest) Apply(ingress *networkingv1.Ingress) {
+	if r.ClassName != "" {
+		className := r.ClassName
+		ingress.Spec.IngressClassName = &className
+	}
+
+	if r.DefaultBackend != nil {
+		if r.DefaultBackend.Service == nil && r.DefaultBackend.Resource == nil {
+			ingress.Spec.DefaultBackend = nil
+		} else {
+			ingress.Spec.DefaultBackend = r.DefaultBackend
+		}
+	}
+
+	if r.Rules != nil {
+		var rules []networkingv1.IngressRule
+		for _, rule := range r.Rules {
+			ingressRule := networkingv1.IngressRule{Host: rule.Host}
+			if rule.Paths != nil {
+				paths := make([]networkingv1.HTTPIngressPath, 0, len(rule.Paths))
+				for _, path := range rule.Paths {
+					pathType := networkingv1.PathType(path.PathType)
+					paths = append(paths, networkingv1.HTTPIngressPath{
+						Path:     path.Path,
+						PathType: &pathType,
+						Backend:  path.backend(),
+					})
+				}
+				ingressRule.HTTP = &networkingv1.HTTPIngressRuleValue{Paths: paths}
+			}
+			rules = append(rules, ingressRule)
+		}
+		ingress.Spec.Rules = rules
+	}
+
+	if r.TLS != nil {
+		ingress.Spec.TLS = nil
+		if len(r.TLS) > 0 {
+			ingress.Spec.TLS = r.TLS
+		}
+	}
+
+	if r.Labels != nil {
+		ingress.Labels = r.Labels
+	}
+	if r.Annotations != nil {
+		ingress.Annotations = r.Annotations
+	}
+	if r.Nginx != nil {
+		ingress.Annotations = r.Nginx.Render(ingress.Annotations)
+	}
+}
+
+func (p *IngressPathRequest) backend() networkingv1.IngressBackend {
+	if p.Backend != nil {
+		return *p.Backend
+	}
+	port := networkingv1.ServiceBackendPort{Number: p.ServicePort.IntVal}
+	if p.ServicePort.Type == intstr.String {
+		port = networkingv1.ServiceBackendPort{Name: p.ServicePort.StrVal}
+	}
+	return networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{Name: p.ServiceName, Port: port},
+	}
+}
+
 // ListIngresses returns all ingresses in a namespace
 func (api *IngressAPI) ListIngresses(ctx context.Context, namespace string) (*networkingv1.IngressList, error) {
 	api.LogInfo(ctx, "ListIngresses", fmt.Sprintf("Fetching ingresses in namespace: %s", namespace))
@@ -50,10 +169,14 @@ func (api *IngressAPI) GetIngress(ctx context.Context, namespace, name string) (
 	return ingress, nil
 }
 
-// CreateIngress creates a new ingress
+// CreateIngress validates and creates a new ingress. Validation failures are returned as FieldErrors.
 func (api *IngressAPI) CreateIngress(ctx context.Context, namespace string, ingress *networkingv1.Ingress) (*networkingv1.Ingress, error) {
 	api.LogInfo(ctx, "CreateIngress", fmt.Sprintf("Creating ingress %s in namespace %s", ingress.Name, namespace))
 
+	if err := api.checkIngress(ctx, namespace, ingress); err != nil {
+		return nil, err
+	}
+
 	result, err := api.GetClientset().NetworkingV1().Ingresses(namespace).Create(ctx, ingress, metav1.CreateOptions{})
 	if err != nil {
 		api.LogError(ctx, "CreateIngress", err)
@@ -63,10 +186,14 @@ func (api *IngressAPI) CreateIngress(ctx context.Context, namespace string, ingr
 	return result, nil
 }
 
-// UpdateIngress updates an existing ingress
+// UpdateIngress validates and updates an existing ingress. Validation failures are returned as FieldErrors.
 func (api *IngressAPI) UpdateIngress(ctx context.Context, namespace string, ingress *networkingv1.Ingress) (*networkingv1.Ingress, error) {
 	api.LogInfo(ctx, "UpdateIngress", fmt.Sprintf("Updating ingress %s in namespace %s", ingress.Name, namespace))
 
+	if err := api.checkIngress(ctx, namespace, ingress); err != nil {
+		return nil, err
+	}
+
 	result, err := api.GetClientset().NetworkingV1().Ingresses(namespace).Update(ctx, ingress, metav1.UpdateOptions{})
 	if err != nil {
 		api.LogError(ctx, "UpdateIngress", err)
@@ -89,7 +216,8 @@ func (api *IngressAPI) DeleteIngress(ctx context.Context, namespace, name string
 	return nil
 }
 
-// GetIngressStatus returns detailed status of an ingress
+// GetIngressStatus returns detailed status of an ingress, including the result of the same
+// validation CreateIngress and UpdateIngress apply, run against the live services and secrets
 func (api *IngressAPI) GetIngressStatus(ctx context.Context, namespace, name string) (*base.APIResponse, error) {
 	api.LogInfo(ctx, "GetIngressStatus", fmt.Sprintf("Fetching status for ingress %s in namespace %s", name, namespace))
 
@@ -98,13 +226,25 @@ func (api *IngressAPI) GetIngressStatus(ctx context.Context, namespace, name str
 		return nil, err
 	}
 
+	fieldErrors, err := api.ValidateIngress(ctx, namespace, ingress)
+	if err != nil {
+		api.LogError(ctx, "GetIngressStatus", err)
+		return nil, err
+	}
+	if fieldErrors == nil {
+		fieldErrors = FieldErrors{}
+	}
+
 	// Build detailed status response
 	status := map[string]interface{}{
-		"loadBalancer": getLoadBalancerStatus(ingress.Status.LoadBalancer),
-		"rules":        getIngressRules(ingress.Spec.Rules),
-		"tls":          getTLSStatus(ingress.Spec.TLS),
-		"class":        ingress.Spec.IngressClassName,
-		"annotations":  ingress.Annotations,
+		"loadBalancer":   getLoadBalancerStatus(ingress.Status.LoadBalancer),
+		"rules":          getIngressRules(ingress.Spec.Rules),
+		"defaultBackend": ingress.Spec.DefaultBackend,
+		"tls":            api.getTLSStatus(ctx, namespace, ingress.Spec.TLS),
+		"class":          ingress.Spec.IngressClassName,
+		"annotations":    ingress.Annotations,
+		"valid":          len(fieldErrors) == 0,
+		"fieldErrors":    fieldErrors,
 	}
 
 	response := base.NewSuccessResponse(status)
@@ -113,6 +253,19 @@ func (api *IngressAPI) GetIngressStatus(ctx context.Context, namespace, name str
 
 // Helper functions
 
+// checkIngress runs ValidateIngress and turns field errors into an error
+func (api *IngressAPI) checkIngress(ctx context.Context, namespace string, ingress *networkingv1.Ingress) error {
+	fieldErrors, err := api.ValidateIngress(ctx, namespace, ingress)
+	if err != nil {
+		api.LogError(ctx, "ValidateIngress", err)
+		return err
+	}
+	if len(fieldErrors) > 0 {
+		return fieldErrors
+	}
+	return nil
+}
+
 func getLoadBalancerStatus(status networkingv1.IngressLoadBalancerStatus) []map[string]interface{} {
 	var result []map[string]interface{}
 	for _, ingress := range status.Ingress {
@@ -124,21 +277,20 @@ func getLoadBalancerStatus(status networkingv1.IngressLoadBalancerStatus) []map[
 	return result
 }
 
+// getIngressRules returns the rules in the shape IngressRequest accepts. Backends are returned
+// as they are stored, so service ports by name and resource backends survive a round trip, and
+// rules without an HTTP section have no paths.
 func getIngressRules(rules []networkingv1.IngressRule) []map[string]interface{} {
-	var result []map[string]interface{}
+	result := make([]map[string]interface{}, 0, len(rules))
 	for _, rule := range rules {
-		paths := make([]map[string]interface{}, 0)
+		var paths []map[string]interface{}
 		if rule.HTTP != nil {
+			paths = make([]map[string]interface{}, 0, len(rule.HTTP.Paths))
 			for _, path := range rule.HTTP.Paths {
 				paths = append(paths, map[string]interface{}{
 					"path":     path.Path,
 					"pathType": path.PathType,
-					"backend": map[string]interface{}{
-						"service": map[string]interface{}{
-							"name": path.Backend.Service.Name,
-							"port": path.Backend.Service.Port,
-						},
-					},
+					"backend":  path.Backend,
 				})
 			}
 		}
@@ -151,13 +303,47 @@ func getIngressRules(rules []networkingv1.IngressRule) []map[string]interface{}
 	return result
 }
 
-func getTLSStatus(tls []networkingv1.IngressTLS) []map[string]interface{} {
+// getTLSStatus inspects the certificate behind every TLS entry and flags hosts
+// that are not covered by the certificate's SANs
+func (api *IngressAPI) getTLSStatus(ctx context.Context, namespace string, tls []networkingv1.IngressTLS) []map[string]interface{} {
 	var result []map[string]interface{}
+	now := time.Now()
 	for _, t := range tls {
-		result = append(result, map[string]interface{}{
+		status := map[string]interface{}{
 			"hosts":      t.Hosts,
 			"secretName": t.SecretName,
-		})
+		}
+
+		if t.SecretName == "" {
+			result = append(result, status)
+			continue
+		}
+
+		tlsSecret, err := api.GetClientset().CoreV1().Secrets(namespace).Get(ctx, t.SecretName, metav1.GetOptions{})
+		if err != nil {
+			api.LogError(ctx, "GetIngressStatus", err)
+			status["error"] = err.Error()
+			result = append(result, status)
+			continue
+		}
+
+		certificate, err := secret.InspectSecretCertificate(tlsSecret, now)
+		if err != nil {
+			status["error"] = err.Error()
+			result = append(result, status)
+			continue
+		}
+
+		uncoveredHosts := make([]string, 0)
+		for _, host := range t.Hosts {
+			if !certificate.Covers(host) {
+				uncoveredHosts = append(uncoveredHosts, host)
+			}
+		}
+
+		status["certificate"] = certificate
+		status["uncoveredHosts"] = uncoveredHosts
+		result = append(result, status)
 	}
 	return result
 }
@@ -0,0 +1,526 @@
+package ingress
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestIngressAPI(objects ...runtime.Object) *IngressAPI {
+	return NewIngressAPI(fake.NewSimpleClientset(objects...), log.New(io.Discard, "", 0))
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func pathType(t networkingv1.PathType) *networkingv1.PathType {
+	return &t
+}
+
+func serviceBackend(name string, port networkingv1.ServiceBackendPort) networkingv1.IngressBackend {
+	return networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: name, Port: port}}
+}
+
+func newTestIngress(name, host string, paths ...networkingv1.HTTPIngressPath) *networkingv1.Ingress {
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{{
+				Host:             host,
+				IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: paths}},
+			}},
+		},
+	}
+}
+
+func fieldSet(errs FieldErrors) map[string]bool {
+	fields := make(map[string]bool)
+	for _, fieldError := range errs {
+		fields[fieldError.Field] = true
+	}
+	return fields
+}
+
+func TestValidateIngressSpec(t *testing.T) {
+	ingress := newTestIngress("web", "*.example.com",
+		networkingv1.HTTPIngressPath{Path: "/", PathType: pathType(networkingv1.PathTypePrefix), Backend: serviceBackend("web", networkingv1.ServiceBackendPort{Number: 80})},
+		networkingv1.HTTPIngressPath{Path: "/api/(.*)", PathType: pathType(networkingv1.PathTypeImplementationSpecific), Backend: serviceBackend("api", networkingv1.ServiceBackendPort{Name: "http"})},
+	)
+	if errs := validateIngressSpec(&ingress.Spec); len(errs) != 0 {
+		t.Errorf("valid spec: errors = %v", errs)
+	}
+
+	ingress = newTestIngress("web", "10.0.0.1",
+		networkingv1.HTTPIngressPath{Path: "/api/(.*)", PathType: pathType(networkingv1.PathTypePrefix), Backend: serviceBackend("api", networkingv1.ServiceBackendPort{Number: 80})},
+		networkingv1.HTTPIngressPath{Path: "/", PathType: pathType("Regex"), Backend: serviceBackend("web", networkingv1.ServiceBackendPort{Number: 80})},
+		networkingv1.HTTPIngressPath{Path: "/a//b", PathType: pathType(networkingv1.PathTypeExact), Backend: serviceBackend("Web_1", networkingv1.ServiceBackendPort{})},
+		networkingv1.HTTPIngressPath{Path: "/", Backend: networkingv1.IngressBackend{}},
+	)
+	fields := fieldSet(validateIngressSpec(&ingress.Spec))
+	for _, want := range []string{
+		"spec.rules[0].host",
+		"spec.rules[0].http.paths[0].path",
+		"spec.rules[0].http.paths[1].pathType",
+		"spec.rules[0].http.paths[2].path",
+		"spec.rules[0].http.paths[2].backend.service.name",
+		"spec.rules[0].http.paths[2].backend.service.port.number",
+		"spec.rules[0].http.paths[3].pathType",
+		"spec.rules[0].http.paths[3].backend",
+	} {
+		if !fields[want] {
+			t.Errorf("missing field error for %s in %v", want, fields)
+		}
+	}
+}
+
+func TestCreateIngressValidatesReferences(t *testing.T) {
+	api := newTestIngressAPI(
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"},
+			Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 80}}},
+		},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "web-tls", Namespace: "team-a"}, Type: corev1.SecretTypeTLS},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "opaque", Namespace: "team-a"}, Type: corev1.SecretTypeOpaque},
+	)
+	ctx := context.Background()
+
+	ingress := newTestIngress("web", "web.example.com",
+		networkingv1.HTTPIngressPath{Path: "/", PathType: pathType(networkingv1.PathTypePrefix), Backend: serviceBackend("web", networkingv1.ServiceBackendPort{Name: "http"})},
+		networkingv1.HTTPIngressPath{Path: "/metrics", PathType: pathType(networkingv1.PathTypePrefix), Backend: serviceBackend("web", networkingv1.ServiceBackendPort{Number: 9090})},
+		networkingv1.HTTPIngressPath{Path: "/api", PathType: pathType(networkingv1.PathTypePrefix), Backend: serviceBackend("api", networkingv1.ServiceBackendPort{Number: 80})},
+	)
+	ingress.Spec.TLS = []networkingv1.IngressTLS{
+		{Hosts: []string{"web.example.com"}, SecretName: "web-tls"},
+		{Hosts: []string{"web.example.com"}, SecretName: "opaque"},
+		{Hosts: []string{"web.example.com"}, SecretName: "missing"},
+	}
+
+	_, err := api.CreateIngress(ctx, "team-a", ingress)
+	var fieldErrors FieldErrors
+	if !errors.As(err, &fieldErrors) {
+		t.Fatalf("CreateIngress: err = %v, want FieldErrors", err)
+	}
+	fields := fieldSet(fieldErrors)
+	for _, want := range []string{
+		"spec.rules[0].http.paths[1].backend.service.port",
+		"spec.rules[0].http.paths[2].backend.service.name",
+		"spec.tls[1].secretName",
+		"spec.tls[2].secretName",
+	} {
+		if !fields[want] {
+			t.Errorf("missing field error for %s in %v", want, fieldErrors)
+		}
+	}
+	if len(fieldErrors) != 4 {
+		t.Errorf("field errors = %v, want 4", fieldErrors)
+	}
+
+	ingress.Spec.Rules[0].HTTP.Paths = ingress.Spec.Rules[0].HTTP.Paths[:1]
+	ingress.Spec.TLS = ingress.Spec.TLS[:1]
+	if _, err := api.CreateIngress(ctx, "team-a", ingress); err != nil {
+		t.Fatalf("CreateIngress valid: %v", err)
+	}
+
+	// The status reports the same checks against live state
+	if err := api.GetClientset().CoreV1().Secrets("team-a").Delete(ctx, "web-tls", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	response, err := api.GetIngressStatus(ctx, "team-a", "web")
+	if err != nil {
+		t.Fatalf("GetIngressStatus: %v", err)
+	}
+	status := response.Data.(map[string]interface{})
+	if status["valid"] != false || !fieldSet(status["fieldErrors"].(FieldErrors))["spec.tls[0].secretName"] {
+		t.Errorf("status = %v, want the deleted TLS secret reported", status)
+	}
+}
+
+func TestFindIngressConflicts(t *testing.T) {
+	prefix := func(path, service string) networkingv1.HTTPIngressPath {
+		return networkingv1.HTTPIngressPath{Path: path, PathType: pathType(networkingv1.PathTypePrefix), Backend: serviceBackend(service, networkingv1.ServiceBackendPort{Number: 80})}
+	}
+	exact := func(path, service string) networkingv1.HTTPIngressPath {
+		return networkingv1.HTTPIngressPath{Path: path, PathType: pathType(networkingv1.PathTypeExact), Backend: serviceBackend(service, networkingv1.ServiceBackendPort{Number: 80})}
+	}
+	inNamespace := func(ing *networkingv1.Ingress, namespace string) networkingv1.Ingress {
+		ing.Namespace = namespace
+		return *ing
+	}
+
+	conflicts := FindIngressConflicts([]networkingv1.Ingress{
+		inNamespace(newTestIngress("shop", "shop.example.com", prefix("/", "web"), prefix("/api", "api")), "team-a"),
+		inNamespace(newTestIngress("checkout", "shop.example.com", prefix("/api/", "checkout")), "team-b"),
+		inNamespace(newTestIngress("cart", "shop.example.com", prefix("/cart", "cart"), exact("/", "landing")), "team-b"),
+		inNamespace(newTestIngress("apidocs", "shop.example.com", prefix("/apidocs", "docs")), "team-a"),
+		inNamespace(newTestIngress("other", "other.example.com", prefix("/api", "api")), "team-b"),
+	})
+
+	got := make(map[string]bool)
+	for _, conflict := range conflicts {
+		got[conflict.Type+" "+conflict.Paths[0].Path+" "+conflict.Paths[1].Path] = true
+	}
+	want := []string{
+		"exact /api /api/", // the same prefix claimed by team-a and team-b
+		"overlap / /api/",  // / of team-a covers /api/ of team-b
+		"overlap / /cart",  // / of team-a covers /cart of team-b
+	}
+	for _, w := range want {
+		if !got[w] {
+			t.Errorf("missing conflict %q in %v", w, got)
+		}
+	}
+	// Exact / of team-b takes precedence over prefix / and /apidocs shares no path elements with /api
+	if len(conflicts) != len(want) {
+		t.Errorf("conflicts = %v, want %v", got, want)
+	}
+}
+
+func TestFindIngressConflictsCanary(t *testing.T) {
+	root := networkingv1.HTTPIngressPath{Path: "/", PathType: pathType(networkingv1.PathTypePrefix), Backend: serviceBackend("web", networkingv1.ServiceBackendPort{Number: 80})}
+	primary := newTestIngress("web", "web.example.com", root)
+	canaryRoot := root
+	canaryRoot.Backend = serviceBackend("web-v2", networkingv1.ServiceBackendPort{Number: 80})
+	canary := newTestIngress("web-canary", "web.example.com", canaryRoot)
+	weight := 10
+	canary.Annotations = (&NginxAnnotations{Canary: &NginxCanary{Weight: &weight}}).Render(nil)
+
+	if conflicts := FindIngressConflicts([]networkingv1.Ingress{*primary, *canary}); len(conflicts) != 0 {
+		t.Errorf("primary and canary: conflicts = %+v, want none", conflicts)
+	}
+
+	// A second canary for the same path competes with the first one
+	other := canary.DeepCopy()
+	other.Name = "web-canary-2"
+	if conflicts := FindIngressConflicts([]networkingv1.Ingress{*primary, *canary, *other}); len(conflicts) != 1 {
+		t.Errorf("two canaries: conflicts = %+v, want one", conflicts)
+	}
+}
+
+func TestGetIngressConflicts(t *testing.T) {
+	root := networkingv1.HTTPIngressPath{Path: "/", PathType: pathType(networkingv1.PathTypePrefix), Backend: serviceBackend("web", networkingv1.ServiceBackendPort{Number: 80})}
+	stored := newTestIngress("web", "web.example.com", root)
+	other := newTestIngress("web", "web.example.com", root)
+	other.Namespace = "team-b"
+	api := newTestIngressAPI(stored, other)
+
+	// The stored version of the ingress itself is not a conflict, the one in team-b is
+	conflicts, err := api.GetIngressConflicts(context.Background(), stored.DeepCopy())
+	if err != nil {
+		t.Fatalf("GetIngressConflicts: %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Type != ConflictExact {
+		t.Fatalf("conflicts = %+v, want the exact claim of team-b", conflicts)
+	}
+	if warnings := conflictWarnings(conflicts); len(warnings) != 1 || warnings[0] != "web.example.com/ is claimed by team-a/web and team-b/web" {
+		t.Errorf("warnings = %v", warnings)
+	}
+}
+
+func TestListIngressClasses(t *testing.T) {
+	older := metav1.NewTime(time.Now().Add(-time.Hour))
+	api := newTestIngressAPI(
+		&networkingv1.IngressClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx", CreationTimestamp: older, Annotations: map[string]string{networkingv1.AnnotationIsDefaultIngressClass: "true"}},
+			Spec:       networkingv1.IngressClassSpec{Controller: "k8s.io/ingress-nginx"},
+		},
+		&networkingv1.IngressClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx-v2", CreationTimestamp: metav1.Now(), Annotations: map[string]string{networkingv1.AnnotationIsDefaultIngressClass: "true"}},
+			Spec:       networkingv1.IngressClassSpec{Controller: "k8s.io/ingress-nginx"},
+		},
+		&networkingv1.IngressClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "traefik"},
+			Spec:       networkingv1.IngressClassSpec{Controller: "traefik.io/ingress-controller"},
+		},
+	)
+
+	classes, defaultClass, err := api.ListIngressClasses(context.Background())
+	if err != nil {
+		t.Fatalf("ListIngressClasses: %v", err)
+	}
+	if len(classes) != 3 || defaultClass != "nginx-v2" {
+		t.Errorf("classes = %v, default = %q, want the newest default nginx-v2", classes, defaultClass)
+	}
+
+	if class, err := newTestIngressAPI().GetDefaultIngressClass(context.Background()); err != nil || class != nil {
+		t.Errorf("GetDefaultIngressClass without classes = %v, %v, want nil", class, err)
+	}
+}
+
+func TestNginxAnnotations(t *testing.T) {
+	rewrite := "/$2"
+	redirect := false
+	size := "16m"
+	weight := 20
+	nginx := &NginxAnnotations{
+		RewriteTarget: &rewrite,
+		SSLRedirect:   &redirect,
+		ProxyBodySize: &size,
+		Auth:          &NginxAuth{Type: "basic", Secret: "team-a/htpasswd", Realm: "Staff only"},
+		Canary:        &NginxCanary{Weight: &weight, Header: "X-Canary"},
+	}
+	if err := nginx.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	annotations := nginx.Render(map[string]string{
+		"team":                      "a",
+		nginxCanaryCookie:           "stale",
+		nginxAnnotationPrefix + "x": "kept",
+	})
+	want := map[string]string{
+		"team":                      "a",
+		nginxAnnotationPrefix + "x": "kept",
+		nginxRewriteTarget:          "/$2",
+		nginxSSLRedirect:            "false",
+		nginxProxyBodySize:          "16m",
+		nginxAuthType:               "basic",
+		nginxAuthSecret:             "team-a/htpasswd",
+		nginxAuthRealm:              "Staff only",
+		nginxCanary:                 "true",
+		nginxCanaryWeight:           "20",
+		nginxCanaryHeader:           "X-Canary",
+	}
+	if !reflect.DeepEqual(annotations, want) {
+		t.Errorf("annotations = %v, want %v", annotations, want)
+	}
+	if parsed := ParseNginxAnnotations(annotations); !reflect.DeepEqual(parsed, nginx) {
+		t.Errorf("ParseNginxAnnotations = %+v, want %+v", parsed, nginx)
+	}
+	if parsed := ParseNginxAnnotations(map[string]string{"team": "a"}); parsed != nil {
+		t.Errorf("ParseNginxAnnotations without nginx annotations = %+v, want nil", parsed)
+	}
+
+	tooHeavy := 120
+	invalid := []NginxAnnotations{
+		{RewriteTarget: stringPtr("$2")},
+		{ProxyBodySize: stringPtr("16 MB")},
+		{Auth: &NginxAuth{Type: "oauth", Secret: "htpasswd"}},
+		{Auth: &NginxAuth{Type: "basic"}},
+		{Canary: &NginxCanary{}},
+		{Canary: &NginxCanary{Weight: &tooHeavy}},
+		{Canary: &NginxCanary{HeaderValue: "always"}},
+	}
+	for _, n := range invalid {
+		if err := n.Validate(); err == nil {
+			t.Errorf("Validate(%+v) should fail", n)
+		}
+	}
+}
+
+func TestSimulateRoute(t *testing.T) {
+	path := func(p string, t networkingv1.PathType, service string) networkingv1.HTTPIngressPath {
+		return networkingv1.HTTPIngressPath{Path: p, PathType: pathType(t), Backend: serviceBackend(service, networkingv1.ServiceBackendPort{Number: 80})}
+	}
+	older := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	shop := newTestIngress("shop", "shop.example.com",
+		path("/", networkingv1.PathTypePrefix, "web"),
+		path("/api", networkingv1.PathTypePrefix, "api"),
+		path("/api/health", networkingv1.PathTypeExact, "health"),
+	)
+	shop.CreationTimestamp = older
+	duplicate := newTestIngress("shop-copy", "shop.example.com", path("/api", networkingv1.PathTypePrefix, "api-v2"))
+	duplicate.CreationTimestamp = metav1.Now()
+	wildcard := newTestIngress("preview", "*.example.com", path("/", networkingv1.PathTypePrefix, "preview"))
+	fallback := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "fallback", Namespace: "team-a"},
+		Spec:       networkingv1.IngressSpec{DefaultBackend: &networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "default-http", Port: networkingv1.ServiceBackendPort{Number: 80}}}},
+	}
+
+	readyPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "team-a", Labels: map[string]string{"app": "api"}},
+		Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}},
+	}
+	api := newTestIngressAPI(shop, duplicate, wildcard, fallback, readyPod,
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "team-a"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "api"}, Ports: []corev1.ServicePort{{Port: 80}}},
+		},
+	)
+	ctx := context.Background()
+
+	tests := []struct {
+		url       string
+		matchedBy string
+		ingress   string
+		backend   string
+	}{
+		{"https://shop.example.com/api/orders?id=1", MatchPrefix, "shop", "service/api:80"},
+		{"shop.example.com/api/health", MatchExact, "shop", "service/health:80"},
+		{"shop.example.com/apis", MatchPrefix, "shop", "service/web:80"},
+		{"http://SHOP.example.com:8080", MatchPrefix, "shop", "service/web:80"},
+		{"beta.example.com/cart", MatchPrefix, "preview", "service/preview:80"},
+		{"a.beta.example.com/", MatchDefaultBackend, "fallback", "service/default-http:80"},
+	}
+	for _, tt := range tests {
+		match, err := api.SimulateRoute(ctx, tt.url, "")
+		if err != nil {
+			t.Fatalf("SimulateRoute(%s): %v", tt.url, err)
+		}
+		if !match.Matched || match.MatchedBy != tt.matchedBy || match.Route.Name != tt.ingress || match.Route.Backend != tt.backend {
+			t.Errorf("SimulateRoute(%s) = %+v, want %s via %s to %s", tt.url, match, tt.ingress, tt.matchedBy, tt.backend)
+		}
+	}
+
+	// The older ingress wins the tie on /api and the pods behind its service are reported
+	match, err := api.SimulateRoute(ctx, "shop.example.com/api", "")
+	if err != nil {
+		t.Fatalf("SimulateRoute: %v", err)
+	}
+	if len(match.Shadowed) != 2 || match.Backend == nil || !match.Backend.Exists || match.Backend.ReadyPods != 1 {
+		t.Errorf("match = %+v, backend = %+v", match, match.Backend)
+	}
+
+	if match, err := api.SimulateRoute(ctx, "shop.example.com/", "nginx"); err != nil || match.Matched {
+		t.Errorf("SimulateRoute with an unused class = %+v, %v, want no match", match, err)
+	}
+	if _, err := api.SimulateRoute(ctx, "http:///path", ""); !errors.Is(err, ErrInvalidURL) {
+		t.Errorf("SimulateRoute without host: err = %v, want ErrInvalidURL", err)
+	}
+}
+
+func TestIngressRequestRoundTrip(t *testing.T) {
+	body := `{
+		"defaultBackend": {"resource": {"apiGroup": "k8s.example.com", "kind": "StorageBucket", "name": "static"}},
+		"rules": [
+			{"host": "shop.example.com", "paths": [
+				{"path": "/", "pathType": "Prefix", "serviceName": "web", "servicePort": 80},
+				{"path": "/api", "pathType": "Prefix", "serviceName": "api", "servicePort": "http"},
+				{"path": "/assets", "pathType": "Prefix", "backend": {"resource": {"kind": "StorageBucket", "name": "assets"}}}
+			]},
+			{"host": "tls.example.com"}
+		]
+	}`
+	var request IngressRequest
+	if err := json.Unmarshal([]byte(body), &request); err != nil {
+		t.Fatalf("unmarshal request: %v", err)
+	}
+	if err := request.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	ingress := &networkingv1.Ingress{}
+	request.Apply(ingress)
+
+	if errs := validateIngressSpec(&ingress.Spec); len(errs) != 0 {
+		t.Errorf("applied spec: errors = %v", errs)
+	}
+	if backend := ingress.Spec.DefaultBackend; backend == nil || backend.Resource == nil || backend.Resource.Name != "static" {
+		t.Errorf("defaultBackend = %+v, want resource static", backend)
+	}
+	paths := ingress.Spec.Rules[0].HTTP.Paths
+	if port := paths[0].Backend.Service.Port; port.Number != 80 || port.Name != "" {
+		t.Errorf("paths[0] port = %+v, want number 80", port)
+	}
+	if port := paths[1].Backend.Service.Port; port.Name != "http" || port.Number != 0 {
+		t.Errorf("paths[1] port = %+v, want name http", port)
+	}
+	if paths[2].Backend.Resource == nil || paths[2].Backend.Service != nil {
+		t.Errorf("paths[2] backend = %+v, want resource", paths[2].Backend)
+	}
+	if ingress.Spec.Rules[1].HTTP != nil {
+		t.Errorf("rule without paths has HTTP %+v", ingress.Spec.Rules[1].HTTP)
+	}
+
+	// What is read back can be sent again and yields the same spec
+	data, err := json.Marshal(map[string]interface{}{
+		"defaultBackend": ingress.Spec.DefaultBackend,
+		"rules":          getIngressRules(ingress.Spec.Rules),
+	})
+	if err != nil {
+		t.Fatalf("marshal rules: %v", err)
+	}
+	var readBack IngressRequest
+	if err := json.Unmarshal(data, &readBack); err != nil {
+		t.Fatalf("unmarshal read back: %v", err)
+	}
+	if err := readBack.Validate(); err != nil {
+		t.Fatalf("read back Validate() error = %v", err)
+	}
+	roundTrip := &networkingv1.Ingress{}
+	readBack.Apply(roundTrip)
+	if !reflect.DeepEqual(roundTrip.Spec, ingress.Spec) {
+		t.Errorf("round trip spec = %+v, want %+v", roundTrip.Spec, ingress.Spec)
+	}
+
+	// An empty defaultBackend removes it on update
+	(&IngressRequest{DefaultBackend: &networkingv1.IngressBackend{}}).Apply(roundTrip)
+	if roundTrip.Spec.DefaultBackend != nil {
+		t.Errorf("defaultBackend = %+v after clearing, want nil", roundTrip.Spec.DefaultBackend)
+	}
+
+	invalid := IngressRequest{Rules: []IngressRuleRequest{{Host: "a.example.com", Paths: []IngressPathRequest{{Path: "/", PathType: "Prefix"}}}}}
+	if err := invalid.Validate(); err == nil {
+		t.Error("path without backend: Validate() error = nil")
+	}
+}
+
+func TestGetIngressStatusResourceBackend(t *testing.T) {
+	ingress := newTestIngress("assets", "shop.example.com", networkingv1.HTTPIngressPath{
+		Path:     "/assets",
+		PathType: pathType(networkingv1.PathTypePrefix),
+		Backend: networkingv1.IngressBackend{
+			Resource: &corev1.TypedLocalObjectReference{APIGroup: stringPtr("k8s.example.com"), Kind: "StorageBucket", Name: "assets"},
+		},
+	})
+	ingress.Spec.Rules = append(ingress.Spec.Rules, networkingv1.IngressRule{Host: "tls.example.com"})
+
+	response, err := newTestIngressAPI(ingress).GetIngressStatus(context.Background(), "team-a", "assets")
+	if err != nil {
+		t.Fatalf("GetIngressStatus() error = %v", err)
+	}
+	data := response.Data.(map[string]interface{})
+	rules := data["rules"].([]map[string]interface{})
+	if len(rules) != 2 {
+		t.Fatalf("rules = %v, want 2", rules)
+	}
+	paths := rules[0]["paths"].([]map[string]interface{})
+	if backend := paths[0]["backend"].(networkingv1.IngressBackend); backend.Resource == nil || backend.Resource.Name != "assets" {
+		t.Errorf("backend = %+v, want resource assets", backend)
+	}
+	if paths := rules[1]["paths"].([]map[string]interface{}); paths != nil {
+		t.Errorf("rule without HTTP: paths = %v, want nil", paths)
+	}
+}
+
+func TestIngressRequestClearsRulesAndTLS(t *testing.T) {
+	ingress := newTestIngress("web", "shop.example.com", networkingv1.HTTPIngressPath{
+		Path: "/", PathType: pathType(networkingv1.PathTypePrefix), Backend: serviceBackend("web", networkingv1.ServiceBackendPort{Number: 80}),
+	})
+	ingress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: []string{"shop.example.com"}, SecretName: "shop-tls"}}
+
+	// Omitted lists are left unchanged
+	var request IngressRequest
+	if err := json.Unmarshal([]byte(`{"labels": {"app": "web"}}`), &request); err != nil {
+		t.Fatalf("unmarshal request: %v", err)
+	}
+	request.Apply(ingress)
+	if len(ingress.Spec.Rules) != 1 || len(ingress.Spec.TLS) != 1 {
+		t.Fatalf("spec = %+v, want rules and TLS unchanged", ingress.Spec)
+	}
+
+	// An explicit [] removes them, turning the ingress into a defaultBackend-only one
+	request = IngressRequest{}
+	body := `{"defaultBackend": {"service": {"name": "web", "port": {"number": 80}}}, "rules": [], "tls": []}`
+	if err := json.Unmarshal([]byte(body), &request); err != nil {
+		t.Fatalf("unmarshal request: %v", err)
+	}
+	request.Apply(ingress)
+	if ingress.Spec.Rules != nil || ingress.Spec.TLS != nil {
+		t.Errorf("spec = %+v, want rules and TLS removed", ingress.Spec)
+	}
+	if errs := validateIngressSpec(&ingress.Spec); len(errs) != 0 {
+		t.Errorf("defaultBackend-only spec: errors = %v", errs)
+	}
+}
@@ -0,0 +1,226 @@
+package ingress
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// nginxAnnotationPrefix is the annotation prefix read by ingress-nginx
+const nginxAnnotationPrefix = "nginx.ingress.kubernetes.io/"
+
+// Annotations managed by NginxAnnotations
+const (
+	nginxRewriteTarget      = nginxAnnotationPrefix + "rewrite-target"
+	nginxSSLRedirect        = nginxAnnotationPrefix + "ssl-redirect"
+	nginxProxyBodySize      = nginxAnnotationPrefix + "proxy-body-size"
+	nginxAuthType           = nginxAnnotationPrefix + "auth-type"
+	nginxAuthSecret         = nginxAnnotationPrefix + "auth-secret"
+	nginxAuthSecretType     = nginxAnnotationPrefix + "auth-secret-type"
+	nginxAuthRealm          = nginxAnnotationPrefix + "auth-realm"
+	nginxCanary             = nginxAnnotationPrefix + "canary"
+	nginxCanaryWeight       = nginxAnnotationPrefix + "canary-weight"
+	nginxCanaryWeightTotal  = nginxAnnotationPrefix + "canary-weight-total"
+	nginxCanaryHeader       = nginxAnnotationPrefix + "canary-by-header"
+	nginxCanaryHeaderValue  = nginxAnnotationPrefix + "canary-by-header-value"
+	nginxCanaryHeaderRegexp = nginxAnnotationPrefix + "canary-by-header-pattern"
+	nginxCanaryCookie       = nginxAnnotationPrefix + "canary-by-cookie"
+)
+
+// proxyBodySizePattern matches nginx sizes such as 0 (unlimited), 512k, 8m or 1g
+var proxyBodySizePattern = regexp.MustCompile(`^[0-9]+[kKmMgG]?$`)
+
+// NginxAnnotations are typed helpers for the common ingress-nginx annotations.
+// Unset fields leave the corresponding annotations untouched.
+type NginxAnnotations struct {
+	RewriteTarget *string      `json:"rewriteTarget,omitempty"`
+	SSLRedirect   *bool        `json:"sslRedirect,omitempty"`
+	ProxyBodySize *string      `json:"proxyBodySize,omitempty"`
+	Auth          *NginxAuth   `json:"auth,omitempty"`
+	Canary        *NginxCanary `json:"canary,omitempty"`
+}
+
+// NginxAuth configures basic or digest authentication from an htpasswd secret.
+// Secret may be given as namespace/name.
+type NginxAuth struct {
+	Type       string `json:"type"`
+	Secret     string `json:"secret"`
+	SecretType string `json:"secretType,omitempty"`
+	Realm      string `json:"realm,omitempty"`
+}
+
+// NginxCanary marks the ingress as a canary of another ingress for the same host and path.
+// Header and cookie rules take precedence over the weight.
+type NginxCanary struct {
+	Weight        *int   `json:"weight,omitempty"`
+	WeightTotal   *int   `json:"weightTotal,omitempty"`
+	Header        string `json:"header,omitempty"`
+	HeaderValue   string `json:"headerValue,omitempty"`
+	HeaderPattern string `json:"headerPattern,omitempty"`
+	Cookie        string `json:"cookie,omitempty"`
+}
+
+// Validate checks the values before they are rendered into annotations
+func (n *NginxAnnotations) Validate() error {
+	if n.RewriteTarget != nil && !strings.HasPrefix(*n.RewriteTarget, "/") {
+		return fmt.Errorf("nginx.rewriteTarget must start with /, e.g. /$2")
+	}
+	if n.ProxyBodySize != nil && !proxyBodySizePattern.MatchString(*n.ProxyBodySize) {
+		return fmt.Errorf("nginx.proxyBodySize must be a size such as 8m, 512k or 0 for unlimited")
+	}
+
+	if auth := n.Auth; auth != nil {
+		if auth.Type != "basic" && auth.Type != "digest" {
+			return fmt.Errorf("nginx.auth.type must be basic or digest")
+		}
+		if auth.Secret == "" {
+			return fmt.Errorf("nginx.auth.secret is required")
+		}
+		for _, part := range strings.SplitN(auth.Secret, "/", 2) {
+			if errs := validation.IsDNS1123Subdomain(part); len(errs) > 0 {
+				return fmt.Errorf("nginx.auth.secret must be a secret name or namespace/name: %s", strings.Join(errs, "; "))
+			}
+		}
+		if auth.SecretType != "" && auth.SecretType != "auth-file" && auth.SecretType != "auth-map" {
+			return fmt.Errorf("nginx.auth.secretType must be auth-file or auth-map")
+		}
+	}
+
+	if canary := n.Canary; canary != nil {
+		if canary.Weight == nil && canary.Header == "" && canary.Cookie == "" {
+			return fmt.Errorf("nginx.canary needs a weight, header or cookie")
+		}
+		total := 100
+		if canary.WeightTotal != nil {
+			if *canary.WeightTotal < 1 {
+				return fmt.Errorf("nginx.canary.weightTotal must be positive")
+			}
+			total = *canary.WeightTotal
+		}
+		if canary.Weight != nil && (*canary.Weight < 0 || *canary.Weight > total) {
+			return fmt.Errorf("nginx.canary.weight must be between 0 and %d", total)
+		}
+		if (canary.HeaderValue != "" || canary.HeaderPattern != "") && canary.Header == "" {
+			return fmt.Errorf("nginx.canary.headerValue and headerPattern require header")
+		}
+		if canary.HeaderValue != "" && canary.HeaderPattern != "" {
+			return fmt.Errorf("nginx.canary.headerValue and headerPattern are mutually exclusive")
+		}
+		if canary.HeaderPattern != "" {
+			if _, err := regexp.Compile(canary.HeaderPattern); err != nil {
+				return fmt.Errorf("nginx.canary.headerPattern: %v", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Render writes the set fields into the annotations and returns them. The auth and canary
+// annotations are replaced as a group so that stale keys of a previous configuration do not remain.
+func (n *NginxAnnotations) Render(annotations map[string]string) map[string]string {
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	if n.RewriteTarget != nil {
+		annotations[nginxRewriteTarget] = *n.RewriteTarget
+	}
+	if n.SSLRedirect != nil {
+		annotations[nginxSSLRedirect] = strconv.FormatBool(*n.SSLRedirect)
+	}
+	if n.ProxyBodySize != nil {
+		annotations[nginxProxyBodySize] = *n.ProxyBodySize
+	}
+
+	if auth := n.Auth; auth != nil {
+		for _, key := range []string{nginxAuthType, nginxAuthSecret, nginxAuthSecretType, nginxAuthRealm} {
+			delete(annotations, key)
+		}
+		annotations[nginxAuthType] = auth.Type
+		annotations[nginxAuthSecret] = auth.Secret
+		setIfNotEmpty(annotations, nginxAuthSecretType, auth.SecretType)
+		setIfNotEmpty(annotations, nginxAuthRealm, auth.Realm)
+	}
+
+	if canary := n.Canary; canary != nil {
+		for _, key := range []string{nginxCanaryWeight, nginxCanaryWeightTotal, nginxCanaryHeader,
+			nginxCanaryHeaderValue, nginxCanaryHeaderRegexp, nginxCanaryCookie} {
+			delete(annotations, key)
+		}
+		annotations[nginxCanary] = "true"
+		if canary.Weight != nil {
+			annotations[nginxCanaryWeight] = strconv.Itoa(*canary.Weight)
+		}
+		if canary.WeightTotal != nil {
+			annotations[nginxCanaryWeightTotal] = strconv.Itoa(*canary.WeightTotal)
+		}
+		setIfNotEmpty(annotations, nginxCanaryHeader, canary.Header)
+		setIfNotEmpty(annotations, nginxCanaryHeaderValue, canary.HeaderValue)
+		setIfNotEmpty(annotations, nginxCanaryHeaderRegexp, canary.HeaderPattern)
+		setIfNotEmpty(annotations, nginxCanaryCookie, canary.Cookie)
+	}
+
+	return annotations
+}
+
+// ParseNginxAnnotations reads the typed view back from the annotations of an ingress.
+// It returns nil when none of the managed annotations are set.
+func ParseNginxAnnotations(annotations map[string]string) *NginxAnnotations {
+	result := &NginxAnnotations{}
+	found := false
+
+	if value, ok := annotations[nginxRewriteTarget]; ok {
+		result.RewriteTarget = &value
+		found = true
+	}
+	if value, err := strconv.ParseBool(annotations[nginxSSLRedirect]); err == nil {
+		result.SSLRedirect = &value
+		found = true
+	}
+	if value, ok := annotations[nginxProxyBodySize]; ok {
+		result.ProxyBodySize = &value
+		found = true
+	}
+	if authType, ok := annotations[nginxAuthType]; ok {
+		result.Auth = &NginxAuth{
+			Type:       authType,
+			Secret:     annotations[nginxAuthSecret],
+			SecretType: annotations[nginxAuthSecretType],
+			Realm:      annotations[nginxAuthRealm],
+		}
+		found = true
+	}
+	if annotations[nginxCanary] == "true" {
+		canary := &NginxCanary{
+			Header:        annotations[nginxCanaryHeader],
+			HeaderValue:   annotations[nginxCanaryHeaderValue],
+			HeaderPattern: annotations[nginxCanaryHeaderRegexp],
+			Cookie:        annotations[nginxCanaryCookie],
+		}
+		if value, err := strconv.Atoi(annotations[nginxCanaryWeight]); err == nil {
+			canary.Weight = &value
+		}
+		if value, err := strconv.Atoi(annotations[nginxCanaryWeightTotal]); err == nil {
+			canary.WeightTotal = &value
+		}
+		result.Canary = canary
+		found = true
+	}
+
+	if !found {
+		return nil
+	}
+	return result
+}
+
+// Helper functions
+
+func setIfNotEmpty(annotations map[string]string, key, value string) {
+	if value != "" {
+		annotations[key] = value
+	}
+}
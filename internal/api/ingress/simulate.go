@@ -0,0 +1,288 @@
+package ingress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s-glance-backend/internal/api/service"
+)
+
+// ErrInvalidURL is returned when the URL to simulate cannot be parsed or has no host
+var ErrInvalidURL = errors.New("invalid url")
+
+// Ways a simulated request can be matched
+const (
+	MatchExact          = "Exact"
+	MatchPrefix         = "Prefix"
+	MatchDefaultBackend = "defaultBackend"
+)
+
+// RouteMatch is the result of simulating a request against the ingresses of the cluster
+type RouteMatch struct {
+	Host      string           `json:"host"`
+	Path      string           `json:"path"`
+	Matched   bool             `json:"matched"`
+	MatchedBy string           `json:"matchedBy,omitempty"`
+	Reason    string           `json:"reason"`
+	Route     *IngressPathRef  `json:"route,omitempty"`
+	Shadowed  []IngressPathRef `json:"shadowed"`
+	Backend   *RouteBackend    `json:"backend,omitempty"`
+}
+
+// RouteBackend is the service a matched route sends traffic to and the pods currently behind it
+type RouteBackend struct {
+	Service   string                   `json:"service"`
+	Namespace string                   `json:"namespace"`
+	Port      string                   `json:"port"`
+	Exists    bool                     `json:"exists"`
+	Type      string                   `json:"type,omitempty"`
+	Pods      []map[string]interface{} `json:"pods"`
+	ReadyPods int                      `json:"readyPods"`
+}
+
+// SimulateRoute determines which ingress rule and backend would serve the URL, following the
+// Kubernetes precedence: an exact host beats a wildcard host, which beats rules without a host;
+// then Exact paths beat Prefix paths and the longest prefix wins. ImplementationSpecific paths are
+// treated as prefixes. Remaining ties go to the oldest ingress, as ingress-nginx does.
+// When class is set, only ingresses of that class are considered.
+func (api *IngressAPI) SimulateRoute(ctx context.Context, rawURL, class string) (*RouteMatch, error) {
+	api.LogInfo(ctx, "SimulateRoute", fmt.Sprintf("Simulating request to %s", rawURL))
+
+	host, path, err := parseRouteURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	ingresses, err := api.GetClientset().NetworkingV1().Ingresses("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "SimulateRoute", err)
+		return nil, api.HandleError(err, "list ingresses")
+	}
+
+	var candidates []networkingv1.Ingress
+	for _, ing := range ingresses.Items {
+		if class == "" || getIngressClass(&ing) == class {
+			candidates = append(candidates, ing)
+		}
+	}
+	sortByAge(candidates)
+
+	match := matchRoute(candidates, host, path)
+	if match.Route == nil {
+		return match, nil
+	}
+
+	// The backend lives in the namespace of the ingress
+	backend, err := api.getRouteBackend(ctx, match.Route.Namespace, candidates, match.Route)
+	if err != nil {
+		return nil, err
+	}
+	match.Backend = backend
+	return match, nil
+}
+
+// Helper functions
+
+// parseRouteURL accepts full URLs and scheme-less host/path forms such as shop.example.com/cart
+func parseRouteURL(rawURL string) (string, string, error) {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "http://" + rawURL
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if host == "" {
+		return "", "", fmt.Errorf("%w: a host is required", ErrInvalidURL)
+	}
+	if net.ParseIP(host) != nil {
+		// Requests by IP only match rules without a host
+		host = ""
+	}
+	path := parsed.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	return host, path, nil
+}
+
+// matchRoute picks the path that serves the request among ingresses sorted oldest first
+func matchRoute(ingresses []networkingv1.Ingress, host, path string) *RouteMatch {
+	match := &RouteMatch{Host: host, Path: path, Shadowed: []IngressPathRef{}}
+
+	// Only the most specific host tier serves the request; a matching host without a matching
+	// path does not fall back to wildcard or host-less rules
+	refs := indexIngressPaths(ingresses)
+	bestHost := 0
+	for _, ref := range refs {
+		if rank := hostMatchRank(ref.Host, host); rank > bestHost {
+			bestHost = rank
+		}
+	}
+
+	var best *IngressPathRef
+	bestExact, bestLength := false, -1
+	for i := range refs {
+		ref := &refs[i]
+		if bestHost == 0 || hostMatchRank(ref.Host, host) != bestHost {
+			continue
+		}
+		exact := ref.PathType == string(networkingv1.PathTypeExact)
+		if (exact && ref.Path != path) || (!exact && !pathHasPrefix(path, ref.Path)) {
+			continue
+		}
+
+		length := len(normalizePath(ref.Path))
+		if best == nil || (exact && !bestExact) || (exact == bestExact && length > bestLength) {
+			if best != nil {
+				match.Shadowed = append(match.Shadowed, *best)
+			}
+			best, bestExact, bestLength = ref, exact, length
+		} else {
+			match.Shadowed = append(match.Shadowed, *ref)
+		}
+	}
+
+	if best != nil {
+		match.Matched = true
+		match.Route = best
+		match.MatchedBy = MatchPrefix
+		if bestExact {
+			match.MatchedBy = MatchExact
+		}
+		match.Reason = fmt.Sprintf("%s path %s of ingress %s/%s", match.MatchedBy, best.Path, best.Namespace, best.Name)
+		return match
+	}
+
+	for _, ing := range ingresses {
+		if ing.Spec.DefaultBackend != nil {
+			match.Matched = true
+			match.MatchedBy = MatchDefaultBackend
+			match.Route = &IngressPathRef{
+				Namespace: ing.Namespace,
+				Name:      ing.Name,
+				Class:     getIngressClass(&ing),
+				Backend:   backendString(ing.Spec.DefaultBackend),
+			}
+			match.Reason = fmt.Sprintf("no rule matches; the defaultBackend of ingress %s/%s serves the request", ing.Namespace, ing.Name)
+			return match
+		}
+	}
+
+	match.Reason = "no rule matches; the ingress controller's own default backend answers, usually with 404"
+	if bestHost > 0 {
+		match.Reason = "the host matches but none of its paths do; the ingress controller's own default backend answers, usually with 404"
+	}
+	return match
+}
+
+// hostMatchRank scores how specifically a rule host matches the request host:
+// 3 for the same host, 2 for a wildcard covering exactly one label, 1 for rules without a host
+func hostMatchRank(ruleHost, host string) int {
+	switch {
+	case ruleHost == "":
+		return 1
+	case host == "":
+		return 0
+	case strings.EqualFold(ruleHost, host):
+		return 3
+	case strings.HasPrefix(ruleHost, "*."):
+		suffix := strings.ToLower(ruleHost[1:])
+		if strings.HasSuffix(host, suffix) {
+			label := strings.TrimSuffix(host, suffix)
+			if label != "" && !strings.Contains(label, ".") {
+				return 2
+			}
+		}
+	}
+	return 0
+}
+
+// sortByAge orders ingresses oldest first, by namespace and name when created at the same time
+func sortByAge(ingresses []networkingv1.Ingress) {
+	sort.SliceStable(ingresses, func(i, j int) bool {
+		a, b := ingresses[i], ingresses[j]
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return a.CreationTimestamp.Before(&b.CreationTimestamp)
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+}
+
+// findRouteBackend returns the backend of the matched path or default backend
+func findRouteBackend(ingresses []networkingv1.Ingress, route *IngressPathRef) *networkingv1.IngressBackend {
+	for i := range ingresses {
+		ing := &ingresses[i]
+		if ing.Namespace != route.Namespace || ing.Name != route.Name {
+			continue
+		}
+		if route.Path == "" && route.PathType == "" {
+			return ing.Spec.DefaultBackend
+		}
+		for _, rule := range ing.Spec.Rules {
+			if rule.Host != route.Host || rule.HTTP == nil {
+				continue
+			}
+			for j := range rule.HTTP.Paths {
+				path := &rule.HTTP.Paths[j]
+				if path.Path == route.Path && backendString(&path.Backend) == route.Backend {
+					return &path.Backend
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// getRouteBackend resolves the service of the matched route and the pods its selector matches
+func (api *IngressAPI) getRouteBackend(ctx context.Context, namespace string, ingresses []networkingv1.Ingress, route *IngressPathRef) (*RouteBackend, error) {
+	backend := findRouteBackend(ingresses, route)
+	if backend == nil || backend.Service == nil {
+		// Resource backends are served by the controller, not by pods
+		return nil, nil
+	}
+
+	result := &RouteBackend{
+		Service:   backend.Service.Name,
+		Namespace: namespace,
+		Port:      strings.Trim(backendPortString(backend.Service.Port), `"`),
+		Pods:      []map[string]interface{}{},
+	}
+
+	svc, err := api.GetClientset().CoreV1().Services(namespace).Get(ctx, backend.Service.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return result, nil
+	}
+	if err != nil {
+		api.LogError(ctx, "SimulateRoute", err)
+		return nil, api.HandleError(err, "get backend service")
+	}
+	result.Exists = true
+	result.Type = string(svc.Spec.Type)
+
+	pods, err := service.ListSelectedPods(ctx, api.GetClientset(), svc)
+	if err != nil {
+		api.LogError(ctx, "SimulateRoute", err)
+		return nil, api.HandleError(err, "list backend pods")
+	}
+	result.Pods = service.GetMatchedPods(pods)
+	for i := range pods {
+		if service.IsPodReady(&pods[i]) {
+			result.ReadyPods++
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,278 @@
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// FieldError describes an invalid ingress field, addressed by its path in the spec
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// FieldErrors is returned by CreateIngress and UpdateIngress when the ingress is invalid
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fieldError := range e {
+		messages = append(messages, fieldError.Field+": "+fieldError.Message)
+	}
+	return "invalid ingress: " + strings.Join(messages, "; ")
+}
+
+func (e *FieldErrors) add(field, format string, args ...interface{}) {
+	*e = append(*e, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// Path sequences and suffixes the API server rejects for Exact and Prefix paths
+var (
+	invalidPathSequences = []string{"//", "/./", "/../", "%2f", "%2F"}
+	invalidPathSuffixes  = []string{"/..", "/."}
+)
+
+// regexCharacters only have a meaning for ImplementationSpecific paths, e.g. with ingress-nginx use-regex
+const regexCharacters = `^$*+?()[]{}|\`
+
+// ValidateIngress checks the spec of an ingress and that the services and TLS secrets it references
+// exist in the namespace, the services expose the referenced ports and the secrets are of type
+// kubernetes.io/tls. The returned error is only set when the references could not be looked up.
+func (api *IngressAPI) ValidateIngress(ctx context.Context, namespace string, ingress *networkingv1.Ingress) (FieldErrors, error) {
+	errs := validateIngressSpec(&ingress.Spec)
+
+	services := make(map[string]*corev1.Service)
+	checkService := func(field string, backend *networkingv1.IngressServiceBackend) error {
+		svc, seen := services[backend.Name]
+		if !seen {
+			result, err := api.GetClientset().CoreV1().Services(namespace).Get(ctx, backend.Name, metav1.GetOptions{})
+			if err != nil && !apierrors.IsNotFound(err) {
+				return api.HandleError(err, "get backend service")
+			}
+			if err == nil {
+				svc = result
+			}
+			services[backend.Name] = svc
+		}
+		if svc == nil {
+			errs.add(field+".name", "service %q does not exist in namespace %s", backend.Name, namespace)
+			return nil
+		}
+		// ExternalName services have no ports to check
+		if svc.Spec.Type != corev1.ServiceTypeExternalName && !serviceExposesPort(svc, backend.Port) {
+			errs.add(field+".port", "service %q does not expose port %s (available: %s)",
+				backend.Name, backendPortString(backend.Port), servicePortList(svc))
+		}
+		return nil
+	}
+
+	if backend := ingress.Spec.DefaultBackend; backend != nil && backend.Service != nil {
+		if err := checkService("spec.defaultBackend.service", backend.Service); err != nil {
+			return nil, err
+		}
+	}
+	for i, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for j, path := range rule.HTTP.Paths {
+			if path.Backend.Service == nil {
+				continue
+			}
+			field := fmt.Sprintf("spec.rules[%d].http.paths[%d].backend.service", i, j)
+			if err := checkService(field, path.Backend.Service); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for i, tls := range ingress.Spec.TLS {
+		if tls.SecretName == "" {
+			continue
+		}
+		field := fmt.Sprintf("spec.tls[%d].secretName", i)
+		tlsSecret, err := api.GetClientset().CoreV1().Secrets(namespace).Get(ctx, tls.SecretName, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			errs.add(field, "secret %q does not exist in namespace %s", tls.SecretName, namespace)
+		case err != nil:
+			return nil, api.HandleError(err, "get tls secret")
+		case tlsSecret.Type != corev1.SecretTypeTLS:
+			errs.add(field, "secret %q is of type %s, want %s", tls.SecretName, tlsSecret.Type, corev1.SecretTypeTLS)
+		}
+	}
+
+	return errs, nil
+}
+
+// Helper functions
+
+// validateIngressSpec checks the spec on its own, without looking up referenced objects
+func validateIngressSpec(spec *networkingv1.IngressSpec) FieldErrors {
+	var errs FieldErrors
+
+	if spec.DefaultBackend == nil && len(spec.Rules) == 0 {
+		errs.add("spec", "either defaultBackend or rules must be specified")
+	}
+	if spec.IngressClassName != nil {
+		for _, msg := range validation.IsDNS1123Subdomain(*spec.IngressClassName) {
+			errs.add("spec.ingressClassName", "%s", msg)
+		}
+	}
+	if spec.DefaultBackend != nil {
+		validateBackend(&errs, "spec.defaultBackend", spec.DefaultBackend)
+	}
+
+	for i, rule := range spec.Rules {
+		field := fmt.Sprintf("spec.rules[%d]", i)
+		if rule.Host != "" {
+			validateHost(&errs, field+".host", rule.Host)
+		}
+		if rule.HTTP == nil {
+			continue
+		}
+		if len(rule.HTTP.Paths) == 0 {
+			errs.add(field+".http.paths", "at least one path is required")
+		}
+		for j, path := range rule.HTTP.Paths {
+			pathField := fmt.Sprintf("%s.http.paths[%d]", field, j)
+			validatePath(&errs, pathField, path)
+			validateBackend(&errs, pathField+".backend", &path.Backend)
+		}
+	}
+
+	for i, tls := range spec.TLS {
+		for j, host := range tls.Hosts {
+			validateHost(&errs, fmt.Sprintf("spec.tls[%d].hosts[%d]", i, j), host)
+		}
+	}
+
+	return errs
+}
+
+func validatePath(errs *FieldErrors, field string, path networkingv1.HTTPIngressPath) {
+	if path.PathType == nil {
+		errs.add(field+".pathType", "pathType is required")
+		return
+	}
+
+	switch *path.PathType {
+	case networkingv1.PathTypeExact, networkingv1.PathTypePrefix:
+		if !strings.HasPrefix(path.Path, "/") {
+			errs.add(field+".path", "must be an absolute path starting with /")
+			return
+		}
+		for _, sequence := range invalidPathSequences {
+			if strings.Contains(path.Path, sequence) {
+				errs.add(field+".path", "must not contain %q", sequence)
+			}
+		}
+		for _, suffix := range invalidPathSuffixes {
+			if strings.HasSuffix(path.Path, suffix) {
+				errs.add(field+".path", "must not end with %q", suffix)
+			}
+		}
+		if strings.ContainsAny(path.Path, regexCharacters) {
+			errs.add(field+".path", "regular expressions require pathType %s", networkingv1.PathTypeImplementationSpecific)
+		}
+	case networkingv1.PathTypeImplementationSpecific:
+		if path.Path != "" && !strings.HasPrefix(path.Path, "/") {
+			errs.add(field+".path", "must be an absolute path starting with /")
+		}
+	default:
+		errs.add(field+".pathType", "must be one of %s, %s or %s", networkingv1.PathTypeExact,
+			networkingv1.PathTypePrefix, networkingv1.PathTypeImplementationSpecific)
+	}
+}
+
+func validateBackend(errs *FieldErrors, field string, backend *networkingv1.IngressBackend) {
+	switch {
+	case backend.Service != nil && backend.Resource != nil:
+		errs.add(field, "service and resource are mutually exclusive")
+	case backend.Service == nil && backend.Resource == nil:
+		errs.add(field, "a service or resource backend is required")
+	case backend.Service != nil:
+		if backend.Service.Name == "" {
+			errs.add(field+".service.name", "service name is required")
+		} else {
+			for _, msg := range validation.IsDNS1035Label(backend.Service.Name) {
+				errs.add(field+".service.name", "%s", msg)
+			}
+		}
+		port := backend.Service.Port
+		switch {
+		case port.Name != "" && port.Number != 0:
+			errs.add(field+".service.port", "port name and number are mutually exclusive")
+		case port.Name != "":
+			for _, msg := range validation.IsValidPortName(port.Name) {
+				errs.add(field+".service.port.name", "%s", msg)
+			}
+		default:
+			for _, msg := range validation.IsValidPortNum(int(port.Number)) {
+				errs.add(field+".service.port.number", "%s", msg)
+			}
+		}
+	default:
+		if backend.Resource.Kind == "" || backend.Resource.Name == "" {
+			errs.add(field+".resource", "resource kind and name are required")
+		}
+	}
+}
+
+// validateHost accepts DNS names with an optional leading wildcard label, but no IP addresses
+func validateHost(errs *FieldErrors, field, host string) {
+	if net.ParseIP(host) != nil {
+		errs.add(field, "must be a DNS name, not an IP address")
+		return
+	}
+	name := strings.TrimPrefix(host, "*.")
+	if strings.Contains(name, "*") {
+		errs.add(field, "a wildcard is only allowed as the first label, e.g. *.example.com")
+		return
+	}
+	for _, msg := range validation.IsDNS1123Subdomain(name) {
+		errs.add(field, "%s", msg)
+	}
+}
+
+func serviceExposesPort(svc *corev1.Service, port networkingv1.ServiceBackendPort) bool {
+	for _, servicePort := range svc.Spec.Ports {
+		if port.Name != "" && servicePort.Name == port.Name {
+			return true
+		}
+		if port.Name == "" && servicePort.Port == port.Number {
+			return true
+		}
+	}
+	return false
+}
+
+func backendPortString(port networkingv1.ServiceBackendPort) string {
+	if port.Name != "" {
+		return fmt.Sprintf("%q", port.Name)
+	}
+	return fmt.Sprintf("%d", port.Number)
+}
+
+func servicePortList(svc *corev1.Service) string {
+	ports := make([]string, 0, len(svc.Spec.Ports))
+	for _, port := range svc.Spec.Ports {
+		if port.Name != "" {
+			ports = append(ports, fmt.Sprintf("%s/%d", port.Name, port.Port))
+		} else {
+			ports = append(ports, fmt.Sprintf("%d", port.Port))
+		}
+	}
+	if len(ports) == 0 {
+		return "none"
+	}
+	return strings.Join(ports, ", ")
+}
@@ -0,0 +1,122 @@
+package job
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/client-go/kubernetes"
+)
+
+type Handler struct {
+	api *JobAPI
+}
+
+func NewHandler(clientset *kubernetes.Clientset, logger *log.Logger) *Handler {
+	if logger == nil {
+		logger = log.New(gin.DefaultWriter, "[JOB-API] ", log.LstdFlags)
+	}
+
+	return &Handler{
+		api: NewJobAPI(clientset, logger),
+	}
+}
+
+// ListJobs handles GET /api/v1/jobs/namespaces/:namespace
+func (h *Handler) ListJobs(c *gin.Context) {
+	namespace := c.Param("namespace")
+	jobs, err := h.api.ListJobs(c.Request.Context(), namespace)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	now := time.Now()
+	var response []map[string]interface{}
+	for i := range jobs.Items {
+		summary := GetJobSummary(&jobs.Items[i], now)
+		summary["labels"] = jobs.Items[i].Labels
+		response = append(response, summary)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    response,
+	})
+}
+
+// GetJob handles GET /api/v1/jobs/namespaces/:namespace/:name
+func (h *Handler) GetJob(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	job, err := h.api.GetJob(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"name":                    job.Name,
+			"namespace":               job.Namespace,
+			"completions":             job.Spec.Completions,
+			"parallelism":             job.Spec.Parallelism,
+			"backoffLimit":            job.Spec.BackoffLimit,
+			"activeDeadlineSeconds":   job.Spec.ActiveDeadlineSeconds,
+			"ttlSecondsAfterFinished": job.Spec.TTLSecondsAfterFinished,
+			"suspend":                 job.Spec.Suspend,
+			"selector":                job.Spec.Selector,
+			"ownerReferences":         job.OwnerReferences,
+			"creationTime":            job.CreationTimestamp,
+			"labels":                  job.Labels,
+			"annotations":             job.Annotations,
+			"containers":              job.Spec.Template.Spec.Containers,
+		},
+	})
+}
+
+// GetJobStatus handles GET /api/v1/jobs/namespaces/:namespace/:name/status
+func (h *Handler) GetJobStatus(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	status, err := h.api.GetJobStatus(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// DeleteJob handles DELETE /api/v1/jobs/namespaces/:namespace/:name
+func (h *Handler) DeleteJob(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	err := h.api.DeleteJob(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Job deleted successfully",
+	})
+}
@@ -0,0 +1,280 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s-glance-backend/internal/api/base"
+)
+
+// Job states reported in job summaries
+const (
+	StateComplete  = "Complete"
+	StateFailed    = "Failed"
+	StateSuspended = "Suspended"
+	StateRunning   = "Running"
+	StatePending   = "Pending"
+)
+
+// Pod failure backoff used by the Job controller: 10s doubled per failure, capped at 6 minutes
+const (
+	defaultBackoffLimit = 6
+	initialPodBackoff   = 10 * time.Second
+	maxPodBackoff       = 6 * time.Minute
+)
+
+// JobAPI handles job-related operations
+type JobAPI struct {
+	*base.BaseAPI
+}
+
+// NewJobAPI creates a new JobAPI instance
+func NewJobAPI(clientset kubernetes.Interface, logger *log.Logger) *JobAPI {
+	return &JobAPI{
+		BaseAPI: base.NewBaseAPI(clientset, logger),
+	}
+}
+
+// ListJobs returns all jobs in a namespace
+func (api *JobAPI) ListJobs(ctx context.Context, namespace string) (*batchv1.JobList, error) {
+	api.LogInfo(ctx, "ListJobs", fmt.Sprintf("Fetching jobs in namespace: %s", namespace))
+
+	jobs, err := api.GetClientset().BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListJobs", err)
+		return nil, api.HandleError(err, "list jobs")
+	}
+
+	return jobs, nil
+}
+
+// GetJob returns a specific job
+func (api *JobAPI) GetJob(ctx context.Context, namespace, name string) (*batchv1.Job, error) {
+	api.LogInfo(ctx, "GetJob", fmt.Sprintf("Fetching job %s in namespace %s", name, namespace))
+
+	job, err := api.GetClientset().BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetJob", err)
+		return nil, api.HandleError(err, "get job")
+	}
+
+	return job, nil
+}
+
+// GetJobStatus returns detailed status of a job, including its pods with links to their logs
+func (api *JobAPI) GetJobStatus(ctx context.Context, namespace, name string) (*base.APIResponse, error) {
+	api.LogInfo(ctx, "GetJobStatus", fmt.Sprintf("Fetching status for job %s in namespace %s", name, namespace))
+
+	job, err := api.GetJob(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := api.ListJobPods(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	status := GetJobSummary(job, time.Now())
+	status["conditions"] = getJobConditions(job.Status.Conditions)
+	status["backoff"] = getBackoffStatus(job)
+	status["pods"] = getJobPods(pods)
+
+	response := base.NewSuccessResponse(status)
+	return &response, nil
+}
+
+// ListJobPods returns the pods created by a job
+func (api *JobAPI) ListJobPods(ctx context.Context, job *batchv1.Job) ([]corev1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector: %v", err)
+	}
+
+	pods, err := api.GetClientset().CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		api.LogError(ctx, "ListJobPods", err)
+		return nil, api.HandleError(err, "list job pods")
+	}
+
+	return pods.Items, nil
+}
+
+// DeleteJob deletes a specific job together with its pods
+func (api *JobAPI) DeleteJob(ctx context.Context, namespace, name string) error {
+	api.LogInfo(ctx, "DeleteJob", fmt.Sprintf("Deleting job %s in namespace %s", name, namespace))
+
+	propagation := metav1.DeletePropagationBackground
+	err := api.GetClientset().BatchV1().Jobs(namespace).Delete(ctx, name, metav1.DeleteOptions{
+		PropagationPolicy: &propagation,
+	})
+	if err != nil {
+		api.LogError(ctx, "DeleteJob", err)
+		return api.HandleError(err, "delete job")
+	}
+
+	return nil
+}
+
+// GetJobState returns the state of a job and, for failed jobs, the failure reason
+func GetJobState(job *batchv1.Job) (string, string) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return StateComplete, ""
+		case batchv1.JobFailed:
+			return StateFailed, condition.Reason
+		case batchv1.JobSuspended:
+			return StateSuspended, ""
+		}
+	}
+	if job.Status.Active > 0 {
+		return StateRunning, ""
+	}
+	return StatePending, ""
+}
+
+// GetJobSummary returns the completion counters, state and timing of a job
+func GetJobSummary(job *batchv1.Job, now time.Time) map[string]interface{} {
+	state, reason := GetJobState(job)
+
+	completions := int32(1)
+	if job.Spec.Completions != nil {
+		completions = *job.Spec.Completions
+	}
+	parallelism := int32(1)
+	if job.Spec.Parallelism != nil {
+		parallelism = *job.Spec.Parallelism
+	}
+
+	summary := map[string]interface{}{
+		"name":        job.Name,
+		"namespace":   job.Namespace,
+		"state":       state,
+		"completions": fmt.Sprintf("%d/%d", job.Status.Succeeded, completions),
+		"counts": map[string]int32{
+			"desired":     completions,
+			"parallelism": parallelism,
+			"active":      job.Status.Active,
+			"succeeded":   job.Status.Succeeded,
+			"failed":      job.Status.Failed,
+		},
+		"startTime":      job.Status.StartTime,
+		"completionTime": job.Status.CompletionTime,
+		"creationTime":   job.CreationTimestamp,
+	}
+	if reason != "" {
+		summary["failureReason"] = reason
+	}
+
+	if job.Status.StartTime != nil {
+		end := now
+		if job.Status.CompletionTime != nil {
+			end = job.Status.CompletionTime.Time
+		}
+		summary["duration"] = end.Sub(job.Status.StartTime.Time).Round(time.Second).String()
+	}
+
+	return summary
+}
+
+// Helper functions
+
+// getBackoffStatus reports how many pod failures the job may still absorb and the delay
+// the controller applies before recreating a failed pod
+func getBackoffStatus(job *batchv1.Job) map[string]interface{} {
+	limit := int32(defaultBackoffLimit)
+	if job.Spec.BackoffLimit != nil {
+		limit = *job.Spec.BackoffLimit
+	}
+
+	remaining := limit - job.Status.Failed
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	backoff := map[string]interface{}{
+		"limit":            limit,
+		"failed":           job.Status.Failed,
+		"retriesRemaining": remaining,
+	}
+	if job.Spec.ActiveDeadlineSeconds != nil {
+		backoff["activeDeadlineSeconds"] = *job.Spec.ActiveDeadlineSeconds
+	}
+
+	if state, _ := GetJobState(job); job.Status.Failed > 0 && (state == StateRunning || state == StatePending) {
+		backoff["currentDelay"] = podBackoffDelay(job.Status.Failed).String()
+	}
+
+	return backoff
+}
+
+func podBackoffDelay(failures int32) time.Duration {
+	delay := initialPodBackoff
+	for i := int32(1); i < failures; i++ {
+		delay *= 2
+		if delay >= maxPodBackoff {
+			return maxPodBackoff
+		}
+	}
+	return delay
+}
+
+func getJobPods(pods []corev1.Pod) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(pods))
+	for _, pod := range pods {
+		var restarts int32
+		var containers []string
+		for _, cs := range pod.Status.ContainerStatuses {
+			restarts += cs.RestartCount
+		}
+		for _, container := range pod.Spec.Containers {
+			containers = append(containers, container.Name)
+		}
+
+		result = append(result, map[string]interface{}{
+			"name":       pod.Name,
+			"phase":      pod.Status.Phase,
+			"nodeName":   pod.Spec.NodeName,
+			"restarts":   restarts,
+			"startTime":  pod.Status.StartTime,
+			"containers": containers,
+			"links":      podLinks(pod.Namespace, pod.Name),
+		})
+	}
+	return result
+}
+
+func podLinks(namespace, name string) map[string]string {
+	podPath := fmt.Sprintf("/api/v1/pods/namespaces/%s/%s", namespace, name)
+	return map[string]string{
+		"pod":  podPath,
+		"logs": podPath + "/logs",
+	}
+}
+
+func getJobConditions(conditions []batchv1.JobCondition) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, condition := range conditions {
+		result = append(result, map[string]interface{}{
+			"type":               condition.Type,
+			"status":             condition.Status,
+			"lastTransitionTime": condition.LastTransitionTime,
+			"reason":             condition.Reason,
+			"message":            condition.Message,
+		})
+	}
+	return result
+}
@@ -0,0 +1,73 @@
+package job
+
+import (
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetJobState(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     batchv1.JobStatus
+		wantState  string
+		wantReason string
+	}{
+		{"pending", batchv1.JobStatus{}, StatePending, ""},
+		{"running", batchv1.JobStatus{Active: 1}, StateRunning, ""},
+		{"complete", batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+			{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+		}}, StateComplete, ""},
+		{"failed", batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+			{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded"},
+		}}, StateFailed, "BackoffLimitExceeded"},
+		{"suspended", batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+			{Type: batchv1.JobSuspended, Status: corev1.ConditionTrue},
+		}}, StateSuspended, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, reason := GetJobState(&batchv1.Job{Status: tt.status})
+			if state != tt.wantState || reason != tt.wantReason {
+				t.Errorf("GetJobState = (%q, %q), want (%q, %q)", state, reason, tt.wantState, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestGetBackoffStatus(t *testing.T) {
+	limit := int32(3)
+	job := &batchv1.Job{
+		Spec:   batchv1.JobSpec{BackoffLimit: &limit},
+		Status: batchv1.JobStatus{Active: 1, Failed: 2},
+	}
+
+	backoff := getBackoffStatus(job)
+	if backoff["retriesRemaining"] != int32(1) {
+		t.Errorf("retriesRemaining = %v, want 1", backoff["retriesRemaining"])
+	}
+	if backoff["currentDelay"] != "20s" {
+		t.Errorf("currentDelay = %v, want 20s", backoff["currentDelay"])
+	}
+
+	if got := podBackoffDelay(10); got != maxPodBackoff {
+		t.Errorf("podBackoffDelay(10) = %v, want %v", got, maxPodBackoff)
+	}
+}
+
+func TestGetJobSummaryDuration(t *testing.T) {
+	start := metav1.NewTime(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+	end := metav1.NewTime(start.Add(90 * time.Second))
+	job := &batchv1.Job{
+		Status: batchv1.JobStatus{Succeeded: 1, StartTime: &start, CompletionTime: &end},
+	}
+
+	summary := GetJobSummary(job, time.Now())
+	if summary["duration"] != "1m30s" || summary["completions"] != "1/1" {
+		t.Errorf("summary = %v", summary)
+	}
+}
@@ -1,24 +1,29 @@
 package namespace
 
 import (
+	"errors"
 	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+
+	"k8s-glance-backend/internal/api/base"
 )
 
 type Handler struct {
 	api *NamespaceAPI
 }
 
-func NewHandler(clientset *kubernetes.Clientset, logger *log.Logger) *Handler {
+func NewHandler(clientset *kubernetes.Clientset, logger *log.Logger, protected []string) *Handler {
 	if logger == nil {
 		logger = log.New(gin.DefaultWriter, "[NAMESPACE-API] ", log.LstdFlags)
 	}
 
 	return &Handler{
-		api: NewNamespaceAPI(clientset, logger),
+		api: NewNamespaceAPI(clientset, logger, protected),
 	}
 }
 
@@ -50,9 +55,9 @@ func (h *Handler) ListNamespaces(c *gin.Context) {
 	})
 }
 
-// GetNamespace handles GET /api/v1/namespaces/:name
+// GetNamespace handles GET /api/v1/namespaces/:namespace
 func (h *Handler) GetNamespace(c *gin.Context) {
-	name := c.Param("name")
+	name := c.Param("namespace")
 	namespace, err := h.api.GetNamespace(c.Request.Context(), name)
 
 	if err != nil {
@@ -72,13 +77,14 @@ func (h *Handler) GetNamespace(c *gin.Context) {
 			"resourceVersion": namespace.ResourceVersion,
 			"labels":          namespace.Labels,
 			"annotations":     namespace.Annotations,
+			"protected":       h.api.IsProtected(namespace.Name),
 		},
 	})
 }
 
-// GetNamespaceMetrics handles GET /api/v1/namespaces/:name/metrics
+// GetNamespaceMetrics handles GET /api/v1/namespaces/:namespace/metrics
 func (h *Handler) GetNamespaceMetrics(c *gin.Context) {
-	name := c.Param("name")
+	name := c.Param("namespace")
 	metrics, err := h.api.GetNamespaceMetrics(c.Request.Context(), name)
 
 	if err != nil {
@@ -91,3 +97,607 @@ func (h *Handler) GetNamespaceMetrics(c *gin.Context) {
 
 	c.JSON(http.StatusOK, metrics)
 }
+
+// GetNamespaceOverview handles GET /api/v1/namespaces/:namespace/overview
+func (h *Handler) GetNamespaceOverview(c *gin.Context) {
+	name := c.Param("namespace")
+	overview, err := h.api.GetNamespaceOverview(c.Request.Context(), name)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, overview)
+}
+
+// GetNamespaceTopology handles GET /api/v1/namespaces/:namespace/topology
+// The graph is returned as JSON, or as Graphviz DOT with ?format=dot
+func (h *Handler) GetNamespaceTopology(c *gin.Context) {
+	name := c.Param("namespace")
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "dot" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "format must be json or dot",
+		})
+		return
+	}
+
+	topology, err := h.api.GetNamespaceTopology(c.Request.Context(), name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if format == "dot" {
+		c.Data(http.StatusOK, "text/vnd.graphviz; charset=utf-8", []byte(topology.DOT()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    topology,
+	})
+}
+
+// CreateNamespace handles POST /api/v1/namespaces
+func (h *Handler) CreateNamespace(c *gin.Context) {
+	var namespaceRequest struct {
+		Name        string            `json:"name" binding:"required"`
+		Labels      map[string]string `json:"labels"`
+		Annotations map[string]string `json:"annotations"`
+	}
+
+	if err := c.ShouldBindJSON(&namespaceRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        namespaceRequest.Name,
+			Labels:      namespaceRequest.Labels,
+			Annotations: namespaceRequest.Annotations,
+		},
+	}
+
+	result, err := h.api.CreateNamespace(c.Request.Context(), namespace)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"name":   result.Name,
+			"status": result.Status.Phase,
+		},
+	})
+}
+
+// UpdateNamespace handles PUT /api/v1/namespaces/:namespace
+func (h *Handler) UpdateNamespace(c *gin.Context) {
+	var updateRequest struct {
+		Labels      map[string]string `json:"labels"`
+		Annotations map[string]string `json:"annotations"`
+	}
+
+	if err := c.ShouldBindJSON(&updateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	name := c.Param("namespace")
+
+	// Get existing namespace
+	existing, err := h.api.GetNamespace(c.Request.Context(), name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Update fields if provided
+	if updateRequest.Labels != nil {
+		existing.Labels = updateRequest.Labels
+	}
+	if updateRequest.Annotations != nil {
+		existing.Annotations = updateRequest.Annotations
+	}
+
+	result, err := h.api.UpdateNamespace(c.Request.Context(), existing)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"name":        result.Name,
+			"labels":      result.Labels,
+			"annotations": result.Annotations,
+			"status":      "updated",
+		},
+	})
+}
+
+// DeleteNamespace handles DELETE /api/v1/namespaces/:namespace?confirm=<name>
+func (h *Handler) DeleteNamespace(c *gin.Context) {
+	name := c.Param("namespace")
+
+	err := h.api.DeleteNamespace(c.Request.Context(), name, c.Query("confirm"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrConfirmationMismatch):
+			status = http.StatusBadRequest
+		case errors.Is(err, ErrProtectedNamespace):
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"message": "Namespace deletion started",
+	})
+}
+
+// GetNamespaceDeletePreview handles GET /api/v1/namespaces/:namespace/delete-preview
+func (h *Handler) GetNamespaceDeletePreview(c *gin.Context) {
+	name := c.Param("namespace")
+
+	preview, err := h.api.GetNamespaceDeletePreview(c.Request.Context(), name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// WatchNamespaceTermination handles GET /api/v1/namespaces/:namespace/termination
+// and streams the termination progress as Server-Sent Events
+func (h *Handler) WatchNamespaceTermination(c *gin.Context) {
+	name := c.Param("namespace")
+
+	base.PrepareStream(c)
+	err := h.api.WatchNamespaceTermination(c.Request.Context(), name, func(progress map[string]interface{}) {
+		base.SendEvent(c, "progress", progress)
+	})
+	if err != nil && c.Request.Context().Err() == nil {
+		base.SendEvent(c, "error", gin.H{"error": err.Error()})
+	}
+}
+
+// ListResourceQuotas handles GET /api/v1/namespaces/:namespace/resourcequotas
+func (h *Handler) ListResourceQuotas(c *gin.Context) {
+	namespace := c.Param("namespace")
+	quotas, err := h.api.ListResourceQuotas(c.Request.Context(), namespace)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	var response []map[string]interface{}
+	for i := range quotas.Items {
+		response = append(response, getResourceQuotaResponse(&quotas.Items[i]))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    response,
+	})
+}
+
+// GetResourceQuota handles GET /api/v1/namespaces/:namespace/resourcequotas/:name
+func (h *Handler) GetResourceQuota(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	quota, err := h.api.GetResourceQuota(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    getResourceQuotaResponse(quota),
+	})
+}
+
+// CreateResourceQuota handles POST /api/v1/namespaces/:namespace/resourcequotas
+func (h *Handler) CreateResourceQuota(c *gin.Context) {
+	var quotaRequest struct {
+		Name   string            `json:"name" binding:"required"`
+		Hard   map[string]string `json:"hard" binding:"required"`
+		Scopes []string          `json:"scopes"`
+		Labels map[string]string `json:"labels"`
+	}
+
+	if err := c.ShouldBindJSON(&quotaRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	hard, err := ParseResourceList("hard", quotaRequest.Hard)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	namespace := c.Param("namespace")
+
+	var scopes []corev1.ResourceQuotaScope
+	for _, scope := range quotaRequest.Scopes {
+		scopes = append(scopes, corev1.ResourceQuotaScope(scope))
+	}
+
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      quotaRequest.Name,
+			Namespace: namespace,
+			Labels:    quotaRequest.Labels,
+		},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard:   hard,
+			Scopes: scopes,
+		},
+	}
+
+	result, err := h.api.CreateResourceQuota(c.Request.Context(), namespace, quota)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    getResourceQuotaResponse(result),
+	})
+}
+
+// UpdateResourceQuota handles PUT /api/v1/namespaces/:namespace/resourcequotas/:name
+func (h *Handler) UpdateResourceQuota(c *gin.Context) {
+	var updateRequest struct {
+		Hard   map[string]string `json:"hard"`
+		Scopes []string          `json:"scopes"`
+		Labels map[string]string `json:"labels"`
+	}
+
+	if err := c.ShouldBindJSON(&updateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	hard, err := ParseResourceList("hard", updateRequest.Hard)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	// Get existing quota
+	existing, err := h.api.GetResourceQuota(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Update fields if provided
+	if hard != nil {
+		existing.Spec.Hard = hard
+	}
+	if updateRequest.Scopes != nil {
+		var scopes []corev1.ResourceQuotaScope
+		for _, scope := range updateRequest.Scopes {
+			scopes = append(scopes, corev1.ResourceQuotaScope(scope))
+		}
+		existing.Spec.Scopes = scopes
+	}
+	if updateRequest.Labels != nil {
+		existing.Labels = updateRequest.Labels
+	}
+
+	result, err := h.api.UpdateResourceQuota(c.Request.Context(), namespace, existing)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    getResourceQuotaResponse(result),
+	})
+}
+
+// DeleteResourceQuota handles DELETE /api/v1/namespaces/:namespace/resourcequotas/:name
+func (h *Handler) DeleteResourceQuota(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	err := h.api.DeleteResourceQuota(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "ResourceQuota deleted successfully",
+	})
+}
+
+// ListLimitRanges handles GET /api/v1/namespaces/:namespace/limitranges
+func (h *Handler) ListLimitRanges(c *gin.Context) {
+	namespace := c.Param("namespace")
+	limitRanges, err := h.api.ListLimitRanges(c.Request.Context(), namespace)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	var response []map[string]interface{}
+	for _, lr := range limitRanges.Items {
+		response = append(response, map[string]interface{}{
+			"name":         lr.Name,
+			"namespace":    lr.Namespace,
+			"limits":       getLimitRangeItems(lr.Spec.Limits),
+			"creationTime": lr.CreationTimestamp,
+			"labels":       lr.Labels,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    response,
+	})
+}
+
+// GetLimitRange handles GET /api/v1/namespaces/:namespace/limitranges/:name
+func (h *Handler) GetLimitRange(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	limitRange, err := h.api.GetLimitRange(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"name":         limitRange.Name,
+			"namespace":    limitRange.Namespace,
+			"limits":       getLimitRangeItems(limitRange.Spec.Limits),
+			"creationTime": limitRange.CreationTimestamp,
+			"labels":       limitRange.Labels,
+			"annotations":  limitRange.Annotations,
+		},
+	})
+}
+
+// CreateLimitRange handles POST /api/v1/namespaces/:namespace/limitranges
+func (h *Handler) CreateLimitRange(c *gin.Context) {
+	var limitRangeRequest struct {
+		Name   string                  `json:"name" binding:"required"`
+		Limits []LimitRangeItemRequest `json:"limits" binding:"required"`
+		Labels map[string]string       `json:"labels"`
+	}
+
+	if err := c.ShouldBindJSON(&limitRangeRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	limits, err := ParseLimitRangeItems(limitRangeRequest.Limits)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	namespace := c.Param("namespace")
+
+	limitRange := &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      limitRangeRequest.Name,
+			Namespace: namespace,
+			Labels:    limitRangeRequest.Labels,
+		},
+		Spec: corev1.LimitRangeSpec{
+			Limits: limits,
+		},
+	}
+
+	result, err := h.api.CreateLimitRange(c.Request.Context(), namespace, limitRange)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"name":      result.Name,
+			"namespace": result.Namespace,
+			"limits":    getLimitRangeItems(result.Spec.Limits),
+		},
+	})
+}
+
+// UpdateLimitRange handles PUT /api/v1/namespaces/:namespace/limitranges/:name
+func (h *Handler) UpdateLimitRange(c *gin.Context) {
+	var updateRequest struct {
+		Limits []LimitRangeItemRequest `json:"limits"`
+		Labels map[string]string       `json:"labels"`
+	}
+
+	if err := c.ShouldBindJSON(&updateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	limits, err := ParseLimitRangeItems(updateRequest.Limits)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	// Get existing LimitRange
+	existing, err := h.api.GetLimitRange(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Update fields if provided
+	if len(limits) > 0 {
+		existing.Spec.Limits = limits
+	}
+	if updateRequest.Labels != nil {
+		existing.Labels = updateRequest.Labels
+	}
+
+	result, err := h.api.UpdateLimitRange(c.Request.Context(), namespace, existing)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"name":      result.Name,
+			"namespace": result.Namespace,
+			"limits":    getLimitRangeItems(result.Spec.Limits),
+			"status":    "updated",
+		},
+	})
+}
+
+// DeleteLimitRange handles DELETE /api/v1/namespaces/:namespace/limitranges/:name
+func (h *Handler) DeleteLimitRange(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	err := h.api.DeleteLimitRange(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "LimitRange deleted successfully",
+	})
+}
+
+// Helper functions
+
+func getResourceQuotaResponse(quota *corev1.ResourceQuota) map[string]interface{} {
+	return map[string]interface{}{
+		"name":         quota.Name,
+		"namespace":    quota.Namespace,
+		"scopes":       quota.Spec.Scopes,
+		"resources":    getQuotaUsage(quota),
+		"creationTime": quota.CreationTimestamp,
+		"labels":       quota.Labels,
+	}
+}
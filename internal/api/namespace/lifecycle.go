@@ -0,0 +1,233 @@
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"k8s-glance-backend/internal/api/base"
+)
+
+// resourceLister lists one kind of namespaced resource
+type resourceLister struct {
+	kind string
+	list func(ctx context.Context, namespace string) (runtime.Object, error)
+}
+
+// GetNamespaceDeletePreview returns everything that would be removed together with a namespace
+func (api *NamespaceAPI) GetNamespaceDeletePreview(ctx context.Context, name string) (*base.APIResponse, error) {
+	api.LogInfo(ctx, "GetNamespaceDeletePreview", fmt.Sprintf("Building delete preview for namespace: %s", name))
+
+	namespace, err := api.GetNamespace(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make(map[string][]string)
+	counts := make(map[string]int)
+	total := 0
+
+	for _, lister := range api.resourceListers() {
+		var names []string
+		list, err := lister.list(ctx, name)
+		if err == nil {
+			names, err = objectNames(list)
+		}
+		if err != nil {
+			api.LogError(ctx, "GetNamespaceDeletePreview", err)
+			return nil, api.HandleError(err, fmt.Sprintf("list %s for delete preview", lister.kind))
+		}
+		if len(names) == 0 {
+			continue
+		}
+		sort.Strings(names)
+		resources[lister.kind] = names
+		counts[lister.kind] = len(names)
+		total += len(names)
+	}
+
+	response := base.NewSuccessResponse(map[string]interface{}{
+		"namespace":  name,
+		"phase":      namespace.Status.Phase,
+		"protected":  api.IsProtected(name),
+		"finalizers": namespace.Spec.Finalizers,
+		"resources":  resources,
+		"counts":     counts,
+		"total":      total,
+	})
+	return &response, nil
+}
+
+// WatchNamespaceTermination reports the termination progress of a namespace until it
+// is gone or the context is cancelled
+func (api *NamespaceAPI) WatchNamespaceTermination(ctx context.Context, name string, report func(progress map[string]interface{})) error {
+	api.LogInfo(ctx, "WatchNamespaceTermination", fmt.Sprintf("Watching termination of namespace: %s", name))
+
+	for {
+		namespace, err := api.GetClientset().CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			report(map[string]interface{}{"name": name, "deleted": true})
+			return nil
+		}
+		if err != nil {
+			api.LogError(ctx, "WatchNamespaceTermination", err)
+			return api.HandleError(err, "get namespace")
+		}
+		report(getTerminationProgress(namespace))
+
+		watcher, err := api.GetClientset().CoreV1().Namespaces().Watch(ctx, metav1.ListOptions{
+			FieldSelector:   fields.OneTermEqualSelector("metadata.name", name).String(),
+			ResourceVersion: namespace.ResourceVersion,
+		})
+		if err != nil {
+			api.LogError(ctx, "WatchNamespaceTermination", err)
+			return api.HandleError(err, "watch namespace")
+		}
+
+		deleted := false
+		for event := range watcher.ResultChan() {
+			if event.Type == watch.Deleted {
+				deleted = true
+				break
+			}
+			if ns, ok := event.Object.(*corev1.Namespace); ok {
+				report(getTerminationProgress(ns))
+			}
+		}
+		watcher.Stop()
+
+		if deleted {
+			report(map[string]interface{}{"name": name, "deleted": true})
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// The watch expired or failed, start over from the current state
+	}
+}
+
+// Helper functions
+
+func (api *NamespaceAPI) resourceListers() []resourceLister {
+	clientset := api.GetClientset()
+	opts := metav1.ListOptions{}
+	return []resourceLister{
+		{"pods", func(ctx context.Context, ns string) (runtime.Object, error) {
+			return clientset.CoreV1().Pods(ns).List(ctx, opts)
+		}},
+		{"deployments", func(ctx context.Context, ns string) (runtime.Object, error) {
+			return clientset.AppsV1().Deployments(ns).List(ctx, opts)
+		}},
+		{"statefulsets", func(ctx context.Context, ns string) (runtime.Object, error) {
+			return clientset.AppsV1().StatefulSets(ns).List(ctx, opts)
+		}},
+		{"daemonsets", func(ctx context.Context, ns string) (runtime.Object, error) {
+			return clientset.AppsV1().DaemonSets(ns).List(ctx, opts)
+		}},
+		{"jobs", func(ctx context.Context, ns string) (runtime.Object, error) {
+			return clientset.BatchV1().Jobs(ns).List(ctx, opts)
+		}},
+		{"cronjobs", func(ctx context.Context, ns string) (runtime.Object, error) {
+			return clientset.BatchV1().CronJobs(ns).List(ctx, opts)
+		}},
+		{"services", func(ctx context.Context, ns string) (runtime.Object, error) {
+			return clientset.CoreV1().Services(ns).List(ctx, opts)
+		}},
+		{"ingresses", func(ctx context.Context, ns string) (runtime.Object, error) {
+			return clientset.NetworkingV1().Ingresses(ns).List(ctx, opts)
+		}},
+		{"configmaps", func(ctx context.Context, ns string) (runtime.Object, error) {
+			return clientset.CoreV1().ConfigMaps(ns).List(ctx, opts)
+		}},
+		{"secrets", func(ctx context.Context, ns string) (runtime.Object, error) {
+			return clientset.CoreV1().Secrets(ns).List(ctx, opts)
+		}},
+		{"persistentvolumeclaims", func(ctx context.Context, ns string) (runtime.Object, error) {
+			return clientset.CoreV1().PersistentVolumeClaims(ns).List(ctx, opts)
+		}},
+		{"serviceaccounts", func(ctx context.Context, ns string) (runtime.Object, error) {
+			return clientset.CoreV1().ServiceAccounts(ns).List(ctx, opts)
+		}},
+		{"roles", func(ctx context.Context, ns string) (runtime.Object, error) {
+			return clientset.RbacV1().Roles(ns).List(ctx, opts)
+		}},
+		{"rolebindings", func(ctx context.Context, ns string) (runtime.Object, error) {
+			return clientset.RbacV1().RoleBindings(ns).List(ctx, opts)
+		}},
+	}
+}
+
+// objectNames returns the names of the items of a list
+func objectNames(list runtime.Object) ([]string, error) {
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		accessor, err := meta.Accessor(item)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, accessor.GetName())
+	}
+	return names, nil
+}
+
+// getTerminationProgress summarises the deletion state of a namespace. A namespace is
+// considered stuck when finalizers remain or content could not be deleted.
+func getTerminationProgress(namespace *corev1.Namespace) map[string]interface{} {
+	var conditions []map[string]interface{}
+	var blockers []string
+	stuck := false
+
+	for _, condition := range namespace.Status.Conditions {
+		conditions = append(conditions, map[string]interface{}{
+			"type":               condition.Type,
+			"status":             condition.Status,
+			"reason":             condition.Reason,
+			"message":            condition.Message,
+			"lastTransitionTime": condition.LastTransitionTime,
+		})
+
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case corev1.NamespaceFinalizersRemaining,
+			corev1.NamespaceDeletionContentFailure,
+			corev1.NamespaceDeletionDiscoveryFailure,
+			corev1.NamespaceDeletionGVParsingFailure:
+			stuck = true
+			blockers = append(blockers, condition.Message)
+		case corev1.NamespaceContentRemaining:
+			blockers = append(blockers, condition.Message)
+		}
+	}
+
+	var finalizers []string
+	for _, finalizer := range namespace.Spec.Finalizers {
+		finalizers = append(finalizers, string(finalizer))
+	}
+
+	return map[string]interface{}{
+		"name":               namespace.Name,
+		"phase":              namespace.Status.Phase,
+		"deletionTimestamp":  namespace.DeletionTimestamp,
+		"finalizers":         finalizers,
+		"metadataFinalizers": namespace.Finalizers,
+		"conditions":         conditions,
+		"blockers":           blockers,
+		"stuck":              stuck,
+		"deleted":            false,
+	}
+}
@@ -0,0 +1,75 @@
+package namespace
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetNamespaceDeletePreview(t *testing.T) {
+	api := newTestNamespaceAPI(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		newTestPod("web-2", corev1.PodRunning),
+		newTestPod("web-1", corev1.PodRunning),
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}},
+		&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "reader", Namespace: "team-a"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "team-b"}},
+	)
+
+	response, err := api.GetNamespaceDeletePreview(context.Background(), "team-a")
+	if err != nil {
+		t.Fatalf("GetNamespaceDeletePreview: %v", err)
+	}
+	data := response.Data.(map[string]interface{})
+
+	want := map[string][]string{
+		"pods":        {"web-1", "web-2"},
+		"deployments": {"web"},
+		"roles":       {"reader"},
+	}
+	if resources := data["resources"].(map[string][]string); !reflect.DeepEqual(resources, want) {
+		t.Errorf("resources = %v, want %v", resources, want)
+	}
+	if data["total"] != 4 {
+		t.Errorf("total = %v, want 4", data["total"])
+	}
+	if data["protected"] != false {
+		t.Errorf("protected = %v, want false", data["protected"])
+	}
+}
+
+func TestGetTerminationProgress(t *testing.T) {
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+		Spec:       corev1.NamespaceSpec{Finalizers: []corev1.FinalizerName{corev1.FinalizerKubernetes}},
+		Status: corev1.NamespaceStatus{
+			Phase: corev1.NamespaceTerminating,
+			Conditions: []corev1.NamespaceCondition{
+				{Type: corev1.NamespaceContentRemaining, Status: corev1.ConditionTrue, Message: "Some resources are remaining: pods. has 1 resource instances"},
+				{Type: corev1.NamespaceFinalizersRemaining, Status: corev1.ConditionTrue, Message: "Some content in the namespace has finalizers remaining"},
+				{Type: corev1.NamespaceDeletionContentFailure, Status: corev1.ConditionFalse},
+			},
+		},
+	}
+
+	progress := getTerminationProgress(namespace)
+	if progress["stuck"] != true {
+		t.Errorf("stuck = %v, want true", progress["stuck"])
+	}
+	if blockers := progress["blockers"].([]string); len(blockers) != 2 {
+		t.Errorf("blockers = %v, want 2", blockers)
+	}
+	if finalizers := progress["finalizers"].([]string); !reflect.DeepEqual(finalizers, []string{"kubernetes"}) {
+		t.Errorf("finalizers = %v", finalizers)
+	}
+
+	namespace.Status.Conditions = namespace.Status.Conditions[:1]
+	if progress := getTerminationProgress(namespace); progress["stuck"] != false {
+		t.Errorf("content remaining only: stuck = %v, want false", progress["stuck"])
+	}
+}
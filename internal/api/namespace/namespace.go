@@ -2,8 +2,10 @@ package namespace
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -12,15 +14,28 @@ import (
 	"k8s-glance-backend/internal/api/base"
 )
 
+// Errors returned by DeleteNamespace before anything is sent to the API server
+var (
+	ErrConfirmationMismatch = errors.New("confirmation does not match the namespace name")
+	ErrProtectedNamespace   = errors.New("namespace is protected and cannot be deleted")
+)
+
 // NamespaceAPI handles namespace-related operations
 type NamespaceAPI struct {
 	*base.BaseAPI
+	protected map[string]bool
 }
 
-// NewNamespaceAPI creates a new NamespaceAPI instance
-func NewNamespaceAPI(clientset *kubernetes.Clientset, logger *log.Logger) *NamespaceAPI {
+// NewNamespaceAPI creates a new NamespaceAPI instance. Protected namespaces cannot be deleted.
+func NewNamespaceAPI(clientset kubernetes.Interface, logger *log.Logger, protected []string) *NamespaceAPI {
+	protectedSet := make(map[string]bool, len(protected))
+	for _, name := range protected {
+		protectedSet[name] = true
+	}
+
 	return &NamespaceAPI{
-		BaseAPI: base.NewBaseAPI(clientset, logger),
+		BaseAPI:   base.NewBaseAPI(clientset, logger),
+		protected: protectedSet,
 	}
 }
 
@@ -50,6 +65,57 @@ func (api *NamespaceAPI) GetNamespace(ctx context.Context, name string) (*corev1
 	return namespace, nil
 }
 
+// CreateNamespace creates a new namespace
+func (api *NamespaceAPI) CreateNamespace(ctx context.Context, namespace *corev1.Namespace) (*corev1.Namespace, error) {
+	api.LogInfo(ctx, "CreateNamespace", fmt.Sprintf("Creating namespace: %s", namespace.Name))
+
+	result, err := api.GetClientset().CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{})
+	if err != nil {
+		api.LogError(ctx, "CreateNamespace", err)
+		return nil, api.HandleError(err, "create namespace")
+	}
+
+	return result, nil
+}
+
+// UpdateNamespace updates an existing namespace
+func (api *NamespaceAPI) UpdateNamespace(ctx context.Context, namespace *corev1.Namespace) (*corev1.Namespace, error) {
+	api.LogInfo(ctx, "UpdateNamespace", fmt.Sprintf("Updating namespace: %s", namespace.Name))
+
+	result, err := api.GetClientset().CoreV1().Namespaces().Update(ctx, namespace, metav1.UpdateOptions{})
+	if err != nil {
+		api.LogError(ctx, "UpdateNamespace", err)
+		return nil, api.HandleError(err, "update namespace")
+	}
+
+	return result, nil
+}
+
+// IsProtected reports whether a namespace is protected from deletion
+func (api *NamespaceAPI) IsProtected(name string) bool {
+	return api.protected[name]
+}
+
+// DeleteNamespace deletes a namespace after checking the confirmation and protection list
+func (api *NamespaceAPI) DeleteNamespace(ctx context.Context, name, confirmation string) error {
+	api.LogInfo(ctx, "DeleteNamespace", fmt.Sprintf("Deleting namespace: %s", name))
+
+	if confirmation != name {
+		return ErrConfirmationMismatch
+	}
+	if api.IsProtected(name) {
+		return ErrProtectedNamespace
+	}
+
+	err := api.GetClientset().CoreV1().Namespaces().Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		api.LogError(ctx, "DeleteNamespace", err)
+		return api.HandleError(err, "delete namespace")
+	}
+
+	return nil
+}
+
 // GetNamespaceMetrics returns resource usage for a namespace
 func (api *NamespaceAPI) GetNamespaceMetrics(ctx context.Context, name string) (*base.APIResponse, error) {
 	api.LogInfo(ctx, "GetNamespaceMetrics", fmt.Sprintf("Fetching metrics for namespace: %s", name))
@@ -62,21 +128,45 @@ func (api *NamespaceAPI) GetNamespaceMetrics(ctx context.Context, name string) (
 	}
 
 	// Calculate resource usage
+	phases := newPhaseCounts()
 	metrics := map[string]interface{}{
 		"podCount": len(pods.Items),
-		"status": map[string]int{
-			"running":   0,
-			"pending":   0,
-			"failed":    0,
-			"succeeded": 0,
-		},
+		"status":   phases,
 	}
 
 	// Count pods by status
 	for _, pod := range pods.Items {
-		metrics["status"].(map[string]int)[string(pod.Status.Phase)]++
+		phases[phaseKey(pod.Status.Phase)]++
+	}
+
+	// Add quota pressure
+	quotas, err := api.ListResourceQuotas(ctx, name)
+	if err != nil {
+		return nil, err
 	}
+	metrics["quotaPressure"] = getQuotaPressure(quotas.Items)
 
 	response := base.NewSuccessResponse(metrics)
 	return &response, nil
 }
+
+// Helper functions
+
+// newPhaseCounts returns pod counters keyed by lowercase phase name
+func newPhaseCounts() map[string]int {
+	return map[string]int{
+		"running":   0,
+		"pending":   0,
+		"failed":    0,
+		"succeeded": 0,
+		"unknown":   0,
+	}
+}
+
+// phaseKey maps a pod phase to its counter key, e.g. Running to running
+func phaseKey(phase corev1.PodPhase) string {
+	if phase == "" {
+		return "unknown"
+	}
+	return strings.ToLower(string(phase))
+}
@@ -0,0 +1,104 @@
+package namespace
+
+import (
+	"context"
+	"io"
+	"log"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestPod(name string, phase corev1.PodPhase, statuses ...corev1.ContainerStatus) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+		Status:     corev1.PodStatus{Phase: phase, ContainerStatuses: statuses},
+	}
+}
+
+func newTestNamespaceAPI(objects ...runtime.Object) *NamespaceAPI {
+	return NewNamespaceAPI(fake.NewSimpleClientset(objects...), log.New(io.Discard, "", 0), []string{"kube-system"})
+}
+
+func TestGetNamespaceMetricsCountsPhases(t *testing.T) {
+	api := newTestNamespaceAPI(
+		newTestPod("a", corev1.PodRunning),
+		newTestPod("b", corev1.PodRunning),
+		newTestPod("c", corev1.PodPending),
+		newTestPod("d", corev1.PodFailed),
+	)
+
+	response, err := api.GetNamespaceMetrics(context.Background(), "team-a")
+	if err != nil {
+		t.Fatalf("GetNamespaceMetrics: %v", err)
+	}
+
+	status := response.Data.(map[string]interface{})["status"]
+	want := map[string]int{"running": 2, "pending": 1, "failed": 1, "succeeded": 0, "unknown": 0}
+	if !reflect.DeepEqual(status, want) {
+		t.Errorf("status = %v, want %v", status, want)
+	}
+}
+
+func TestGetNamespaceOverviewPodProblems(t *testing.T) {
+	api := newTestNamespaceAPI(
+		newTestPod("crashing", corev1.PodRunning, corev1.ContainerStatus{
+			Name:         "app",
+			RestartCount: 7,
+			State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			LastTerminationState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled"},
+			},
+		}),
+		newTestPod("pulling", corev1.PodPending, corev1.ContainerStatus{
+			Name:  "app",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+		}),
+	)
+
+	response, err := api.GetNamespaceOverview(context.Background(), "team-a")
+	if err != nil {
+		t.Fatalf("GetNamespaceOverview: %v", err)
+	}
+
+	pods := response.Data.(map[string]interface{})["pods"].(map[string]interface{})
+	if pods["totalRestarts"] != int32(7) {
+		t.Errorf("totalRestarts = %v, want 7", pods["totalRestarts"])
+	}
+	problems := pods["problems"].(map[string][]string)
+	for reason, want := range map[string][]string{
+		"CrashLoopBackOff": {"crashing"},
+		"OOMKilled":        {"crashing"},
+		"ImagePullBackOff": {"pulling"},
+	} {
+		if !reflect.DeepEqual(problems[reason], want) {
+			t.Errorf("problems[%s] = %v, want %v", reason, problems[reason], want)
+		}
+	}
+}
+
+func TestDeleteNamespaceSafeguards(t *testing.T) {
+	api := newTestNamespaceAPI(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+	)
+
+	if err := api.DeleteNamespace(context.Background(), "team-a", "team-b"); err != ErrConfirmationMismatch {
+		t.Errorf("wrong confirmation: err = %v, want %v", err, ErrConfirmationMismatch)
+	}
+	if err := api.DeleteNamespace(context.Background(), "kube-system", "kube-system"); err != ErrProtectedNamespace {
+		t.Errorf("protected namespace: err = %v, want %v", err, ErrProtectedNamespace)
+	}
+	for _, name := range []string{"kube-system", "team-a"} {
+		if _, err := api.GetNamespace(context.Background(), name); err != nil {
+			t.Errorf("namespace %s was deleted by a rejected request: %v", name, err)
+		}
+	}
+	if err := api.DeleteNamespace(context.Background(), "team-a", "team-a"); err != nil {
+		t.Errorf("DeleteNamespace: %v", err)
+	}
+}
@@ -0,0 +1,295 @@
+package namespace
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s-glance-backend/internal/api/base"
+)
+
+// Workload health states used in the namespace overview
+const (
+	healthHealthy     = "healthy"
+	healthDegraded    = "degraded"
+	healthUnavailable = "unavailable"
+	healthScaledDown  = "scaledDown"
+)
+
+// problemReasons are the container reasons counted as pod problems in the overview
+var problemReasons = []string{"CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull", "OOMKilled"}
+
+// GetNamespaceOverview returns an aggregated health summary of a namespace
+func (api *NamespaceAPI) GetNamespaceOverview(ctx context.Context, name string) (*base.APIResponse, error) {
+	api.LogInfo(ctx, "GetNamespaceOverview", fmt.Sprintf("Building overview for namespace: %s", name))
+
+	clientset := api.GetClientset()
+
+	pods, err := clientset.CoreV1().Pods(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetNamespaceOverview", err)
+		return nil, api.HandleError(err, "list pods for overview")
+	}
+	deployments, err := clientset.AppsV1().Deployments(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetNamespaceOverview", err)
+		return nil, api.HandleError(err, "list deployments for overview")
+	}
+	statefulSets, err := clientset.AppsV1().StatefulSets(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetNamespaceOverview", err)
+		return nil, api.HandleError(err, "list statefulsets for overview")
+	}
+	daemonSets, err := clientset.AppsV1().DaemonSets(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetNamespaceOverview", err)
+		return nil, api.HandleError(err, "list daemonsets for overview")
+	}
+	jobs, err := clientset.BatchV1().Jobs(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetNamespaceOverview", err)
+		return nil, api.HandleError(err, "list jobs for overview")
+	}
+	events, err := clientset.CoreV1().Events(name).List(ctx, metav1.ListOptions{
+		FieldSelector: "type=" + corev1.EventTypeWarning,
+	})
+	if err != nil {
+		api.LogError(ctx, "GetNamespaceOverview", err)
+		return nil, api.HandleError(err, "list events for overview")
+	}
+	services, err := clientset.CoreV1().Services(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetNamespaceOverview", err)
+		return nil, api.HandleError(err, "list services for overview")
+	}
+	endpoints, err := clientset.CoreV1().Endpoints(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetNamespaceOverview", err)
+		return nil, api.HandleError(err, "list endpoints for overview")
+	}
+
+	overview := map[string]interface{}{
+		"namespace": name,
+		"workloads": map[string]interface{}{
+			"deployments":  getDeploymentHealth(deployments.Items),
+			"statefulSets": getStatefulSetHealth(statefulSets.Items),
+			"daemonSets":   getDaemonSetHealth(daemonSets.Items),
+			"jobs":         getJobHealth(jobs.Items),
+		},
+		"pods":              getPodSummary(pods.Items),
+		"resources":         getResourceTotals(pods.Items),
+		"warningEvents":     getWarningEventSummary(events.Items),
+		"unhealthyServices": getServicesWithoutEndpoints(services.Items, endpoints.Items),
+	}
+
+	response := base.NewSuccessResponse(overview)
+	return &response, nil
+}
+
+// Helper functions
+
+func newHealthCounts() map[string]interface{} {
+	return map[string]interface{}{
+		"total":           0,
+		healthHealthy:     0,
+		healthDegraded:    0,
+		healthUnavailable: 0,
+		healthScaledDown:  0,
+		"unhealthy":       []string{},
+	}
+}
+
+func addHealth(counts map[string]interface{}, name string, desired, ready int32) {
+	state := healthHealthy
+	switch {
+	case desired == 0:
+		state = healthScaledDown
+	case ready == 0:
+		state = healthUnavailable
+	case ready < desired:
+		state = healthDegraded
+	}
+
+	counts["total"] = counts["total"].(int) + 1
+	counts[state] = counts[state].(int) + 1
+	if state == healthDegraded || state == healthUnavailable {
+		counts["unhealthy"] = append(counts["unhealthy"].([]string), name)
+	}
+}
+
+func getDeploymentHealth(deployments []appsv1.Deployment) map[string]interface{} {
+	counts := newHealthCounts()
+	for _, d := range deployments {
+		desired := int32(1)
+		if d.Spec.Replicas != nil {
+			desired = *d.Spec.Replicas
+		}
+		addHealth(counts, d.Name, desired, d.Status.AvailableReplicas)
+	}
+	return counts
+}
+
+func getStatefulSetHealth(statefulSets []appsv1.StatefulSet) map[string]interface{} {
+	counts := newHealthCounts()
+	for _, s := range statefulSets {
+		desired := int32(1)
+		if s.Spec.Replicas != nil {
+			desired = *s.Spec.Replicas
+		}
+		addHealth(counts, s.Name, desired, s.Status.ReadyReplicas)
+	}
+	return counts
+}
+
+func getDaemonSetHealth(daemonSets []appsv1.DaemonSet) map[string]interface{} {
+	counts := newHealthCounts()
+	for _, d := range daemonSets {
+		addHealth(counts, d.Name, d.Status.DesiredNumberScheduled, d.Status.NumberReady)
+	}
+	return counts
+}
+
+func getJobHealth(jobs []batchv1.Job) map[string]interface{} {
+	counts := map[string]interface{}{
+		"total":    len(jobs),
+		"active":   0,
+		"complete": 0,
+		"failed":   0,
+		"failing":  []string{},
+	}
+	for _, job := range jobs {
+		state := "active"
+		for _, condition := range job.Status.Conditions {
+			if condition.Status != corev1.ConditionTrue {
+				continue
+			}
+			switch condition.Type {
+			case batchv1.JobComplete:
+				state = "complete"
+			case batchv1.JobFailed:
+				state = "failed"
+			}
+		}
+		counts[state] = counts[state].(int) + 1
+		if state == "failed" {
+			counts["failing"] = append(counts["failing"].([]string), job.Name)
+		}
+	}
+	return counts
+}
+
+// getPodSummary counts pods by phase and lists pods with well-known container problems
+func getPodSummary(pods []corev1.Pod) map[string]interface{} {
+	phases := newPhaseCounts()
+	problems := make(map[string][]string, len(problemReasons))
+	for _, reason := range problemReasons {
+		problems[reason] = []string{}
+	}
+	var totalRestarts int32
+
+	for _, pod := range pods {
+		phases[phaseKey(pod.Status.Phase)]++
+
+		found := make(map[string]bool)
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			totalRestarts += status.RestartCount
+
+			var reasons []string
+			if status.State.Waiting != nil {
+				reasons = append(reasons, status.State.Waiting.Reason)
+			}
+			if status.State.Terminated != nil {
+				reasons = append(reasons, status.State.Terminated.Reason)
+			}
+			if status.LastTerminationState.Terminated != nil {
+				reasons = append(reasons, status.LastTerminationState.Terminated.Reason)
+			}
+
+			for _, reason := range reasons {
+				if _, tracked := problems[reason]; tracked && !found[reason] {
+					found[reason] = true
+					problems[reason] = append(problems[reason], pod.Name)
+				}
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"total":         len(pods),
+		"status":        phases,
+		"problems":      problems,
+		"totalRestarts": totalRestarts,
+	}
+}
+
+// getResourceTotals sums the effective requests and limits of all pods that are not finished
+func getResourceTotals(pods []corev1.Pod) map[string]interface{} {
+	requests := corev1.ResourceList{}
+	limits := corev1.ResourceList{}
+
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		base.AddResourceList(requests, base.PodEffectiveResources(&pod, func(r corev1.ResourceRequirements) corev1.ResourceList { return r.Requests }))
+		base.AddResourceList(limits, base.PodEffectiveResources(&pod, func(r corev1.ResourceRequirements) corev1.ResourceList { return r.Limits }))
+	}
+
+	return map[string]interface{}{
+		"requests": map[string]string{
+			"cpu":    requests.Cpu().String(),
+			"memory": requests.Memory().String(),
+		},
+		"limits": map[string]string{
+			"cpu":    limits.Cpu().String(),
+			"memory": limits.Memory().String(),
+		},
+	}
+}
+
+func getWarningEventSummary(events []corev1.Event) map[string]interface{} {
+	byReason := make(map[string]int32)
+	var total int32
+	for _, event := range events {
+		count := event.Count
+		if count == 0 {
+			count = 1
+		}
+		byReason[event.Reason] += count
+		total += count
+	}
+	return map[string]interface{}{
+		"total":    total,
+		"byReason": byReason,
+	}
+}
+
+// getServicesWithoutEndpoints returns selector-based services that have no ready endpoint address
+func getServicesWithoutEndpoints(services []corev1.Service, endpoints []corev1.Endpoints) []map[string]interface{} {
+	ready := make(map[string]int)
+	for _, ep := range endpoints {
+		for _, subset := range ep.Subsets {
+			ready[ep.Name] += len(subset.Addresses)
+		}
+	}
+
+	result := make([]map[string]interface{}, 0)
+	for _, svc := range services {
+		if len(svc.Spec.Selector) == 0 || svc.Spec.Type == corev1.ServiceTypeExternalName {
+			continue
+		}
+		if ready[svc.Name] == 0 {
+			result = append(result, map[string]interface{}{
+				"name":     svc.Name,
+				"type":     svc.Spec.Type,
+				"selector": svc.Spec.Selector,
+			})
+		}
+	}
+	return result
+}
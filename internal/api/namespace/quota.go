@@ -0,0 +1,303 @@
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s-glance-backend/internal/api/base"
+)
+
+// quotaPressureThreshold is the usage percentage from which a quota resource counts as under pressure
+const quotaPressureThreshold = 90.0
+
+// ListResourceQuotas returns all ResourceQuotas in a namespace
+func (api *NamespaceAPI) ListResourceQuotas(ctx context.Context, namespace string) (*corev1.ResourceQuotaList, error) {
+	api.LogInfo(ctx, "ListResourceQuotas", fmt.Sprintf("Fetching ResourceQuotas in namespace: %s", namespace))
+
+	quotas, err := api.GetClientset().CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListResourceQuotas", err)
+		return nil, api.HandleError(err, "list resourcequotas")
+	}
+
+	return quotas, nil
+}
+
+// GetResourceQuota returns a specific ResourceQuota
+func (api *NamespaceAPI) GetResourceQuota(ctx context.Context, namespace, name string) (*corev1.ResourceQuota, error) {
+	api.LogInfo(ctx, "GetResourceQuota", fmt.Sprintf("Fetching ResourceQuota %s in namespace %s", name, namespace))
+
+	quota, err := api.GetClientset().CoreV1().ResourceQuotas(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetResourceQuota", err)
+		return nil, api.HandleError(err, "get resourcequota")
+	}
+
+	return quota, nil
+}
+
+// CreateResourceQuota creates a new ResourceQuota
+func (api *NamespaceAPI) CreateResourceQuota(ctx context.Context, namespace string, quota *corev1.ResourceQuota) (*corev1.ResourceQuota, error) {
+	api.LogInfo(ctx, "CreateResourceQuota", fmt.Sprintf("Creating ResourceQuota %s in namespace %s", quota.Name, namespace))
+
+	result, err := api.GetClientset().CoreV1().ResourceQuotas(namespace).Create(ctx, quota, metav1.CreateOptions{})
+	if err != nil {
+		api.LogError(ctx, "CreateResourceQuota", err)
+		return nil, api.HandleError(err, "create resourcequota")
+	}
+
+	return result, nil
+}
+
+// UpdateResourceQuota updates an existing ResourceQuota
+func (api *NamespaceAPI) UpdateResourceQuota(ctx context.Context, namespace string, quota *corev1.ResourceQuota) (*corev1.ResourceQuota, error) {
+	api.LogInfo(ctx, "UpdateResourceQuota", fmt.Sprintf("Updating ResourceQuota %s in namespace %s", quota.Name, namespace))
+
+	result, err := api.GetClientset().CoreV1().ResourceQuotas(namespace).Update(ctx, quota, metav1.UpdateOptions{})
+	if err != nil {
+		api.LogError(ctx, "UpdateResourceQuota", err)
+		return nil, api.HandleError(err, "update resourcequota")
+	}
+
+	return result, nil
+}
+
+// DeleteResourceQuota deletes a specific ResourceQuota
+func (api *NamespaceAPI) DeleteResourceQuota(ctx context.Context, namespace, name string) error {
+	api.LogInfo(ctx, "DeleteResourceQuota", fmt.Sprintf("Deleting ResourceQuota %s in namespace %s", name, namespace))
+
+	err := api.GetClientset().CoreV1().ResourceQuotas(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		api.LogError(ctx, "DeleteResourceQuota", err)
+		return api.HandleError(err, "delete resourcequota")
+	}
+
+	return nil
+}
+
+// ListLimitRanges returns all LimitRanges in a namespace
+func (api *NamespaceAPI) ListLimitRanges(ctx context.Context, namespace string) (*corev1.LimitRangeList, error) {
+	api.LogInfo(ctx, "ListLimitRanges", fmt.Sprintf("Fetching LimitRanges in namespace: %s", namespace))
+
+	limitRanges, err := api.GetClientset().CoreV1().LimitRanges(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListLimitRanges", err)
+		return nil, api.HandleError(err, "list limitranges")
+	}
+
+	return limitRanges, nil
+}
+
+// GetLimitRange returns a specific LimitRange
+func (api *NamespaceAPI) GetLimitRange(ctx context.Context, namespace, name string) (*corev1.LimitRange, error) {
+	api.LogInfo(ctx, "GetLimitRange", fmt.Sprintf("Fetching LimitRange %s in namespace %s", name, namespace))
+
+	limitRange, err := api.GetClientset().CoreV1().LimitRanges(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetLimitRange", err)
+		return nil, api.HandleError(err, "get limitrange")
+	}
+
+	return limitRange, nil
+}
+
+// CreateLimitRange creates a new LimitRange
+func (api *NamespaceAPI) CreateLimitRange(ctx context.Context, namespace string, limitRange *corev1.LimitRange) (*corev1.LimitRange, error) {
+	api.LogInfo(ctx, "CreateLimitRange", fmt.Sprintf("Creating LimitRange %s in namespace %s", limitRange.Name, namespace))
+
+	result, err := api.GetClientset().CoreV1().LimitRanges(namespace).Create(ctx, limitRange, metav1.CreateOptions{})
+	if err != nil {
+		api.LogError(ctx, "CreateLimitRange", err)
+		return nil, api.HandleError(err, "create limitrange")
+	}
+
+	return result, nil
+}
+
+// UpdateLimitRange updates an existing LimitRange
+func (api *NamespaceAPI) UpdateLimitRange(ctx context.Context, namespace string, limitRange *corev1.LimitRange) (*corev1.LimitRange, error) {
+	api.LogInfo(ctx, "UpdateLimitRange", fmt.Sprintf("Updating LimitRange %s in namespace %s", limitRange.Name, namespace))
+
+	result, err := api.GetClientset().CoreV1().LimitRanges(namespace).Update(ctx, limitRange, metav1.UpdateOptions{})
+	if err != nil {
+		api.LogError(ctx, "UpdateLimitRange", err)
+		return nil, api.HandleError(err, "update limitrange")
+	}
+
+	return result, nil
+}
+
+// DeleteLimitRange deletes a specific LimitRange
+func (api *NamespaceAPI) DeleteLimitRange(ctx context.Context, namespace, name string) error {
+	api.LogInfo(ctx, "DeleteLimitRange", fmt.Sprintf("Deleting LimitRange %s in namespace %s", name, namespace))
+
+	err := api.GetClientset().CoreV1().LimitRanges(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		api.LogError(ctx, "DeleteLimitRange", err)
+		return api.HandleError(err, "delete limitrange")
+	}
+
+	return nil
+}
+
+// LimitRangeItemRequest describes a single LimitRange item with quantity strings
+type LimitRangeItemRequest struct {
+	Type                 string            `json:"type" binding:"required"`
+	Max                  map[string]string `json:"max"`
+	Min                  map[string]string `json:"min"`
+	Default              map[string]string `json:"default"`
+	DefaultRequest       map[string]string `json:"defaultRequest"`
+	MaxLimitRequestRatio map[string]string `json:"maxLimitRequestRatio"`
+}
+
+// Helper functions
+
+// ParseLimitRangeItems validates the requested items and converts them into LimitRange items
+func ParseLimitRangeItems(items []LimitRangeItemRequest) ([]corev1.LimitRangeItem, error) {
+	var result []corev1.LimitRangeItem
+	for i, item := range items {
+		field := fmt.Sprintf("limits[%d]", i)
+
+		limitType := corev1.LimitType(item.Type)
+		switch limitType {
+		case corev1.LimitTypeContainer, corev1.LimitTypePod, corev1.LimitTypePersistentVolumeClaim:
+		default:
+			return nil, fmt.Errorf("%s.type: must be one of %s, %s or %s", field,
+				corev1.LimitTypeContainer, corev1.LimitTypePod, corev1.LimitTypePersistentVolumeClaim)
+		}
+
+		limitRangeItem := corev1.LimitRangeItem{Type: limitType}
+		var err error
+		if limitRangeItem.Max, err = ParseResourceList(field+".max", item.Max); err != nil {
+			return nil, err
+		}
+		if limitRangeItem.Min, err = ParseResourceList(field+".min", item.Min); err != nil {
+			return nil, err
+		}
+		if limitRangeItem.Default, err = ParseResourceList(field+".default", item.Default); err != nil {
+			return nil, err
+		}
+		if limitRangeItem.DefaultRequest, err = ParseResourceList(field+".defaultRequest", item.DefaultRequest); err != nil {
+			return nil, err
+		}
+		if limitRangeItem.MaxLimitRequestRatio, err = ParseResourceList(field+".maxLimitRequestRatio", item.MaxLimitRequestRatio); err != nil {
+			return nil, err
+		}
+
+		// Min must not exceed max for the same resource
+		for name, min := range limitRangeItem.Min {
+			if max, ok := limitRangeItem.Max[name]; ok && min.Cmp(max) > 0 {
+				return nil, fmt.Errorf("%s.min.%s: must not be greater than max", field, name)
+			}
+		}
+
+		result = append(result, limitRangeItem)
+	}
+	return result, nil
+}
+
+// ParseResourceList converts a map of quantity strings into a ResourceList.
+// The field name is used to point at the offending entry in errors.
+func ParseResourceList(field string, values map[string]string) (corev1.ResourceList, error) {
+	if values == nil {
+		return nil, nil
+	}
+
+	result := make(corev1.ResourceList, len(values))
+	for name, value := range values {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: invalid quantity %q: %v", field, name, value, err)
+		}
+		if quantity.Sign() < 0 {
+			return nil, fmt.Errorf("%s.%s: quantity %q must not be negative", field, name, value)
+		}
+		result[corev1.ResourceName(name)] = quantity
+	}
+	return result, nil
+}
+
+// getQuotaUsage returns hard versus used for every resource of a quota, sorted by resource name
+func getQuotaUsage(quota *corev1.ResourceQuota) []map[string]interface{} {
+	// A freshly created quota has no status until the quota controller catches up
+	hardList := quota.Status.Hard
+	if len(hardList) == 0 {
+		hardList = quota.Spec.Hard
+	}
+
+	names := make([]string, 0, len(hardList))
+	for name := range hardList {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	result := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		hard := hardList[corev1.ResourceName(name)]
+		used := quota.Status.Used[corev1.ResourceName(name)]
+		result = append(result, map[string]interface{}{
+			"resource": name,
+			"hard":     hard.String(),
+			"used":     used.String(),
+			"percent":  usagePercent(used, hard),
+		})
+	}
+	return result
+}
+
+// usagePercent returns used as a percentage of hard, rounded to one decimal. Any usage of a
+// zero quota counts as fully used.
+func usagePercent(used, hard resource.Quantity) float64 {
+	if hard.IsZero() && !used.IsZero() {
+		return 100
+	}
+	return base.PercentOf(used, hard)
+}
+
+// getQuotaPressure summarises the quota resources of a namespace that are at or above the pressure threshold
+func getQuotaPressure(quotas []corev1.ResourceQuota) map[string]interface{} {
+	var pressured []map[string]interface{}
+	maxPercent := 0.0
+
+	for i := range quotas {
+		for _, usage := range getQuotaUsage(&quotas[i]) {
+			percent := usage["percent"].(float64)
+			if percent > maxPercent {
+				maxPercent = percent
+			}
+			if percent >= quotaPressureThreshold {
+				usage["quota"] = quotas[i].Name
+				pressured = append(pressured, usage)
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"quotaCount":         len(quotas),
+		"maxPercent":         maxPercent,
+		"threshold":          quotaPressureThreshold,
+		"underPressure":      len(pressured) > 0,
+		"pressuredResources": pressured,
+	}
+}
+
+// getLimitRangeItems converts LimitRange items into a response-friendly format
+func getLimitRangeItems(items []corev1.LimitRangeItem) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, item := range items {
+		result = append(result, map[string]interface{}{
+			"type":                 item.Type,
+			"max":                  item.Max,
+			"min":                  item.Min,
+			"default":              item.Default,
+			"defaultRequest":       item.DefaultRequest,
+			"maxLimitRequestRatio": item.MaxLimitRequestRatio,
+		})
+	}
+	return result
+}
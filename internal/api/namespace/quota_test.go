@@ -0,0 +1,82 @@
+package namespace
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestParseResourceList(t *testing.T) {
+	list, err := ParseResourceList("hard", map[string]string{"cpu": "500m", "memory": "1Gi", "pods": "10"})
+	if err != nil {
+		t.Fatalf("ParseResourceList: %v", err)
+	}
+	if cpu := list[corev1.ResourceCPU]; cpu.MilliValue() != 500 {
+		t.Errorf("cpu = %s, want 500m", cpu.String())
+	}
+
+	for _, value := range []string{"1GB", "abc", "-1"} {
+		if _, err := ParseResourceList("hard", map[string]string{"memory": value}); err == nil {
+			t.Errorf("ParseResourceList(%q): want error", value)
+		}
+	}
+}
+
+func TestQuotaUsage(t *testing.T) {
+	quota := &corev1.ResourceQuota{
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+			Used: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1900m"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		},
+	}
+
+	usage := getQuotaUsage(quota)
+	if len(usage) != 2 || usage[0]["resource"] != "cpu" || usage[0]["percent"] != 95.0 || usage[1]["percent"] != 25.0 {
+		t.Errorf("getQuotaUsage() = %v", usage)
+	}
+
+	pressure := getQuotaPressure([]corev1.ResourceQuota{*quota})
+	if pressure["underPressure"] != true || pressure["maxPercent"] != 95.0 {
+		t.Errorf("getQuotaPressure() = %v", pressure)
+	}
+}
+
+func TestParseLimitRangeItems(t *testing.T) {
+	_, err := ParseLimitRangeItems([]LimitRangeItemRequest{{
+		Type: "Container",
+		Min:  map[string]string{"cpu": "2"},
+		Max:  map[string]string{"cpu": "1"},
+	}})
+	if err == nil {
+		t.Errorf("min greater than max: want error")
+	}
+
+	if _, err := ParseLimitRangeItems([]LimitRangeItemRequest{{Type: "Node"}}); err == nil {
+		t.Errorf("invalid type: want error")
+	}
+}
+
+func TestUsagePercent(t *testing.T) {
+	tests := []struct {
+		used, hard string
+		want       float64
+	}{
+		{"4Ei", "8Ei", 50},
+		{"1", "3", 33.3},
+		{"2", "3", 66.7},
+		{"0", "0", 0},
+		{"1", "0", 100},
+	}
+	for _, tt := range tests {
+		if got := usagePercent(resource.MustParse(tt.used), resource.MustParse(tt.hard)); got != tt.want {
+			t.Errorf("usagePercent(%s, %s) = %v, want %v", tt.used, tt.hard, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"k8s-glance-backend/internal/api/configmap"
+	"k8s-glance-backend/internal/api/secret"
+)
+
+// Edge types of the namespace topology graph
+const (
+	EdgeRoutes      = "routes"      // ingress backend to service
+	EdgeTLS         = "tls"         // ingress to its TLS secret
+	EdgeSelects     = "selects"     // service selector to pod
+	EdgeOwns        = "owns"        // owner reference, e.g. deployment to replicaset to pod
+	EdgeReferences  = "references"  // pod volume or env reference to configmap or secret
+	EdgeMounts      = "mounts"      // pod to persistent volume claim
+	EdgeScheduledOn = "scheduledOn" // pod to cluster node
+)
+
+// TopologyNode is a vertex of the topology graph. IDs have the form Kind/name.
+// Missing marks objects that are referenced but do not exist.
+type TopologyNode struct {
+	ID      string `json:"id"`
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Status  string `json:"status,omitempty"`
+	Missing bool   `json:"missing,omitempty"`
+}
+
+// TopologyEdge is a directed edge between two node IDs
+type TopologyEdge struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Type  string `json:"type"`
+	Label string `json:"label,omitempty"`
+}
+
+// Topology is the graph of how traffic and configuration flow through a namespace:
+// ingresses, services, workloads, pods, configmaps, secrets, PVCs and the nodes pods run on
+type Topology struct {
+	Namespace string         `json:"namespace"`
+	Nodes     []TopologyNode `json:"nodes"`
+	Edges     []TopologyEdge `json:"edges"`
+
+	index map[string]int
+}
+
+// GetNamespaceTopology builds the topology graph of a namespace
+func (api *NamespaceAPI) GetNamespaceTopology(ctx context.Context, name string) (*Topology, error) {
+	api.LogInfo(ctx, "GetNamespaceTopology", fmt.Sprintf("Building topology for namespace: %s", name))
+
+	clientset := api.GetClientset()
+	topology := newTopology(name)
+
+	ingresses, err := clientset.NetworkingV1().Ingresses(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetNamespaceTopology", err)
+		return nil, api.HandleError(err, "list ingresses for topology")
+	}
+	services, err := clientset.CoreV1().Services(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetNamespaceTopology", err)
+		return nil, api.HandleError(err, "list services for topology")
+	}
+	deployments, err := clientset.AppsV1().Deployments(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetNamespaceTopology", err)
+		return nil, api.HandleError(err, "list deployments for topology")
+	}
+	replicaSets, err := clientset.AppsV1().ReplicaSets(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetNamespaceTopology", err)
+		return nil, api.HandleError(err, "list replicasets for topology")
+	}
+	statefulSets, err := clientset.AppsV1().StatefulSets(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetNamespaceTopology", err)
+		return nil, api.HandleError(err, "list statefulsets for topology")
+	}
+	pods, err := clientset.CoreV1().Pods(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetNamespaceTopology", err)
+		return nil, api.HandleError(err, "list pods for topology")
+	}
+	configMaps, err := clientset.CoreV1().ConfigMaps(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetNamespaceTopology", err)
+		return nil, api.HandleError(err, "list configmaps for topology")
+	}
+	secrets, err := clientset.CoreV1().Secrets(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetNamespaceTopology", err)
+		return nil, api.HandleError(err, "list secrets for topology")
+	}
+	claims, err := clientset.CoreV1().PersistentVolumeClaims(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetNamespaceTopology", err)
+		return nil, api.HandleError(err, "list persistentvolumeclaims for topology")
+	}
+
+	// Objects of the namespace
+	for _, svc := range services.Items {
+		topology.addNode("Service", svc.Name, string(svc.Spec.Type))
+	}
+	for _, deployment := range deployments.Items {
+		topology.addNode("Deployment", deployment.Name, fmt.Sprintf("%d/%d ready", deployment.Status.ReadyReplicas, deployment.Status.Replicas))
+	}
+	for _, rs := range replicaSets.Items {
+		// Old revisions scaled to zero only add noise
+		if rs.Status.Replicas == 0 && (rs.Spec.Replicas == nil || *rs.Spec.Replicas == 0) {
+			continue
+		}
+		topology.addNode("ReplicaSet", rs.Name, fmt.Sprintf("%d/%d ready", rs.Status.ReadyReplicas, rs.Status.Replicas))
+		topology.addOwnerEdges("ReplicaSet", rs.Name, rs.OwnerReferences)
+	}
+	for _, sts := range statefulSets.Items {
+		topology.addNode("StatefulSet", sts.Name, fmt.Sprintf("%d/%d ready", sts.Status.ReadyReplicas, sts.Status.Replicas))
+	}
+	for _, cm := range configMaps.Items {
+		topology.addNode("ConfigMap", cm.Name, "")
+	}
+	for _, s := range secrets.Items {
+		topology.addNode("Secret", s.Name, string(s.Type))
+	}
+	for _, pvc := range claims.Items {
+		topology.addNode("PersistentVolumeClaim", pvc.Name, string(pvc.Status.Phase))
+	}
+
+	// Ingress backends and TLS secrets
+	for _, ing := range ingresses.Items {
+		topology.addNode("Ingress", ing.Name, "")
+		for _, backend := range getTopologyIngressBackends(&ing) {
+			topology.ensureNode("Service", backend.service)
+			topology.addEdge(nodeID("Ingress", ing.Name), nodeID("Service", backend.service), EdgeRoutes, backend.label)
+		}
+		for _, tls := range ing.Spec.TLS {
+			if tls.SecretName == "" {
+				continue
+			}
+			topology.ensureNode("Secret", tls.SecretName)
+			topology.addEdge(nodeID("Ingress", ing.Name), nodeID("Secret", tls.SecretName), EdgeTLS, strings.Join(tls.Hosts, ","))
+		}
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		podID := topology.addNode("Pod", pod.Name, string(pod.Status.Phase))
+
+		for _, svc := range services.Items {
+			if len(svc.Spec.Selector) > 0 && labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(pod.Labels)) {
+				topology.addEdge(nodeID("Service", svc.Name), podID, EdgeSelects, "")
+			}
+		}
+		topology.addOwnerEdges("Pod", pod.Name, pod.OwnerReferences)
+
+		// The reference scans of the configmap and secret usage endpoints
+		for _, cm := range configMaps.Items {
+			if usage, _ := configmap.GetPodConfigMapReferences(pod, cm.Name); len(usage) > 0 {
+				topology.addEdge(podID, nodeID("ConfigMap", cm.Name), EdgeReferences, usageLabel(usage))
+			}
+		}
+		for _, s := range secrets.Items {
+			if usage, _ := secret.GetPodSecretReferences(pod, s.Name); len(usage) > 0 {
+				topology.addEdge(podID, nodeID("Secret", s.Name), EdgeReferences, usageLabel(usage))
+			}
+		}
+
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil {
+				claim := volume.PersistentVolumeClaim.ClaimName
+				topology.ensureNode("PersistentVolumeClaim", claim)
+				topology.addEdge(podID, nodeID("PersistentVolumeClaim", claim), EdgeMounts, volume.Name)
+			}
+		}
+
+		if pod.Spec.NodeName != "" {
+			topology.addNode("Node", pod.Spec.NodeName, "")
+			topology.addEdge(podID, nodeID("Node", pod.Spec.NodeName), EdgeScheduledOn, "")
+		}
+	}
+
+	return topology, nil
+}
+
+// DOT renders the topology in the Graphviz DOT language
+func (t *Topology) DOT() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", t.Namespace)
+	b.WriteString("  rankdir=LR;\n  node [shape=box];\n")
+	for _, node := range t.Nodes {
+		// Each part is escaped on its own so the \n line breaks stay intact
+		label := escapeDOT(node.Kind) + "\\n" + escapeDOT(node.Name)
+		if node.Status != "" {
+			label += "\\n" + escapeDOT(node.Status)
+		}
+		style := ""
+		if node.Missing {
+			style = ", style=dashed, color=red"
+		}
+		fmt.Fprintf(&b, "  %q [label=\"%s\"%s];\n", node.ID, label, style)
+	}
+	for _, edge := range t.Edges {
+		label := edge.Type
+		if edge.Label != "" {
+			label += ": " + edge.Label
+		}
+		fmt.Fprintf(&b, "  %q -> %q [label=\"%s\"];\n", edge.From, edge.To, escapeDOT(label))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Helper functions
+
+type topologyBackend struct {
+	service string
+	label   string
+}
+
+func newTopology(namespace string) *Topology {
+	return &Topology{
+		Namespace: namespace,
+		Nodes:     []TopologyNode{},
+		Edges:     []TopologyEdge{},
+		index:     make(map[string]int),
+	}
+}
+
+func nodeID(kind, name string) string {
+	return kind + "/" + name
+}
+
+// addNode adds a node once and returns its ID
+func (t *Topology) addNode(kind, name, status string) string {
+	id := nodeID(kind, name)
+	if i, ok := t.index[id]; ok {
+		t.Nodes[i].Missing = false
+		return id
+	}
+	t.index[id] = len(t.Nodes)
+	t.Nodes = append(t.Nodes, TopologyNode{ID: id, Kind: kind, Name: name, Status: status})
+	return id
+}
+
+// ensureNode adds a referenced object that was not listed, marked as missing
+func (t *Topology) ensureNode(kind, name string) {
+	id := nodeID(kind, name)
+	if _, ok := t.index[id]; ok {
+		return
+	}
+	t.index[id] = len(t.Nodes)
+	t.Nodes = append(t.Nodes, TopologyNode{ID: id, Kind: kind, Name: name, Missing: true})
+}
+
+func (t *Topology) addEdge(from, to, edgeType, label string) {
+	t.Edges = append(t.Edges, TopologyEdge{From: from, To: to, Type: edgeType, Label: label})
+}
+
+// addOwnerEdges links an object to those of its owners that are part of the graph
+func (t *Topology) addOwnerEdges(kind, name string, owners []metav1.OwnerReference) {
+	for _, owner := range owners {
+		ownerID := nodeID(owner.Kind, owner.Name)
+		if _, ok := t.index[ownerID]; ok {
+			t.addEdge(ownerID, nodeID(kind, name), EdgeOwns, "")
+		}
+	}
+}
+
+// getTopologyIngressBackends returns the service backends of an ingress, labelled with host and path
+func getTopologyIngressBackends(ing *networkingv1.Ingress) []topologyBackend {
+	var backends []topologyBackend
+	if backend := ing.Spec.DefaultBackend; backend != nil && backend.Service != nil {
+		backends = append(backends, topologyBackend{service: backend.Service.Name, label: "default"})
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		host := rule.Host
+		if host == "" {
+			host = "*"
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service == nil {
+				continue
+			}
+			backends = append(backends, topologyBackend{service: path.Backend.Service.Name, label: host + path.Path})
+		}
+	}
+	return backends
+}
+
+// usageLabel summarizes the kinds of references a pod makes, e.g. "envFrom,volumeMounts"
+func usageLabel(usage map[string][]string) string {
+	kinds := make([]string, 0, len(usage))
+	for kind := range usage {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return strings.Join(kinds, ",")
+}
+
+// escapeDOT escapes a raw value for a quoted DOT string. Backslashes go first so that the
+// escaped quotes are not doubled again.
+func escapeDOT(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, `"`, `\"`)
+}
@@ -0,0 +1,125 @@
+package namespace
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetNamespaceTopology(t *testing.T) {
+	replicas := int32(1)
+	api := newTestNamespaceAPI(
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"},
+			Spec: networkingv1.IngressSpec{
+				TLS: []networkingv1.IngressTLS{{Hosts: []string{"web.example.com"}, SecretName: "web-tls"}},
+				Rules: []networkingv1.IngressRule{{
+					Host: "web.example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{
+							{Path: "/", Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "web"}}},
+							{Path: "/api", Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "api"}}},
+							{Path: "/static", Backend: networkingv1.IngressBackend{Resource: &corev1.TypedLocalObjectReference{Kind: "Bucket", Name: "static"}}},
+						},
+					}},
+				}},
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "web"}},
+		},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}},
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-5d8f", Namespace: "team-a", OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web"}}},
+			Spec:       appsv1.ReplicaSetSpec{Replicas: &replicas},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "web-5d8f-x",
+				Namespace:       "team-a",
+				Labels:          map[string]string{"app": "web"},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-5d8f"}},
+			},
+			Spec: corev1.PodSpec{
+				NodeName: "node-1",
+				Containers: []corev1.Container{{
+					Name:    "web",
+					EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "web-config"}}}},
+				}},
+				Volumes: []corev1.Volume{{
+					Name:         "data",
+					VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "web-data"}},
+				}},
+			},
+		},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "web-config", Namespace: "team-a"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "web-tls", Namespace: "team-a"}, Type: corev1.SecretTypeTLS},
+	)
+
+	topology, err := api.GetNamespaceTopology(context.Background(), "team-a")
+	if err != nil {
+		t.Fatalf("GetNamespaceTopology: %v", err)
+	}
+
+	edges := make(map[string]bool)
+	for _, edge := range topology.Edges {
+		edges[edge.From+" "+edge.Type+" "+edge.To] = true
+	}
+	for _, want := range []string{
+		"Ingress/web routes Service/web",
+		"Ingress/web routes Service/api",
+		"Ingress/web tls Secret/web-tls",
+		"Service/web selects Pod/web-5d8f-x",
+		"Deployment/web owns ReplicaSet/web-5d8f",
+		"ReplicaSet/web-5d8f owns Pod/web-5d8f-x",
+		"Pod/web-5d8f-x references ConfigMap/web-config",
+		"Pod/web-5d8f-x mounts PersistentVolumeClaim/web-data",
+		"Pod/web-5d8f-x scheduledOn Node/node-1",
+	} {
+		if !edges[want] {
+			t.Errorf("missing edge %q in %v", want, topology.Edges)
+		}
+	}
+
+	missing := make(map[string]bool)
+	for _, node := range topology.Nodes {
+		missing[node.ID] = node.Missing
+	}
+	if !missing["Service/api"] || !missing["PersistentVolumeClaim/web-data"] || missing["Service/web"] {
+		t.Errorf("missing flags = %v, want only the api service and the web-data claim", missing)
+	}
+
+	dot := topology.DOT()
+	if !strings.HasPrefix(dot, `digraph "team-a" {`) || !strings.Contains(dot, `"Ingress/web" -> "Service/web" [label="routes: web.example.com/"]`) {
+		t.Errorf("DOT output = %s", dot)
+	}
+}
+
+func TestTopologyDOTEscaping(t *testing.T) {
+	topology := &Topology{
+		Namespace: "team-a",
+		Nodes: []TopologyNode{
+			{ID: "Ingress/web", Kind: "Ingress", Name: "web"},
+			{ID: "Service/web", Kind: "Service", Name: "web", Status: `say "hi"`},
+		},
+		Edges: []TopologyEdge{
+			{From: "Ingress/web", To: "Service/web", Type: EdgeRoutes, Label: `web.example.com/foo\`},
+		},
+	}
+
+	dot := topology.DOT()
+	for _, want := range []string{
+		`"Service/web" [label="Service\nweb\nsay \"hi\""];`,
+		`"Ingress/web" -> "Service/web" [label="routes: web.example.com/foo\\"];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT output is missing %s:\n%s", want, dot)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package networkpolicy
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ErrUnresolvedPort is returned when a named port is not declared by the destination pod
+var ErrUnresolvedPort = errors.New("named port not found on destination pod")
+
+// PodRef identifies a pod
+type PodRef struct {
+	Namespace string `json:"namespace" binding:"required"`
+	Pod       string `json:"pod" binding:"required"`
+}
+
+// ReachabilityRequest asks whether Source can open a connection to Destination on Port.
+// Port may be a number or the name of a container port of the destination pod.
+type ReachabilityRequest struct {
+	Source      PodRef             `json:"source" binding:"required"`
+	Destination PodRef             `json:"destination" binding:"required"`
+	Port        intstr.IntOrString `json:"port"`
+	Protocol    corev1.Protocol    `json:"protocol"`
+}
+
+// RuleMatch identifies a policy rule that allows the connection
+type RuleMatch struct {
+	Policy    string `json:"policy"`
+	Namespace string `json:"namespace"`
+	RuleIndex int    `json:"ruleIndex"`
+	Peer      string `json:"peer"`
+	Port      string `json:"port"`
+}
+
+// DirectionResult is the verdict for one side of the connection. A pod that no policy selects
+// for the direction is not isolated and allows all traffic in that direction.
+type DirectionResult struct {
+	Isolated     bool        `json:"isolated"`
+	Allowed      bool        `json:"allowed"`
+	Policies     []string    `json:"policies"`
+	MatchedRules []RuleMatch `json:"matchedRules"`
+	Reason       string      `json:"reason"`
+}
+
+// ReachabilityResult is the outcome of EvaluateReachability. Traffic is allowed only when
+// egress from the source and ingress to the destination both allow it.
+type ReachabilityResult struct {
+	Allowed  bool            `json:"allowed"`
+	Port     int32           `json:"port"`
+	PortName string          `json:"portName,omitempty"`
+	Protocol corev1.Protocol `json:"protocol"`
+	Egress   DirectionResult `json:"egress"`
+	Ingress  DirectionResult `json:"ingress"`
+	Notes    []string        `json:"notes"`
+}
+
+// EvaluateReachability decides from the object specs alone whether source can reach destination on
+// port/protocol, following the NetworkPolicy semantics of the networking.k8s.io/v1 API.
+// namespaces is used to evaluate namespace selectors and may omit namespaces that are not known.
+func EvaluateReachability(source, destination *corev1.Pod, namespaces map[string]*corev1.Namespace, policies []networkingv1.NetworkPolicy, port intstr.IntOrString, protocol corev1.Protocol) (*ReachabilityResult, error) {
+	if protocol == "" {
+		protocol = corev1.ProtocolTCP
+	}
+
+	portNumber := port.IntVal
+	portName := ""
+	if port.Type == intstr.String {
+		portName = port.StrVal
+		number, ok := namedContainerPort(destination, portName, protocol)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s/%s has no %s port named %q", ErrUnresolvedPort, destination.Namespace, destination.Name, protocol, portName)
+		}
+		portNumber = number
+	}
+
+	result := &ReachabilityResult{
+		Port:     portNumber,
+		PortName: portName,
+		Protocol: protocol,
+		Notes:    []string{},
+	}
+
+	result.Egress = evaluateDirection(networkingv1.PolicyTypeEgress, source, policies, func(policy *networkingv1.NetworkPolicy) []RuleMatch {
+		var matches []RuleMatch
+		for i, rule := range policy.Spec.Egress {
+			if peer, ok := matchPeers(rule.To, policy.Namespace, destination, namespaces); ok {
+				if port, ok := matchPorts(rule.Ports, destination, portNumber, protocol); ok {
+					matches = append(matches, RuleMatch{Policy: policy.Name, Namespace: policy.Namespace, RuleIndex: i, Peer: peer, Port: port})
+				}
+			}
+		}
+		return matches
+	})
+
+	result.Ingress = evaluateDirection(networkingv1.PolicyTypeIngress, destination, policies, func(policy *networkingv1.NetworkPolicy) []RuleMatch {
+		var matches []RuleMatch
+		for i, rule := range policy.Spec.Ingress {
+			if peer, ok := matchPeers(rule.From, policy.Namespace, source, namespaces); ok {
+				if port, ok := matchPorts(rule.Ports, destination, portNumber, protocol); ok {
+					matches = append(matches, RuleMatch{Policy: policy.Name, Namespace: policy.Namespace, RuleIndex: i, Peer: peer, Port: port})
+				}
+			}
+		}
+		return matches
+	})
+
+	result.Allowed = result.Egress.Allowed && result.Ingress.Allowed
+
+	for _, pod := range []*corev1.Pod{source, destination} {
+		if pod.Spec.HostNetwork {
+			result.Notes = append(result.Notes, fmt.Sprintf("%s/%s uses the host network; most network plugins do not apply policies to it", pod.Namespace, pod.Name))
+		}
+	}
+	if portName == "" && !declaresPort(destination, portNumber, protocol) {
+		result.Notes = append(result.Notes, fmt.Sprintf("%s/%s does not declare %s port %d; policies may allow it but nothing may be listening", destination.Namespace, destination.Name, protocol, portNumber))
+	}
+
+	return result, nil
+}
+
+// Helper functions
+
+func evaluateDirection(policyType networkingv1.PolicyType, pod *corev1.Pod, policies []networkingv1.NetworkPolicy, matchRules func(*networkingv1.NetworkPolicy) []RuleMatch) DirectionResult {
+	direction := strings.ToLower(string(policyType))
+	result := DirectionResult{
+		Policies:     []string{},
+		MatchedRules: []RuleMatch{},
+	}
+
+	for i := range policies {
+		policy := &policies[i]
+		if !selectsPod(policy, pod) || !hasPolicyType(policy, policyType) {
+			continue
+		}
+		result.Isolated = true
+		result.Policies = append(result.Policies, policy.Name)
+		result.MatchedRules = append(result.MatchedRules, matchRules(policy)...)
+	}
+
+	switch {
+	case !result.Isolated:
+		result.Allowed = true
+		result.Reason = fmt.Sprintf("no policy selects %s/%s for %s, so all %s traffic is allowed", pod.Namespace, pod.Name, direction, direction)
+	case len(result.MatchedRules) > 0:
+		result.Allowed = true
+		result.Reason = fmt.Sprintf("allowed by %d %s rule(s)", len(result.MatchedRules), direction)
+	default:
+		result.Reason = fmt.Sprintf("%s/%s is isolated for %s by %s and no rule allows this connection",
+			pod.Namespace, pod.Name, direction, strings.Join(result.Policies, ", "))
+	}
+
+	return result
+}
+
+// selectsPod reports whether the policy's podSelector selects the pod
+func selectsPod(policy *networkingv1.NetworkPolicy, pod *corev1.Pod) bool {
+	if policy.Namespace != pod.Namespace {
+		return false
+	}
+	return selectorMatches(&policy.Spec.PodSelector, pod.Labels)
+}
+
+// effectivePolicyTypes applies the API defaulting: Ingress always, Egress when egress rules are present
+func effectivePolicyTypes(policy *networkingv1.NetworkPolicy) []networkingv1.PolicyType {
+	if len(policy.Spec.PolicyTypes) > 0 {
+		return policy.Spec.PolicyTypes
+	}
+	types := []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}
+	if len(policy.Spec.Egress) > 0 {
+		types = append(types, networkingv1.PolicyTypeEgress)
+	}
+	return types
+}
+
+func hasPolicyType(policy *networkingv1.NetworkPolicy, policyType networkingv1.PolicyType) bool {
+	for _, t := range effectivePolicyTypes(policy) {
+		if t == policyType {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPeers returns a description of the first peer that matches the pod. An empty peer list matches everything.
+func matchPeers(peers []networkingv1.NetworkPolicyPeer, policyNamespace string, pod *corev1.Pod, namespaces map[string]*corev1.Namespace) (string, bool) {
+	if len(peers) == 0 {
+		return "any", true
+	}
+
+	for i, peer := range peers {
+		if peer.IPBlock != nil {
+			if ipBlockContains(peer.IPBlock, pod.Status.PodIP) {
+				return fmt.Sprintf("peer %d (ipBlock %s)", i, peer.IPBlock.CIDR), true
+			}
+			continue
+		}
+
+		if peer.NamespaceSelector == nil {
+			if pod.Namespace != policyNamespace {
+				continue
+			}
+		} else if !selectorMatches(peer.NamespaceSelector, namespaceLabels(pod.Namespace, namespaces)) {
+			continue
+		}
+		if peer.PodSelector != nil && !selectorMatches(peer.PodSelector, pod.Labels) {
+			continue
+		}
+		return fmt.Sprintf("peer %d", i), true
+	}
+	return "", false
+}
+
+// matchPorts returns a description of the first port entry that matches. An empty port list matches every port.
+func matchPorts(ports []networkingv1.NetworkPolicyPort, destination *corev1.Pod, port int32, protocol corev1.Protocol) (string, bool) {
+	if len(ports) == 0 {
+		return "any", true
+	}
+
+	for _, p := range ports {
+		portProtocol := corev1.ProtocolTCP
+		if p.Protocol != nil {
+			portProtocol = *p.Protocol
+		}
+		if portProtocol != protocol {
+			continue
+		}
+
+		if p.Port == nil {
+			return fmt.Sprintf("all %s ports", protocol), true
+		}
+		if p.Port.Type == intstr.String {
+			if number, ok := namedContainerPort(destination, p.Port.StrVal, protocol); ok && number == port {
+				return fmt.Sprintf("%s/%s (%d)", protocol, p.Port.StrVal, number), true
+			}
+			continue
+		}
+		if p.EndPort != nil {
+			if port >= p.Port.IntVal && port <= *p.EndPort {
+				return fmt.Sprintf("%s/%d-%d", protocol, p.Port.IntVal, *p.EndPort), true
+			}
+			continue
+		}
+		if p.Port.IntVal == port {
+			return fmt.Sprintf("%s/%d", protocol, port), true
+		}
+	}
+	return "", false
+}
+
+func selectorMatches(selector *metav1.LabelSelector, podLabels map[string]string) bool {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return s.Matches(labels.Set(podLabels))
+}
+
+// namespaceLabels returns the labels of a namespace, falling back to the name label the API server sets on every namespace
+func namespaceLabels(name string, namespaces map[string]*corev1.Namespace) map[string]string {
+	if namespace, ok := namespaces[name]; ok && namespace.Labels != nil {
+		return namespace.Labels
+	}
+	return map[string]string{corev1.LabelMetadataName: name}
+}
+
+func ipBlockContains(block *networkingv1.IPBlock, podIP string) bool {
+	ip := net.ParseIP(podIP)
+	if ip == nil {
+		return false
+	}
+	_, cidr, err := net.ParseCIDR(block.CIDR)
+	if err != nil || !cidr.Contains(ip) {
+		return false
+	}
+	for _, except := range block.Except {
+		if _, excluded, err := net.ParseCIDR(except); err == nil && excluded.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func namedContainerPort(pod *corev1.Pod, name string, protocol corev1.Protocol) (int32, bool) {
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.Name == name && containerPortProtocol(port) == protocol {
+				return port.ContainerPort, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func declaresPort(pod *corev1.Pod, number int32, protocol corev1.Protocol) bool {
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.ContainerPort == number && containerPortProtocol(port) == protocol {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containerPortProtocol(port corev1.ContainerPort) corev1.Protocol {
+	if port.Protocol == "" {
+		return corev1.ProtocolTCP
+	}
+	return port.Protocol
+}
@@ -0,0 +1,284 @@
+package networkpolicy
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type Handler struct {
+	api *NetworkPolicyAPI
+}
+
+// NetworkPolicyRequest holds the policy fields accepted on create and update.
+// On update only the fields that are present replace the existing ones.
+type NetworkPolicyRequest struct {
+	PodSelector *metav1.LabelSelector                   `json:"podSelector"`
+	PolicyTypes []networkingv1.PolicyType               `json:"policyTypes"`
+	Ingress     []networkingv1.NetworkPolicyIngressRule `json:"ingress"`
+	Egress      []networkingv1.NetworkPolicyEgressRule  `json:"egress"`
+	Labels      map[string]string                       `json:"labels"`
+	Annotations map[string]string                       `json:"annotations"`
+}
+
+func NewHandler(clientset *kubernetes.Clientset, logger *log.Logger) *Handler {
+	if logger == nil {
+		logger = log.New(gin.DefaultWriter, "[NETWORKPOLICY-API] ", log.LstdFlags)
+	}
+
+	return &Handler{
+		api: NewNetworkPolicyAPI(clientset, logger),
+	}
+}
+
+// ListNetworkPolicies handles GET /api/v1/networkpolicies/namespaces/:namespace
+func (h *Handler) ListNetworkPolicies(c *gin.Context) {
+	namespace := c.Param("namespace")
+	policies, err := h.api.ListNetworkPolicies(c.Request.Context(), namespace)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	var response []map[string]interface{}
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		response = append(response, map[string]interface{}{
+			"name":         policy.Name,
+			"namespace":    policy.Namespace,
+			"podSelector":  metav1.FormatLabelSelector(&policy.Spec.PodSelector),
+			"policyTypes":  effectivePolicyTypes(policy),
+			"ingressRules": len(policy.Spec.Ingress),
+			"egressRules":  len(policy.Spec.Egress),
+			"creationTime": policy.CreationTimestamp,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    response,
+	})
+}
+
+// GetNetworkPolicy handles GET /api/v1/networkpolicies/namespaces/:namespace/:name
+func (h *Handler) GetNetworkPolicy(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	details, err := h.api.GetNetworkPolicyDetails(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, details)
+}
+
+// CreateNetworkPolicy handles POST /api/v1/networkpolicies/namespaces/:namespace
+func (h *Handler) CreateNetworkPolicy(c *gin.Context) {
+	var createRequest struct {
+		Name string `json:"name" binding:"required"`
+		NetworkPolicyRequest
+	}
+
+	if err := c.ShouldBindJSON(&createRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	if err := validatePolicyTypes(createRequest.PolicyTypes); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	namespace := c.Param("namespace")
+
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      createRequest.Name,
+			Namespace: namespace,
+		},
+	}
+	applyPolicyRequest(policy, &createRequest.NetworkPolicyRequest)
+
+	result, err := h.api.CreateNetworkPolicy(c.Request.Context(), namespace, policy)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"name":      result.Name,
+			"namespace": result.Namespace,
+			"status":    "created",
+		},
+	})
+}
+
+// UpdateNetworkPolicy handles PUT /api/v1/networkpolicies/namespaces/:namespace/:name
+func (h *Handler) UpdateNetworkPolicy(c *gin.Context) {
+	var updateRequest NetworkPolicyRequest
+
+	if err := c.ShouldBindJSON(&updateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	if err := validatePolicyTypes(updateRequest.PolicyTypes); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	// Get existing network policy
+	existing, err := h.api.GetNetworkPolicy(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	applyPolicyRequest(existing, &updateRequest)
+
+	result, err := h.api.UpdateNetworkPolicy(c.Request.Context(), namespace, existing)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"name":      result.Name,
+			"namespace": result.Namespace,
+			"status":    "updated",
+		},
+	})
+}
+
+// DeleteNetworkPolicy handles DELETE /api/v1/networkpolicies/namespaces/:namespace/:name
+func (h *Handler) DeleteNetworkPolicy(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	err := h.api.DeleteNetworkPolicy(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "NetworkPolicy deleted successfully",
+	})
+}
+
+// AnalyzeReachability handles POST /api/v1/networkpolicies/analyze
+func (h *Handler) AnalyzeReachability(c *gin.Context) {
+	var analyzeRequest ReachabilityRequest
+
+	if err := c.ShouldBindJSON(&analyzeRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	if analyzeRequest.Port.String() == "" || analyzeRequest.Port.String() == "0" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "port is required",
+		})
+		return
+	}
+
+	result, err := h.api.AnalyzeReachability(c.Request.Context(), analyzeRequest)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrUnresolvedPort) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// Helper functions
+
+func validatePolicyTypes(policyTypes []networkingv1.PolicyType) error {
+	for _, policyType := range policyTypes {
+		if policyType != networkingv1.PolicyTypeIngress && policyType != networkingv1.PolicyTypeEgress {
+			return fmt.Errorf("invalid policy type %q, must be Ingress or Egress", policyType)
+		}
+	}
+	return nil
+}
+
+func applyPolicyRequest(policy *networkingv1.NetworkPolicy, req *NetworkPolicyRequest) {
+	if req.PodSelector != nil {
+		policy.Spec.PodSelector = *req.PodSelector
+	}
+	if req.PolicyTypes != nil {
+		policy.Spec.PolicyTypes = req.PolicyTypes
+	}
+	if req.Ingress != nil {
+		policy.Spec.Ingress = req.Ingress
+	}
+	if req.Egress != nil {
+		policy.Spec.Egress = req.Egress
+	}
+	if req.Labels != nil {
+		policy.Labels = req.Labels
+	}
+	if req.Annotations != nil {
+		policy.Annotations = req.Annotations
+	}
+}
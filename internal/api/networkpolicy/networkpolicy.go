@@ -0,0 +1,182 @@
+package networkpolicy
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s-glance-backend/internal/api/base"
+)
+
+// NetworkPolicyAPI handles NetworkPolicy operations
+type NetworkPolicyAPI struct {
+	*base.BaseAPI
+}
+
+// NewNetworkPolicyAPI creates a new NetworkPolicyAPI instance
+func NewNetworkPolicyAPI(clientset kubernetes.Interface, logger *log.Logger) *NetworkPolicyAPI {
+	return &NetworkPolicyAPI{
+		BaseAPI: base.NewBaseAPI(clientset, logger),
+	}
+}
+
+// ListNetworkPolicies returns all network policies in a namespace
+func (api *NetworkPolicyAPI) ListNetworkPolicies(ctx context.Context, namespace string) (*networkingv1.NetworkPolicyList, error) {
+	api.LogInfo(ctx, "ListNetworkPolicies", fmt.Sprintf("Fetching networkpolicies in namespace: %s", namespace))
+
+	policies, err := api.GetClientset().NetworkingV1().NetworkPolicies(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListNetworkPolicies", err)
+		return nil, api.HandleError(err, "list networkpolicies")
+	}
+
+	return policies, nil
+}
+
+// GetNetworkPolicy returns a specific network policy
+func (api *NetworkPolicyAPI) GetNetworkPolicy(ctx context.Context, namespace, name string) (*networkingv1.NetworkPolicy, error) {
+	api.LogInfo(ctx, "GetNetworkPolicy", fmt.Sprintf("Fetching networkpolicy %s in namespace %s", name, namespace))
+
+	policy, err := api.GetClientset().NetworkingV1().NetworkPolicies(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetNetworkPolicy", err)
+		return nil, api.HandleError(err, "get networkpolicy")
+	}
+
+	return policy, nil
+}
+
+// GetNetworkPolicyDetails returns a network policy together with the pods it selects in its namespace
+func (api *NetworkPolicyAPI) GetNetworkPolicyDetails(ctx context.Context, namespace, name string) (*base.APIResponse, error) {
+	policy, err := api.GetNetworkPolicy(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := api.GetClientset().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetNetworkPolicyDetails", err)
+		return nil, api.HandleError(err, "list pods")
+	}
+
+	selected := []string{}
+	for i := range pods.Items {
+		if selectsPod(policy, &pods.Items[i]) {
+			selected = append(selected, pods.Items[i].Name)
+		}
+	}
+
+	details := map[string]interface{}{
+		"name":         policy.Name,
+		"namespace":    policy.Namespace,
+		"podSelector":  policy.Spec.PodSelector,
+		"policyTypes":  effectivePolicyTypes(policy),
+		"ingress":      policy.Spec.Ingress,
+		"egress":       policy.Spec.Egress,
+		"selectedPods": selected,
+		"labels":       policy.Labels,
+		"annotations":  policy.Annotations,
+		"creationTime": policy.CreationTimestamp,
+	}
+
+	response := base.NewSuccessResponse(details)
+	return &response, nil
+}
+
+// CreateNetworkPolicy creates a new network policy
+func (api *NetworkPolicyAPI) CreateNetworkPolicy(ctx context.Context, namespace string, policy *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+	api.LogInfo(ctx, "CreateNetworkPolicy", fmt.Sprintf("Creating networkpolicy %s in namespace %s", policy.Name, namespace))
+
+	result, err := api.GetClientset().NetworkingV1().NetworkPolicies(namespace).Create(ctx, policy, metav1.CreateOptions{})
+	if err != nil {
+		api.LogError(ctx, "CreateNetworkPolicy", err)
+		return nil, api.HandleError(err, "create networkpolicy")
+	}
+
+	return result, nil
+}
+
+// UpdateNetworkPolicy updates an existing network policy
+func (api *NetworkPolicyAPI) UpdateNetworkPolicy(ctx context.Context, namespace string, policy *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+	api.LogInfo(ctx, "UpdateNetworkPolicy", fmt.Sprintf("Updating networkpolicy %s in namespace %s", policy.Name, namespace))
+
+	result, err := api.GetClientset().NetworkingV1().NetworkPolicies(namespace).Update(ctx, policy, metav1.UpdateOptions{})
+	if err != nil {
+		api.LogError(ctx, "UpdateNetworkPolicy", err)
+		return nil, api.HandleError(err, "update networkpolicy")
+	}
+
+	return result, nil
+}
+
+// DeleteNetworkPolicy deletes a specific network policy
+func (api *NetworkPolicyAPI) DeleteNetworkPolicy(ctx context.Context, namespace, name string) error {
+	api.LogInfo(ctx, "DeleteNetworkPolicy", fmt.Sprintf("Deleting networkpolicy %s in namespace %s", name, namespace))
+
+	err := api.GetClientset().NetworkingV1().NetworkPolicies(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		api.LogError(ctx, "DeleteNetworkPolicy", err)
+		return api.HandleError(err, "delete networkpolicy")
+	}
+
+	return nil
+}
+
+// AnalyzeReachability fetches the two pods, their namespaces and the policies of both namespaces,
+// then evaluates whether the source pod can reach the destination pod on the given port
+func (api *NetworkPolicyAPI) AnalyzeReachability(ctx context.Context, req ReachabilityRequest) (*ReachabilityResult, error) {
+	api.LogInfo(ctx, "AnalyzeReachability", fmt.Sprintf("Analyzing %s/%s -> %s/%s port %s",
+		req.Source.Namespace, req.Source.Pod, req.Destination.Namespace, req.Destination.Pod, req.Port.String()))
+
+	source, err := api.GetClientset().CoreV1().Pods(req.Source.Namespace).Get(ctx, req.Source.Pod, metav1.GetOptions{})
+	if err != nil {
+		api.LogError(ctx, "AnalyzeReachability", err)
+		return nil, api.HandleError(err, "get source pod")
+	}
+	destination, err := api.GetClientset().CoreV1().Pods(req.Destination.Namespace).Get(ctx, req.Destination.Pod, metav1.GetOptions{})
+	if err != nil {
+		api.LogError(ctx, "AnalyzeReachability", err)
+		return nil, api.HandleError(err, "get destination pod")
+	}
+
+	// Peers may select namespaces by label, so every namespace's labels are needed
+	namespaceList, err := api.GetClientset().CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "AnalyzeReachability", err)
+		return nil, api.HandleError(err, "list namespaces")
+	}
+	namespaces := make(map[string]*corev1.Namespace, len(namespaceList.Items))
+	for i := range namespaceList.Items {
+		namespaces[namespaceList.Items[i].Name] = &namespaceList.Items[i]
+	}
+
+	var policies []networkingv1.NetworkPolicy
+	for _, namespace := range uniqueNamespaces(req.Source.Namespace, req.Destination.Namespace) {
+		list, err := api.ListNetworkPolicies(ctx, namespace)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, list.Items...)
+	}
+
+	return EvaluateReachability(source, destination, namespaces, policies, req.Port, req.Protocol)
+}
+
+// Helper functions
+
+func uniqueNamespaces(namespaces ...string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, namespace := range namespaces {
+		if !seen[namespace] {
+			seen[namespace] = true
+			result = append(result, namespace)
+		}
+	}
+	return result
+}
@@ -0,0 +1,156 @@
+package networkpolicy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestPod(namespace, name, app, ip string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"app": app}},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name:  "main",
+			Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+		}}},
+		Status: corev1.PodStatus{PodIP: ip},
+	}
+}
+
+func newTestNamespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func denyAll(namespace string, types ...networkingv1.PolicyType) networkingv1.NetworkPolicy {
+	return networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-deny", Namespace: namespace},
+		Spec:       networkingv1.NetworkPolicySpec{PolicyTypes: types},
+	}
+}
+
+func allowFromFrontend(port intstr.IntOrString) networkingv1.NetworkPolicy {
+	return networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-frontend", Namespace: "backend"},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From: []networkingv1.NetworkPolicyPeer{{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "web"}},
+					PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "frontend"}},
+				}},
+				Ports: []networkingv1.NetworkPolicyPort{{Port: &port}},
+			}},
+		},
+	}
+}
+
+func TestEvaluateReachability(t *testing.T) {
+	source := newTestPod("web", "frontend-1", "frontend", "10.0.1.5")
+	other := newTestPod("web", "crawler-1", "crawler", "10.0.1.6")
+	destination := newTestPod("backend", "api-1", "api", "10.0.2.7")
+	namespaces := map[string]*corev1.Namespace{
+		"web":     newTestNamespace("web", map[string]string{"team": "web"}),
+		"backend": newTestNamespace("backend", nil),
+	}
+
+	tests := []struct {
+		name     string
+		source   *corev1.Pod
+		policies []networkingv1.NetworkPolicy
+		port     intstr.IntOrString
+		allowed  bool
+		matched  string
+	}{
+		{"no policies", source, nil, intstr.FromInt(8080), true, ""},
+		{"ingress deny all", source, []networkingv1.NetworkPolicy{denyAll("backend")}, intstr.FromInt(8080), false, ""},
+		{"allowed by named port rule", source, []networkingv1.NetworkPolicy{denyAll("backend"), allowFromFrontend(intstr.FromString("http"))}, intstr.FromInt(8080), true, "allow-frontend"},
+		{"request by port name", source, []networkingv1.NetworkPolicy{allowFromFrontend(intstr.FromInt(8080))}, intstr.FromString("http"), true, "allow-frontend"},
+		{"wrong port", source, []networkingv1.NetworkPolicy{allowFromFrontend(intstr.FromInt(8080))}, intstr.FromInt(9090), false, ""},
+		{"pod selector mismatch", other, []networkingv1.NetworkPolicy{allowFromFrontend(intstr.FromInt(8080))}, intstr.FromInt(8080), false, ""},
+		{"egress deny all", source, []networkingv1.NetworkPolicy{denyAll("web", networkingv1.PolicyTypeEgress)}, intstr.FromInt(8080), false, ""},
+	}
+
+	for _, tt := range tests {
+		result, err := EvaluateReachability(tt.source, destination, namespaces, tt.policies, tt.port, "")
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if result.Allowed != tt.allowed {
+			t.Errorf("%s: allowed = %v, want %v (egress %q, ingress %q)", tt.name, result.Allowed, tt.allowed, result.Egress.Reason, result.Ingress.Reason)
+		}
+		if tt.matched != "" && (len(result.Ingress.MatchedRules) != 1 || result.Ingress.MatchedRules[0].Policy != tt.matched) {
+			t.Errorf("%s: matched rules = %+v, want %s", tt.name, result.Ingress.MatchedRules, tt.matched)
+		}
+		if result.Port != 8080 && tt.port.IntVal != 9090 {
+			t.Errorf("%s: resolved port = %d, want 8080", tt.name, result.Port)
+		}
+	}
+
+	if _, err := EvaluateReachability(source, destination, namespaces, nil, intstr.FromString("grpc"), ""); !errors.Is(err, ErrUnresolvedPort) {
+		t.Errorf("unknown port name: err = %v, want ErrUnresolvedPort", err)
+	}
+}
+
+func TestEvaluateReachabilityEgressIPBlock(t *testing.T) {
+	source := newTestPod("web", "frontend-1", "frontend", "10.0.1.5")
+	destination := newTestPod("backend", "api-1", "api", "10.0.2.7")
+	udp := corev1.ProtocolUDP
+
+	policy := networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "egress", Namespace: "web"},
+		Spec: networkingv1.NetworkPolicySpec{
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress: []networkingv1.NetworkPolicyEgressRule{
+				{Ports: []networkingv1.NetworkPolicyPort{{Protocol: &udp}}},
+				{To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/16", Except: []string{"10.0.3.0/24"}}}}},
+			},
+		},
+	}
+
+	result, err := EvaluateReachability(source, destination, nil, []networkingv1.NetworkPolicy{policy}, intstr.FromInt(8080), "")
+	if err != nil {
+		t.Fatalf("EvaluateReachability: %v", err)
+	}
+	if !result.Allowed || len(result.Egress.MatchedRules) != 1 || result.Egress.MatchedRules[0].RuleIndex != 1 {
+		t.Errorf("egress = %+v, want allowed by rule 1 only", result.Egress)
+	}
+
+	destination.Status.PodIP = "10.0.3.9"
+	result, _ = EvaluateReachability(source, destination, nil, []networkingv1.NetworkPolicy{policy}, intstr.FromInt(8080), "")
+	if result.Allowed {
+		t.Error("destination in an except range should be denied")
+	}
+}
+
+func TestAnalyzeReachability(t *testing.T) {
+	deny := denyAll("backend")
+	allow := allowFromFrontend(intstr.FromInt(8080))
+	api := NewNetworkPolicyAPI(fake.NewSimpleClientset(
+		newTestNamespace("web", map[string]string{"team": "web"}),
+		newTestNamespace("backend", nil),
+		newTestPod("web", "frontend-1", "frontend", "10.0.1.5"),
+		newTestPod("backend", "api-1", "api", "10.0.2.7"),
+		&deny,
+		&allow,
+	), log.New(io.Discard, "", 0))
+
+	result, err := api.AnalyzeReachability(context.Background(), ReachabilityRequest{
+		Source:      PodRef{Namespace: "web", Pod: "frontend-1"},
+		Destination: PodRef{Namespace: "backend", Pod: "api-1"},
+		Port:        intstr.FromInt(8080),
+	})
+	if err != nil {
+		t.Fatalf("AnalyzeReachability: %v", err)
+	}
+	if !result.Allowed || len(result.Ingress.Policies) != 2 {
+		t.Errorf("result = %+v, want allowed with two isolating policies", result)
+	}
+}
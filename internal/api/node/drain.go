@@ -0,0 +1,286 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// mirrorPodAnnotation marks static pods managed directly by the kubelet
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// defaultDrainTimeout bounds a drain when no timeout is given
+const defaultDrainTimeout = 5 * time.Minute
+
+// Polling periods while waiting on PodDisruptionBudgets and pod termination
+var (
+	evictionRetryPeriod  = 5 * time.Second
+	deletionPollInterval = 2 * time.Second
+)
+
+// ErrDrainBlocked is returned when pods on the node cannot be evicted without forcing
+var ErrDrainBlocked = errors.New("drain blocked")
+
+// DrainOptions controls how a node is drained
+type DrainOptions struct {
+	// GracePeriodSeconds overrides the termination grace period of evicted pods
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds"`
+	// TimeoutSeconds bounds the whole drain, including waiting on PodDisruptionBudgets
+	TimeoutSeconds int `json:"timeoutSeconds"`
+	// DeleteEmptyDirData allows evicting pods that use emptyDir volumes, losing their data
+	DeleteEmptyDirData bool `json:"deleteEmptyDirData"`
+	// Force allows evicting pods that are not managed by a controller and will not be recreated
+	Force bool `json:"force"`
+}
+
+// DrainReporter receives drain progress events
+type DrainReporter func(event string, data map[string]interface{})
+
+// drainPlan is the classification of the pods on a node before draining
+type drainPlan struct {
+	evict   []corev1.Pod
+	skipped []map[string]interface{}
+	blocked []map[string]interface{}
+}
+
+// DrainNode cordons a node and evicts its pods through the Eviction API, so PodDisruptionBudgets
+// are honoured. DaemonSet and mirror pods are skipped. Progress is sent to report as it happens.
+func (api *NodeAPI) DrainNode(ctx context.Context, name string, options DrainOptions, report DrainReporter) error {
+	api.LogInfo(ctx, "DrainNode", fmt.Sprintf("Draining node: %s", name))
+
+	timeout := defaultDrainTimeout
+	if options.TimeoutSeconds > 0 {
+		timeout = time.Duration(options.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := api.SetUnschedulable(ctx, name, true); err != nil {
+		return err
+	}
+	report("cordoned", map[string]interface{}{"node": name})
+
+	pods, err := api.listActivePods(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	plan := planDrain(pods, options)
+	budgets, err := api.getPodBudgets(ctx, plan.evict)
+	if err != nil {
+		return err
+	}
+
+	toEvict := make([]map[string]interface{}, 0, len(plan.evict))
+	for _, pod := range plan.evict {
+		toEvict = append(toEvict, map[string]interface{}{
+			"name":      pod.Name,
+			"namespace": pod.Namespace,
+			"budgets":   budgets[podKey(&pod)],
+		})
+	}
+	report("plan", map[string]interface{}{
+		"node":    name,
+		"evict":   toEvict,
+		"skipped": plan.skipped,
+		"blocked": plan.blocked,
+	})
+
+	if len(plan.blocked) > 0 {
+		return fmt.Errorf("%w: %d pod(s) cannot be evicted without force or deleteEmptyDirData; the node stays cordoned", ErrDrainBlocked, len(plan.blocked))
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		failures []string
+	)
+	safeReport := func(event string, data map[string]interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		report(event, data)
+	}
+
+	for i := range plan.evict {
+		wg.Add(1)
+		go func(pod *corev1.Pod) {
+			defer wg.Done()
+			if err := api.evictPod(ctx, pod, options.GracePeriodSeconds, safeReport); err != nil {
+				mu.Lock()
+				failures = append(failures, fmt.Sprintf("%s/%s: %v", pod.Namespace, pod.Name, err))
+				mu.Unlock()
+				safeReport("failed", map[string]interface{}{
+					"name":      pod.Name,
+					"namespace": pod.Namespace,
+					"error":     err.Error(),
+				})
+			}
+		}(&plan.evict[i])
+	}
+	wg.Wait()
+
+	if len(failures) > 0 {
+		api.LogError(ctx, "DrainNode", fmt.Errorf("failed to evict %d pod(s)", len(failures)))
+		return fmt.Errorf("failed to evict %d pod(s); the node stays cordoned: %v", len(failures), failures)
+	}
+
+	report("complete", map[string]interface{}{
+		"node":    name,
+		"evicted": len(plan.evict),
+		"skipped": len(plan.skipped),
+	})
+	return nil
+}
+
+// evictPod evicts a pod, retrying while a PodDisruptionBudget forbids the disruption, and waits
+// until the pod is gone
+func (api *NodeAPI) evictPod(ctx context.Context, pod *corev1.Pod, gracePeriodSeconds *int64, report DrainReporter) error {
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+	}
+	if gracePeriodSeconds != nil {
+		eviction.DeleteOptions = &metav1.DeleteOptions{GracePeriodSeconds: gracePeriodSeconds}
+	}
+
+	report("evicting", map[string]interface{}{"name": pod.Name, "namespace": pod.Namespace})
+
+	for {
+		err := api.GetClientset().CoreV1().Pods(pod.Namespace).EvictV1(ctx, eviction)
+		if err == nil || apierrors.IsNotFound(err) {
+			break
+		}
+		if !apierrors.IsTooManyRequests(err) {
+			return err
+		}
+
+		// A PodDisruptionBudget does not allow the disruption right now
+		report("blocked", map[string]interface{}{
+			"name":      pod.Name,
+			"namespace": pod.Namespace,
+			"reason":    err.Error(),
+		})
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for PodDisruptionBudget: %v", err)
+		case <-time.After(evictionRetryPeriod):
+		}
+	}
+
+	report("evicted", map[string]interface{}{"name": pod.Name, "namespace": pod.Namespace})
+
+	for {
+		current, err := api.GetClientset().CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) || (err == nil && current.UID != pod.UID) {
+			report("deleted", map[string]interface{}{"name": pod.Name, "namespace": pod.Namespace})
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for pod termination")
+		case <-time.After(deletionPollInterval):
+		}
+	}
+}
+
+// getPodBudgets returns the PodDisruptionBudgets covering each pod, keyed by namespace/name
+func (api *NodeAPI) getPodBudgets(ctx context.Context, pods []corev1.Pod) (map[string][]map[string]interface{}, error) {
+	result := make(map[string][]map[string]interface{})
+	budgetsByNamespace := make(map[string][]policyv1.PodDisruptionBudget)
+
+	for i := range pods {
+		pod := &pods[i]
+		budgets, ok := budgetsByNamespace[pod.Namespace]
+		if !ok {
+			list, err := api.GetClientset().PolicyV1().PodDisruptionBudgets(pod.Namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				api.LogError(ctx, "getPodBudgets", err)
+				return nil, api.HandleError(err, "list poddisruptionbudgets")
+			}
+			budgets = list.Items
+			budgetsByNamespace[pod.Namespace] = budgets
+		}
+
+		for _, budget := range budgets {
+			selector, err := metav1.LabelSelectorAsSelector(budget.Spec.Selector)
+			if err != nil || selector.Empty() || !selector.Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+			result[podKey(pod)] = append(result[podKey(pod)], map[string]interface{}{
+				"name":               budget.Name,
+				"disruptionsAllowed": budget.Status.DisruptionsAllowed,
+			})
+		}
+	}
+	return result, nil
+}
+
+// Helper functions
+
+// planDrain sorts the pods on a node into pods to evict, pods that are skipped and pods
+// that block the drain unless the matching option is set
+func planDrain(pods []corev1.Pod, options DrainOptions) drainPlan {
+	var plan drainPlan
+	for _, pod := range pods {
+		entry := map[string]interface{}{
+			"name":      pod.Name,
+			"namespace": pod.Namespace,
+		}
+
+		if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+			entry["reason"] = "mirror pod managed by the kubelet"
+			plan.skipped = append(plan.skipped, entry)
+			continue
+		}
+
+		owner := metav1.GetControllerOf(&pod)
+		if owner != nil && owner.Kind == "DaemonSet" {
+			entry["reason"] = fmt.Sprintf("managed by DaemonSet %s", owner.Name)
+			plan.skipped = append(plan.skipped, entry)
+			continue
+		}
+
+		var reasons []string
+		if owner == nil && !options.Force {
+			reasons = append(reasons, "not managed by a controller and will not be recreated (use force)")
+		}
+		if usesEmptyDir(&pod) && !options.DeleteEmptyDirData {
+			reasons = append(reasons, "uses emptyDir volumes whose data will be lost (use deleteEmptyDirData)")
+		}
+		if len(reasons) > 0 {
+			entry["reasons"] = reasons
+			plan.blocked = append(plan.blocked, entry)
+			continue
+		}
+
+		plan.evict = append(plan.evict, pod)
+	}
+	return plan
+}
+
+func usesEmptyDir(pod *corev1.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func podKey(pod *corev1.Pod) string {
+	return pod.Namespace + "/" + pod.Name
+}
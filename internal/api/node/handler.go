@@ -0,0 +1,111 @@
+package node
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s-glance-backend/internal/api/base"
+)
+
+type Handler struct {
+	api *NodeAPI
+}
+
+func NewHandler(clientset *kubernetes.Clientset, logger *log.Logger) *Handler {
+	if logger == nil {
+		logger = log.New(gin.DefaultWriter, "[NODE-API] ", log.LstdFlags)
+	}
+
+	return &Handler{
+		api: NewNodeAPI(clientset, logger),
+	}
+}
+
+// ListNodes handles GET /api/v1/nodes
+func (h *Handler) ListNodes(c *gin.Context) {
+	nodes, err := h.api.ListNodes(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    nodes,
+	})
+}
+
+// GetNode handles GET /api/v1/nodes/:name
+func (h *Handler) GetNode(c *gin.Context) {
+	name := c.Param("name")
+
+	details, err := h.api.GetNodeDetails(c.Request.Context(), name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, details)
+}
+
+// CordonNode handles POST /api/v1/nodes/:name/cordon
+func (h *Handler) CordonNode(c *gin.Context) {
+	h.setUnschedulable(c, true, "Node cordoned successfully")
+}
+
+// UncordonNode handles POST /api/v1/nodes/:name/uncordon
+func (h *Handler) UncordonNode(c *gin.Context) {
+	h.setUnschedulable(c, false, "Node uncordoned successfully")
+}
+
+func (h *Handler) setUnschedulable(c *gin.Context, unschedulable bool, message string) {
+	name := c.Param("name")
+
+	err := h.api.SetUnschedulable(c.Request.Context(), name, unschedulable)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": message,
+	})
+}
+
+// DrainNode handles POST /api/v1/nodes/:name/drain
+// and streams the drain progress as Server-Sent Events
+func (h *Handler) DrainNode(c *gin.Context) {
+	var options DrainOptions
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&options); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "Invalid request format: " + err.Error(),
+			})
+			return
+		}
+	}
+
+	name := c.Param("name")
+
+	base.PrepareStream(c)
+	err := h.api.DrainNode(c.Request.Context(), name, options, func(event string, data map[string]interface{}) {
+		base.SendEvent(c, event, data)
+	})
+	if err != nil && c.Request.Context().Err() == nil {
+		base.SendEvent(c, "error", gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,283 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s-glance-backend/internal/api/base"
+)
+
+// Label prefixes used to derive node roles
+const (
+	nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+	legacyRoleLabel     = "kubernetes.io/role"
+)
+
+// NodeAPI handles node-related operations
+type NodeAPI struct {
+	*base.BaseAPI
+}
+
+// NewNodeAPI creates a new NodeAPI instance
+func NewNodeAPI(clientset kubernetes.Interface, logger *log.Logger) *NodeAPI {
+	return &NodeAPI{
+		BaseAPI: base.NewBaseAPI(clientset, logger),
+	}
+}
+
+// ListNodes returns an inventory of all nodes with their capacity usage
+func (api *NodeAPI) ListNodes(ctx context.Context) ([]map[string]interface{}, error) {
+	api.LogInfo(ctx, "ListNodes", "Fetching all nodes")
+
+	nodes, err := api.GetClientset().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListNodes", err)
+		return nil, api.HandleError(err, "list nodes")
+	}
+
+	pods, err := api.listActivePods(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	podsByNode := make(map[string][]corev1.Pod)
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" {
+			podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], pod)
+		}
+	}
+
+	sort.Slice(nodes.Items, func(i, j int) bool {
+		return nodes.Items[i].Name < nodes.Items[j].Name
+	})
+
+	result := make([]map[string]interface{}, 0, len(nodes.Items))
+	for i := range nodes.Items {
+		result = append(result, getNodeSummary(&nodes.Items[i], podsByNode[nodes.Items[i].Name]))
+	}
+	return result, nil
+}
+
+// GetNode returns a specific node
+func (api *NodeAPI) GetNode(ctx context.Context, name string) (*corev1.Node, error) {
+	api.LogInfo(ctx, "GetNode", fmt.Sprintf("Fetching node: %s", name))
+
+	node, err := api.GetClientset().CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetNode", err)
+		return nil, api.HandleError(err, "get node")
+	}
+
+	return node, nil
+}
+
+// GetNodeDetails returns the inventory of a node together with the pods running on it
+func (api *NodeAPI) GetNodeDetails(ctx context.Context, name string) (*base.APIResponse, error) {
+	api.LogInfo(ctx, "GetNodeDetails", fmt.Sprintf("Fetching details for node: %s", name))
+
+	node, err := api.GetNode(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := api.listActivePods(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	details := getNodeSummary(node, pods)
+	details["labels"] = node.Labels
+	details["annotations"] = node.Annotations
+	details["addresses"] = node.Status.Addresses
+	details["podCIDRs"] = node.Spec.PodCIDRs
+	details["providerID"] = node.Spec.ProviderID
+	details["nodeInfo"] = map[string]interface{}{
+		"kernelVersion":           node.Status.NodeInfo.KernelVersion,
+		"osImage":                 node.Status.NodeInfo.OSImage,
+		"containerRuntimeVersion": node.Status.NodeInfo.ContainerRuntimeVersion,
+		"kubeProxyVersion":        node.Status.NodeInfo.KubeProxyVersion,
+	}
+	details["pods"] = getNodePods(pods)
+
+	response := base.NewSuccessResponse(details)
+	return &response, nil
+}
+
+// SetUnschedulable cordons or uncordons a node
+func (api *NodeAPI) SetUnschedulable(ctx context.Context, name string, unschedulable bool) error {
+	api.LogInfo(ctx, "SetUnschedulable", fmt.Sprintf("Setting unschedulable=%t on node %s", unschedulable, name))
+
+	patch := fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable)
+	_, err := api.GetClientset().CoreV1().Nodes().Patch(ctx, name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	if err != nil {
+		api.LogError(ctx, "SetUnschedulable", err)
+		if unschedulable {
+			return api.HandleError(err, "cordon node")
+		}
+		return api.HandleError(err, "uncordon node")
+	}
+
+	return nil
+}
+
+// listActivePods returns pods that are not finished, on one node or on all nodes when nodeName is empty
+func (api *NodeAPI) listActivePods(ctx context.Context, nodeName string) ([]corev1.Pod, error) {
+	selectors := []fields.Selector{
+		fields.OneTermNotEqualSelector("status.phase", string(corev1.PodSucceeded)),
+		fields.OneTermNotEqualSelector("status.phase", string(corev1.PodFailed)),
+	}
+	if nodeName != "" {
+		selectors = append(selectors, fields.OneTermEqualSelector("spec.nodeName", nodeName))
+	}
+
+	pods, err := api.GetClientset().CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.AndSelectors(selectors...).String(),
+	})
+	if err != nil {
+		api.LogError(ctx, "listActivePods", err)
+		return nil, api.HandleError(err, "list node pods")
+	}
+
+	return pods.Items, nil
+}
+
+// Helper functions
+
+func getNodeSummary(node *corev1.Node, pods []corev1.Pod) map[string]interface{} {
+	requests := corev1.ResourceList{}
+	limits := corev1.ResourceList{}
+	for i := range pods {
+		base.AddResourceList(requests, base.PodEffectiveResources(&pods[i], func(r corev1.ResourceRequirements) corev1.ResourceList { return r.Requests }))
+		base.AddResourceList(limits, base.PodEffectiveResources(&pods[i], func(r corev1.ResourceRequirements) corev1.ResourceList { return r.Limits }))
+	}
+	podCount := resource.NewQuantity(int64(len(pods)), resource.DecimalSI)
+
+	allocatable := node.Status.Allocatable
+	return map[string]interface{}{
+		"name":           node.Name,
+		"roles":          getNodeRoles(node),
+		"ready":          isNodeReady(node),
+		"unschedulable":  node.Spec.Unschedulable,
+		"kubeletVersion": node.Status.NodeInfo.KubeletVersion,
+		"os":             node.Status.NodeInfo.OperatingSystem,
+		"architecture":   node.Status.NodeInfo.Architecture,
+		"internalIP":     getNodeAddress(node, corev1.NodeInternalIP),
+		"conditions":     getNodeConditions(node.Status.Conditions),
+		"taints":         getNodeTaints(node.Spec.Taints),
+		"resources": map[string]interface{}{
+			"cpu":    getResourceUsage(allocatable.Cpu(), requests.Cpu(), limits.Cpu()),
+			"memory": getResourceUsage(allocatable.Memory(), requests.Memory(), limits.Memory()),
+			"pods":   getResourceUsage(allocatable.Pods(), podCount, nil),
+		},
+		"podCount":     len(pods),
+		"creationTime": node.CreationTimestamp,
+	}
+}
+
+func getResourceUsage(allocatable, requested, limits *resource.Quantity) map[string]interface{} {
+	usage := map[string]interface{}{
+		"allocatable":      allocatable.String(),
+		"requested":        requested.String(),
+		"requestedPercent": base.PercentOf(*requested, *allocatable),
+	}
+	if limits != nil {
+		usage["limits"] = limits.String()
+		usage["limitsPercent"] = base.PercentOf(*limits, *allocatable)
+	}
+	return usage
+}
+
+// getNodeRoles derives roles from node-role.kubernetes.io/<role> labels and the legacy kubernetes.io/role label
+func getNodeRoles(node *corev1.Node) []string {
+	roles := []string{}
+	for key, value := range node.Labels {
+		switch {
+		case strings.HasPrefix(key, nodeRoleLabelPrefix):
+			if role := strings.TrimPrefix(key, nodeRoleLabelPrefix); role != "" {
+				roles = append(roles, role)
+			}
+		case key == legacyRoleLabel && value != "":
+			roles = append(roles, value)
+		}
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+func getNodeAddress(node *corev1.Node, addressType corev1.NodeAddressType) string {
+	for _, address := range node.Status.Addresses {
+		if address.Type == addressType {
+			return address.Address
+		}
+	}
+	return ""
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func getNodeConditions(conditions []corev1.NodeCondition) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, condition := range conditions {
+		result = append(result, map[string]interface{}{
+			"type":               condition.Type,
+			"status":             condition.Status,
+			"reason":             condition.Reason,
+			"message":            condition.Message,
+			"lastTransitionTime": condition.LastTransitionTime,
+		})
+	}
+	return result
+}
+
+func getNodeTaints(taints []corev1.Taint) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(taints))
+	for _, taint := range taints {
+		result = append(result, map[string]interface{}{
+			"key":    taint.Key,
+			"value":  taint.Value,
+			"effect": taint.Effect,
+		})
+	}
+	return result
+}
+
+func getNodePods(pods []corev1.Pod) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(pods))
+	for i := range pods {
+		pod := &pods[i]
+		requests := base.PodEffectiveResources(pod, func(r corev1.ResourceRequirements) corev1.ResourceList { return r.Requests })
+		result = append(result, map[string]interface{}{
+			"name":      pod.Name,
+			"namespace": pod.Namespace,
+			"phase":     pod.Status.Phase,
+			"owner":     getControllerKind(pod),
+			"cpu":       requests.Cpu().String(),
+			"memory":    requests.Memory().String(),
+		})
+	}
+	return result
+}
+
+func getControllerKind(pod *corev1.Pod) string {
+	if owner := metav1.GetControllerOf(pod); owner != nil {
+		return owner.Kind
+	}
+	return ""
+}
@@ -0,0 +1,151 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newTestNode(name string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"node-role.kubernetes.io/control-plane": "", "kubernetes.io/role": "worker"},
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+				corev1.ResourcePods:   resource.MustParse("110"),
+			},
+		},
+	}
+}
+
+func newTestPod(name, ownerKind string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "team-a",
+			UID:       types.UID("uid-" + name),
+			Labels:    map[string]string{"app": name},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+			Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				}},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	if ownerKind != "" {
+		controller := true
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: ownerKind, Name: "owner", Controller: &controller}}
+	}
+	return pod
+}
+
+func TestGetNodeSummary(t *testing.T) {
+	pods := []corev1.Pod{*newTestPod("a", "ReplicaSet"), *newTestPod("b", "ReplicaSet")}
+
+	summary := getNodeSummary(newTestNode("node-1"), pods)
+
+	if roles := summary["roles"].([]string); !reflect.DeepEqual(roles, []string{"control-plane", "worker"}) {
+		t.Errorf("roles = %v", roles)
+	}
+	resources := summary["resources"].(map[string]interface{})
+	cpu := resources["cpu"].(map[string]interface{})
+	if cpu["requested"] != "1" || cpu["requestedPercent"] != 50.0 {
+		t.Errorf("cpu = %v", cpu)
+	}
+	if podUsage := resources["pods"].(map[string]interface{}); podUsage["requested"] != "2" {
+		t.Errorf("pods = %v", podUsage)
+	}
+}
+
+func TestPlanDrain(t *testing.T) {
+	mirror := newTestPod("static", "")
+	mirror.Annotations = map[string]string{mirrorPodAnnotation: "hash"}
+	scratch := newTestPod("scratch", "ReplicaSet")
+	scratch.Spec.Volumes = []corev1.Volume{{Name: "tmp", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+
+	pods := []corev1.Pod{*newTestPod("web", "ReplicaSet"), *newTestPod("agent", "DaemonSet"), *mirror, *newTestPod("bare", ""), *scratch}
+
+	plan := planDrain(pods, DrainOptions{})
+	if len(plan.evict) != 1 || len(plan.skipped) != 2 || len(plan.blocked) != 2 {
+		t.Errorf("plan = evict %d, skipped %d, blocked %d; want 1, 2, 2", len(plan.evict), len(plan.skipped), len(plan.blocked))
+	}
+
+	plan = planDrain(pods, DrainOptions{Force: true, DeleteEmptyDirData: true})
+	if len(plan.evict) != 3 || len(plan.blocked) != 0 {
+		t.Errorf("forced plan = evict %d, blocked %d; want 3, 0", len(plan.evict), len(plan.blocked))
+	}
+}
+
+func TestDrainNode(t *testing.T) {
+	evictionRetryPeriod = time.Millisecond
+	deletionPollInterval = time.Millisecond
+
+	clientset := fake.NewSimpleClientset(newTestNode("node-1"), newTestPod("web", "ReplicaSet"), newTestPod("agent", "DaemonSet"))
+	attempts := 0
+	clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		attempts++
+		if attempts == 1 {
+			return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+		}
+		name := action.(k8stesting.CreateAction).GetObject().(metav1.Object).GetName()
+		return true, nil, clientset.Tracker().Delete(corev1.SchemeGroupVersion.WithResource("pods"), "team-a", name)
+	})
+
+	api := NewNodeAPI(clientset, log.New(io.Discard, "", 0))
+	var events []string
+	err := api.DrainNode(context.Background(), "node-1", DrainOptions{}, func(event string, data map[string]interface{}) {
+		events = append(events, event)
+	})
+	if err != nil {
+		t.Fatalf("DrainNode: %v", err)
+	}
+
+	want := []string{"cordoned", "plan", "evicting", "blocked", "evicted", "deleted", "complete"}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+
+	node, _ := clientset.CoreV1().Nodes().Get(context.Background(), "node-1", metav1.GetOptions{})
+	if !node.Spec.Unschedulable {
+		t.Error("node should be cordoned")
+	}
+	if _, err := clientset.CoreV1().Pods("team-a").Get(context.Background(), "agent", metav1.GetOptions{}); err != nil {
+		t.Errorf("DaemonSet pod should not be evicted: %v", err)
+	}
+}
+
+func TestDrainNodeBlocked(t *testing.T) {
+	clientset := fake.NewSimpleClientset(newTestNode("node-1"), newTestPod("bare", ""))
+	api := NewNodeAPI(clientset, log.New(io.Discard, "", 0))
+
+	err := api.DrainNode(context.Background(), "node-1", DrainOptions{}, func(string, map[string]interface{}) {})
+	if !errors.Is(err, ErrDrainBlocked) {
+		t.Errorf("err = %v, want ErrDrainBlocked", err)
+	}
+}
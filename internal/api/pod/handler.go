@@ -3,6 +3,7 @@ package pod
 import (
 	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	corev1 "k8s.io/api/core/v1"
@@ -103,6 +104,46 @@ func (h *Handler) GetPodMetrics(c *gin.Context) {
 	c.JSON(http.StatusOK, metrics)
 }
 
+// GetPodLogs handles GET /api/v1/pods/namespaces/:namespace/:name/logs
+func (h *Handler) GetPodLogs(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	container := c.Query("container")
+
+	var tailLines int64
+	if value := c.Query("tailLines"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "Invalid tailLines value",
+			})
+			return
+		}
+		tailLines = parsed
+	}
+	previous := c.Query("previous") == "true"
+
+	logs, err := h.api.GetPodLogs(c.Request.Context(), namespace, name, container, tailLines, previous)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"pod":       name,
+			"namespace": namespace,
+			"container": container,
+			"logs":      logs,
+		},
+	})
+}
+
 // DeletePod handles DELETE /api/v1/namespaces/:namespace/pods/:name
 func (h *Handler) DeletePod(c *gin.Context) {
 	namespace := c.Param("namespace")
@@ -84,6 +84,38 @@ func (api *PodAPI) GetPodMetrics(ctx context.Context, namespace, name string) (*
 	return &response, nil
 }
 
+// Bounds for GetPodLogs, so that a chatty container cannot exhaust the memory of the backend
+const (
+	defaultLogTailLines int64 = 1000
+	maxLogBytes         int64 = 5 << 20
+)
+
+// GetPodLogs returns the logs of a pod container. An empty container selects the only
+// container of the pod; tailLines limits the output to the last lines and defaults to
+// defaultLogTailLines. The output is capped at maxLogBytes in any case.
+func (api *PodAPI) GetPodLogs(ctx context.Context, namespace, name, container string, tailLines int64, previous bool) (string, error) {
+	api.LogInfo(ctx, "GetPodLogs", fmt.Sprintf("Fetching logs for pod %s in namespace %s", name, namespace))
+
+	if tailLines <= 0 {
+		tailLines = defaultLogTailLines
+	}
+	limitBytes := maxLogBytes
+	options := &corev1.PodLogOptions{
+		Container:  container,
+		Previous:   previous,
+		TailLines:  &tailLines,
+		LimitBytes: &limitBytes,
+	}
+
+	logs, err := api.GetClientset().CoreV1().Pods(namespace).GetLogs(name, options).DoRaw(ctx)
+	if err != nil {
+		api.LogError(ctx, "GetPodLogs", err)
+		return "", api.HandleError(err, "get pod logs")
+	}
+
+	return string(logs), nil
+}
+
 // DeletePod deletes a specific pod
 func (api *PodAPI) DeletePod(ctx context.Context, namespace, name string) error {
 	api.LogInfo(ctx, "DeletePod", fmt.Sprintf("Deleting pod %s in namespace %s", name, namespace))
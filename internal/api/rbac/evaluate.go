@@ -0,0 +1,270 @@
+package rbac
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// ScopeCluster marks grants that come from a ClusterRoleBinding and apply in every namespace
+const ScopeCluster = "cluster"
+
+// Snapshot holds the RBAC objects an offline evaluation works on
+type Snapshot struct {
+	Roles               []rbacv1.Role
+	ClusterRoles        []rbacv1.ClusterRole
+	RoleBindings        []rbacv1.RoleBinding
+	ClusterRoleBindings []rbacv1.ClusterRoleBinding
+}
+
+// AccessAttributes describes a resource request. Group is empty for the core API group.
+type AccessAttributes struct {
+	Verb        string `json:"verb" form:"verb" binding:"required"`
+	Group       string `json:"group" form:"group"`
+	Resource    string `json:"resource" form:"resource" binding:"required"`
+	Subresource string `json:"subresource" form:"subresource"`
+	Namespace   string `json:"namespace" form:"namespace"`
+	Name        string `json:"name" form:"name"`
+}
+
+// Subject identifies a user, group or service account. Groups lists additional groups a user
+// belongs to; the implicit system groups are added automatically.
+type Subject struct {
+	Kind      string   `json:"kind"`
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace,omitempty"`
+	Groups    []string `json:"groups,omitempty"`
+}
+
+// Grant is a set of rules a binding gives its subjects, in a namespace or cluster-wide
+type Grant struct {
+	Scope       string              `json:"scope"`
+	BindingKind string              `json:"bindingKind"`
+	Binding     string              `json:"binding"`
+	RoleKind    string              `json:"roleKind"`
+	Role        string              `json:"role"`
+	Rules       []rbacv1.PolicyRule `json:"rules"`
+	Missing     bool                `json:"missing,omitempty"`
+}
+
+// SubjectAccess is a subject that is allowed a request, with the grants that allow it
+type SubjectAccess struct {
+	Kind      string  `json:"kind"`
+	Name      string  `json:"name"`
+	Namespace string  `json:"namespace,omitempty"`
+	Via       []Grant `json:"via"`
+}
+
+// WhoCan returns the subjects bound to a rule that allows the request. Only RoleBindings in the
+// request namespace are considered; ClusterRoleBindings always apply.
+func (s *Snapshot) WhoCan(attrs AccessAttributes) []SubjectAccess {
+	bySubject := make(map[string]*SubjectAccess)
+	var order []string
+
+	s.forEachGrant(func(grant Grant, subjects []rbacv1.Subject) {
+		if grant.Scope != ScopeCluster && grant.Scope != attrs.Namespace {
+			return
+		}
+		matched := matchingRules(grant.Rules, attrs)
+		if len(matched) == 0 {
+			return
+		}
+		grant.Rules = matched
+
+		for _, subject := range subjects {
+			key := subject.Kind + "/" + subject.Namespace + "/" + subject.Name
+			access, ok := bySubject[key]
+			if !ok {
+				access = &SubjectAccess{Kind: subject.Kind, Name: subject.Name, Namespace: subject.Namespace}
+				bySubject[key] = access
+				order = append(order, key)
+			}
+			access.Via = append(access.Via, grant)
+		}
+	})
+
+	sort.Strings(order)
+	result := make([]SubjectAccess, 0, len(order))
+	for _, key := range order {
+		result = append(result, *bySubject[key])
+	}
+	return result
+}
+
+// SubjectGrants returns every grant that applies to the subject, directly or through its groups.
+// Grants that reference a missing role are included and flagged so dangling bindings are visible.
+func (s *Snapshot) SubjectGrants(subject Subject) []Grant {
+	groups := subjectGroups(subject)
+	result := []Grant{}
+
+	s.forEachGrant(func(grant Grant, subjects []rbacv1.Subject) {
+		for _, candidate := range subjects {
+			if subjectMatches(subject, groups, candidate) {
+				result = append(result, grant)
+				return
+			}
+		}
+	})
+
+	sort.SliceStable(result, func(i, j int) bool {
+		// Cluster-wide grants first, then by namespace
+		if (result[i].Scope == ScopeCluster) != (result[j].Scope == ScopeCluster) {
+			return result[i].Scope == ScopeCluster
+		}
+		return result[i].Scope < result[j].Scope
+	})
+	return result
+}
+
+// Allows reports whether any of the grants allows the request
+func Allows(grants []Grant, attrs AccessAttributes) bool {
+	for _, grant := range grants {
+		if (grant.Scope == ScopeCluster || grant.Scope == attrs.Namespace) && len(matchingRules(grant.Rules, attrs)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Helper functions
+
+// forEachGrant resolves every binding to the rules of the role it references
+func (s *Snapshot) forEachGrant(visit func(Grant, []rbacv1.Subject)) {
+	roles := make(map[string][]rbacv1.PolicyRule, len(s.Roles))
+	for _, role := range s.Roles {
+		roles[role.Namespace+"/"+role.Name] = role.Rules
+	}
+	clusterRoles := make(map[string][]rbacv1.PolicyRule, len(s.ClusterRoles))
+	for _, role := range s.ClusterRoles {
+		clusterRoles[role.Name] = role.Rules
+	}
+
+	resolve := func(grant Grant, namespace string) Grant {
+		var rules []rbacv1.PolicyRule
+		var ok bool
+		if grant.RoleKind == "Role" {
+			rules, ok = roles[namespace+"/"+grant.Role]
+		} else {
+			rules, ok = clusterRoles[grant.Role]
+		}
+		grant.Rules = rules
+		grant.Missing = !ok
+		return grant
+	}
+
+	for _, binding := range s.ClusterRoleBindings {
+		grant := resolve(Grant{
+			Scope:       ScopeCluster,
+			BindingKind: "ClusterRoleBinding",
+			Binding:     binding.Name,
+			RoleKind:    binding.RoleRef.Kind,
+			Role:        binding.RoleRef.Name,
+		}, "")
+		visit(grant, binding.Subjects)
+	}
+
+	for _, binding := range s.RoleBindings {
+		grant := resolve(Grant{
+			Scope:       binding.Namespace,
+			BindingKind: "RoleBinding",
+			Binding:     binding.Name,
+			RoleKind:    binding.RoleRef.Kind,
+			Role:        binding.RoleRef.Name,
+		}, binding.Namespace)
+		visit(grant, bindingSubjects(binding))
+	}
+}
+
+// bindingSubjects defaults the namespace of service account subjects to the binding's namespace
+func bindingSubjects(binding rbacv1.RoleBinding) []rbacv1.Subject {
+	subjects := make([]rbacv1.Subject, len(binding.Subjects))
+	for i, subject := range binding.Subjects {
+		if subject.Kind == rbacv1.ServiceAccountKind && subject.Namespace == "" {
+			subject.Namespace = binding.Namespace
+		}
+		subjects[i] = subject
+	}
+	return subjects
+}
+
+// subjectGroups returns the groups the subject is a member of, including the ones every authenticated identity gets
+func subjectGroups(subject Subject) map[string]bool {
+	groups := map[string]bool{"system:authenticated": true}
+	for _, group := range subject.Groups {
+		groups[group] = true
+	}
+	switch subject.Kind {
+	case rbacv1.ServiceAccountKind:
+		groups["system:serviceaccounts"] = true
+		groups["system:serviceaccounts:"+subject.Namespace] = true
+	case rbacv1.GroupKind:
+		groups[subject.Name] = true
+	}
+	return groups
+}
+
+func subjectMatches(subject Subject, groups map[string]bool, candidate rbacv1.Subject) bool {
+	switch candidate.Kind {
+	case rbacv1.GroupKind:
+		return groups[candidate.Name]
+	case rbacv1.UserKind:
+		if subject.Kind == rbacv1.ServiceAccountKind {
+			return candidate.Name == fmt.Sprintf("system:serviceaccount:%s:%s", subject.Namespace, subject.Name)
+		}
+		return subject.Kind == rbacv1.UserKind && candidate.Name == subject.Name
+	case rbacv1.ServiceAccountKind:
+		return subject.Kind == rbacv1.ServiceAccountKind && candidate.Name == subject.Name && candidate.Namespace == subject.Namespace
+	}
+	return false
+}
+
+// matchingRules returns the rules that allow the request, following the RBAC authorizer's matching
+func matchingRules(rules []rbacv1.PolicyRule, attrs AccessAttributes) []rbacv1.PolicyRule {
+	resource := attrs.Resource
+	if attrs.Subresource != "" {
+		resource += "/" + attrs.Subresource
+	}
+
+	var matched []rbacv1.PolicyRule
+	for _, rule := range rules {
+		if !matchesValue(rule.Verbs, attrs.Verb) || !matchesValue(rule.APIGroups, attrs.Group) {
+			continue
+		}
+		if !matchesResource(rule.Resources, resource, attrs.Subresource) {
+			continue
+		}
+		if len(rule.ResourceNames) > 0 && (attrs.Name == "" || !contains(rule.ResourceNames, attrs.Name)) {
+			continue
+		}
+		matched = append(matched, rule)
+	}
+	return matched
+}
+
+func matchesValue(values []string, value string) bool {
+	return contains(values, rbacv1.VerbAll) || contains(values, value)
+}
+
+func matchesResource(resources []string, resource, subresource string) bool {
+	for _, r := range resources {
+		if r == rbacv1.ResourceAll || r == resource {
+			return true
+		}
+		// "*/scale" matches the scale subresource of every resource
+		if subresource != "" && strings.HasPrefix(r, "*/") && r[2:] == subresource {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
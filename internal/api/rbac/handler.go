@@ -0,0 +1,250 @@
+package rbac
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxAccessChecks bounds the number of SelfSubjectAccessReviews a single request may create
+const maxAccessChecks = 100
+
+type Handler struct {
+	api *RBACAPI
+}
+
+func NewHandler(clientset *kubernetes.Clientset, logger *log.Logger) *Handler {
+	if logger == nil {
+		logger = log.New(gin.DefaultWriter, "[RBAC-API] ", log.LstdFlags)
+	}
+
+	return &Handler{
+		api: NewRBACAPI(clientset, logger),
+	}
+}
+
+// ListRoles handles GET /api/v1/rbac/roles/namespaces/:namespace
+func (h *Handler) ListRoles(c *gin.Context) {
+	namespace := c.Param("namespace")
+	roles, err := h.api.ListRoles(c.Request.Context(), namespace)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    roles,
+	})
+}
+
+// ListClusterRoles handles GET /api/v1/rbac/clusterroles
+func (h *Handler) ListClusterRoles(c *gin.Context) {
+	roles, err := h.api.ListClusterRoles(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    roles,
+	})
+}
+
+// ListRoleBindings handles GET /api/v1/rbac/rolebindings/namespaces/:namespace
+func (h *Handler) ListRoleBindings(c *gin.Context) {
+	namespace := c.Param("namespace")
+	bindings, err := h.api.ListRoleBindings(c.Request.Context(), namespace)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	var response []map[string]interface{}
+	for _, binding := range bindings.Items {
+		response = append(response, map[string]interface{}{
+			"name":         binding.Name,
+			"namespace":    binding.Namespace,
+			"roleRef":      binding.RoleRef,
+			"subjects":     binding.Subjects,
+			"creationTime": binding.CreationTimestamp,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    response,
+	})
+}
+
+// ListClusterRoleBindings handles GET /api/v1/rbac/clusterrolebindings
+func (h *Handler) ListClusterRoleBindings(c *gin.Context) {
+	bindings, err := h.api.ListClusterRoleBindings(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	var response []map[string]interface{}
+	for _, binding := range bindings.Items {
+		response = append(response, map[string]interface{}{
+			"name":         binding.Name,
+			"roleRef":      binding.RoleRef,
+			"subjects":     binding.Subjects,
+			"creationTime": binding.CreationTimestamp,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    response,
+	})
+}
+
+// WhoCan handles GET /api/v1/rbac/who-can?verb=&resource=&group=&subresource=&namespace=&name=
+func (h *Handler) WhoCan(c *gin.Context) {
+	var attrs AccessAttributes
+
+	if err := c.ShouldBindQuery(&attrs); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	subjects, err := h.api.WhoCan(c.Request.Context(), attrs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"request":  attrs,
+			"subjects": subjects,
+		},
+	})
+}
+
+// GetSubjectPermissions handles GET /api/v1/rbac/subjects/:kind/:name?namespace=&groups=
+// If verb and resource are given as well (with optional group, subresource, resourceName and inNamespace,
+// which defaults to the subject's namespace), the response also tells whether that request is allowed.
+func (h *Handler) GetSubjectPermissions(c *gin.Context) {
+	subject := Subject{
+		Kind:      c.Param("kind"),
+		Name:      c.Param("name"),
+		Namespace: c.Query("namespace"),
+	}
+	if groups := c.Query("groups"); groups != "" {
+		subject.Groups = strings.Split(groups, ",")
+	}
+
+	switch subject.Kind {
+	case rbacv1.UserKind, rbacv1.GroupKind:
+	case rbacv1.ServiceAccountKind:
+		if subject.Namespace == "" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "namespace is required for ServiceAccount subjects",
+			})
+			return
+		}
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "kind must be User, Group or ServiceAccount",
+		})
+		return
+	}
+
+	grants, err := h.api.GetSubjectGrants(c.Request.Context(), subject)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	data := map[string]interface{}{
+		"subject": subject,
+		"grants":  grants,
+	}
+
+	if verb, resource := c.Query("verb"), c.Query("resource"); verb != "" && resource != "" {
+		attrs := AccessAttributes{
+			Verb:        verb,
+			Group:       c.Query("group"),
+			Resource:    resource,
+			Subresource: c.Query("subresource"),
+			Namespace:   c.DefaultQuery("inNamespace", subject.Namespace),
+			Name:        c.Query("resourceName"),
+		}
+		data["request"] = attrs
+		data["allowed"] = Allows(grants, attrs)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+	})
+}
+
+// ReviewSelfAccess handles POST /api/v1/rbac/access-review
+func (h *Handler) ReviewSelfAccess(c *gin.Context) {
+	var reviewRequest struct {
+		Checks []AccessAttributes `json:"checks" binding:"required,dive"`
+	}
+
+	if err := c.ShouldBindJSON(&reviewRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	if len(reviewRequest.Checks) > maxAccessChecks {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Too many checks in one request",
+		})
+		return
+	}
+
+	results, err := h.api.ReviewSelfAccess(c.Request.Context(), reviewRequest.Checks)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    results,
+	})
+}
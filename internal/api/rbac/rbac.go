@@ -0,0 +1,253 @@
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s-glance-backend/internal/api/base"
+)
+
+// RBACAPI handles Role, ClusterRole and binding operations and access evaluation
+type RBACAPI struct {
+	*base.BaseAPI
+}
+
+// NewRBACAPI creates a new RBACAPI instance
+func NewRBACAPI(clientset kubernetes.Interface, logger *log.Logger) *RBACAPI {
+	return &RBACAPI{
+		BaseAPI: base.NewBaseAPI(clientset, logger),
+	}
+}
+
+// ListRoles returns the roles in a namespace with the bindings that reference each of them
+func (api *RBACAPI) ListRoles(ctx context.Context, namespace string) ([]map[string]interface{}, error) {
+	api.LogInfo(ctx, "ListRoles", fmt.Sprintf("Fetching roles in namespace: %s", namespace))
+
+	roles, err := api.GetClientset().RbacV1().Roles(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListRoles", err)
+		return nil, api.HandleError(err, "list roles")
+	}
+	bindings, err := api.GetClientset().RbacV1().RoleBindings(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListRoles", err)
+		return nil, api.HandleError(err, "list rolebindings")
+	}
+
+	result := make([]map[string]interface{}, 0, len(roles.Items))
+	for _, role := range roles.Items {
+		boundBy := []map[string]interface{}{}
+		for _, binding := range bindings.Items {
+			if binding.RoleRef.Kind == "Role" && binding.RoleRef.Name == role.Name {
+				boundBy = append(boundBy, bindingSummary("RoleBinding", binding.Name, binding.Namespace, binding.Subjects))
+			}
+		}
+		result = append(result, map[string]interface{}{
+			"name":         role.Name,
+			"namespace":    role.Namespace,
+			"rules":        role.Rules,
+			"boundBy":      boundBy,
+			"creationTime": role.CreationTimestamp,
+		})
+	}
+
+	return result, nil
+}
+
+// ListClusterRoles returns the cluster roles with the bindings, cluster-wide and namespaced, that reference each of them
+func (api *RBACAPI) ListClusterRoles(ctx context.Context) ([]map[string]interface{}, error) {
+	api.LogInfo(ctx, "ListClusterRoles", "Fetching clusterroles")
+
+	roles, err := api.GetClientset().RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListClusterRoles", err)
+		return nil, api.HandleError(err, "list clusterroles")
+	}
+	clusterBindings, err := api.GetClientset().RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListClusterRoles", err)
+		return nil, api.HandleError(err, "list clusterrolebindings")
+	}
+	bindings, err := api.GetClientset().RbacV1().RoleBindings("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListClusterRoles", err)
+		return nil, api.HandleError(err, "list rolebindings")
+	}
+
+	boundBy := make(map[string][]map[string]interface{})
+	for _, binding := range clusterBindings.Items {
+		boundBy[binding.RoleRef.Name] = append(boundBy[binding.RoleRef.Name], bindingSummary("ClusterRoleBinding", binding.Name, "", binding.Subjects))
+	}
+	for _, binding := range bindings.Items {
+		if binding.RoleRef.Kind == "ClusterRole" {
+			boundBy[binding.RoleRef.Name] = append(boundBy[binding.RoleRef.Name], bindingSummary("RoleBinding", binding.Name, binding.Namespace, binding.Subjects))
+		}
+	}
+
+	result := make([]map[string]interface{}, 0, len(roles.Items))
+	for _, role := range roles.Items {
+		bound := boundBy[role.Name]
+		if bound == nil {
+			bound = []map[string]interface{}{}
+		}
+		result = append(result, map[string]interface{}{
+			"name":         role.Name,
+			"rules":        role.Rules,
+			"aggregated":   role.AggregationRule != nil,
+			"boundBy":      bound,
+			"creationTime": role.CreationTimestamp,
+		})
+	}
+
+	return result, nil
+}
+
+// ListRoleBindings returns the role bindings in a namespace
+func (api *RBACAPI) ListRoleBindings(ctx context.Context, namespace string) (*rbacv1.RoleBindingList, error) {
+	api.LogInfo(ctx, "ListRoleBindings", fmt.Sprintf("Fetching rolebindings in namespace: %s", namespace))
+
+	bindings, err := api.GetClientset().RbacV1().RoleBindings(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListRoleBindings", err)
+		return nil, api.HandleError(err, "list rolebindings")
+	}
+
+	return bindings, nil
+}
+
+// ListClusterRoleBindings returns all cluster role bindings
+func (api *RBACAPI) ListClusterRoleBindings(ctx context.Context) (*rbacv1.ClusterRoleBindingList, error) {
+	api.LogInfo(ctx, "ListClusterRoleBindings", "Fetching clusterrolebindings")
+
+	bindings, err := api.GetClientset().RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListClusterRoleBindings", err)
+		return nil, api.HandleError(err, "list clusterrolebindings")
+	}
+
+	return bindings, nil
+}
+
+// WhoCan returns the subjects allowed to perform the request according to the RBAC objects.
+// Other authorizers (e.g. node or webhook) are not taken into account.
+func (api *RBACAPI) WhoCan(ctx context.Context, attrs AccessAttributes) ([]SubjectAccess, error) {
+	api.LogInfo(ctx, "WhoCan", fmt.Sprintf("Evaluating who can %s %s in namespace %q", attrs.Verb, attrs.Resource, attrs.Namespace))
+
+	snapshot, err := api.loadSnapshot(ctx, attrs.Namespace, attrs.Namespace == "")
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot.WhoCan(attrs), nil
+}
+
+// GetSubjectGrants returns everything the subject is granted across the cluster according to the RBAC objects
+func (api *RBACAPI) GetSubjectGrants(ctx context.Context, subject Subject) ([]Grant, error) {
+	api.LogInfo(ctx, "GetSubjectGrants", fmt.Sprintf("Evaluating grants for %s %s", subject.Kind, subject.Name))
+
+	snapshot, err := api.loadSnapshot(ctx, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot.SubjectGrants(subject), nil
+}
+
+// ReviewSelfAccess asks the API server whether the identity this backend runs as may perform each request
+func (api *RBACAPI) ReviewSelfAccess(ctx context.Context, checks []AccessAttributes) ([]map[string]interface{}, error) {
+	api.LogInfo(ctx, "ReviewSelfAccess", fmt.Sprintf("Reviewing %d access checks", len(checks)))
+
+	result := make([]map[string]interface{}, 0, len(checks))
+	for _, check := range checks {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace:   check.Namespace,
+					Verb:        check.Verb,
+					Group:       check.Group,
+					Resource:    check.Resource,
+					Subresource: check.Subresource,
+					Name:        check.Name,
+				},
+			},
+		}
+
+		response, err := api.GetClientset().AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+		if err != nil {
+			api.LogError(ctx, "ReviewSelfAccess", err)
+			return nil, api.HandleError(err, "create selfsubjectaccessreview")
+		}
+
+		result = append(result, map[string]interface{}{
+			"check":   check,
+			"allowed": response.Status.Allowed,
+			"denied":  response.Status.Denied,
+			"reason":  response.Status.Reason,
+		})
+	}
+
+	return result, nil
+}
+
+// LoadSnapshot fetches the RBAC objects. Roles and role bindings come from the given namespace, or from
+// every namespace when it is empty; clusterOnly skips them entirely for cluster-scoped requests.
+func LoadSnapshot(ctx context.Context, clientset kubernetes.Interface, namespace string, clusterOnly bool) (*Snapshot, error) {
+	rbac := clientset.RbacV1()
+	snapshot := &Snapshot{}
+
+	clusterRoles, err := rbac.ClusterRoles().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list clusterroles: %w", err)
+	}
+	snapshot.ClusterRoles = clusterRoles.Items
+
+	clusterBindings, err := rbac.ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list clusterrolebindings: %w", err)
+	}
+	snapshot.ClusterRoleBindings = clusterBindings.Items
+
+	if clusterOnly {
+		return snapshot, nil
+	}
+
+	roles, err := rbac.Roles(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list roles: %w", err)
+	}
+	snapshot.Roles = roles.Items
+
+	bindings, err := rbac.RoleBindings(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list rolebindings: %w", err)
+	}
+	snapshot.RoleBindings = bindings.Items
+
+	return snapshot, nil
+}
+
+// Helper functions
+
+func (api *RBACAPI) loadSnapshot(ctx context.Context, namespace string, clusterOnly bool) (*Snapshot, error) {
+	snapshot, err := LoadSnapshot(ctx, api.GetClientset(), namespace, clusterOnly)
+	if err != nil {
+		api.LogError(ctx, "loadSnapshot", err)
+		return nil, api.HandleError(err, "load rbac objects")
+	}
+	return snapshot, nil
+}
+
+func bindingSummary(kind, name, namespace string, subjects []rbacv1.Subject) map[string]interface{} {
+	return map[string]interface{}{
+		"kind":      kind,
+		"name":      name,
+		"namespace": namespace,
+		"subjects":  subjects,
+	}
+}
@@ -0,0 +1,161 @@
+package rbac
+
+import (
+	"context"
+	"io"
+	"log"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newTestSnapshot() *Snapshot {
+	return &Snapshot{
+		Roles: []rbacv1.Role{{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod-reader", Namespace: "team-a"},
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"pods", "pods/log"}, Verbs: []string{"get", "list"}},
+			},
+		}},
+		ClusterRoles: []rbacv1.ClusterRole{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-admin"},
+				Rules:      []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "scaler"},
+				Rules: []rbacv1.PolicyRule{
+					{APIGroups: []string{"apps"}, Resources: []string{"*/scale"}, Verbs: []string{"update"}, ResourceNames: []string{"web"}},
+				},
+			},
+		},
+		RoleBindings: []rbacv1.RoleBinding{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "readers", Namespace: "team-a"},
+				RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "pod-reader"},
+				Subjects: []rbacv1.Subject{
+					{Kind: rbacv1.UserKind, Name: "alice"},
+					{Kind: rbacv1.ServiceAccountKind, Name: "ci"},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "scalers", Namespace: "team-a"},
+				RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "scaler"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "oncall"}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "dangling", Namespace: "team-a"},
+				RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "deleted"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+			},
+		},
+		ClusterRoleBindings: []rbacv1.ClusterRoleBinding{{
+			ObjectMeta: metav1.ObjectMeta{Name: "admins"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:masters"}},
+		}},
+	}
+}
+
+func TestWhoCan(t *testing.T) {
+	snapshot := newTestSnapshot()
+
+	tests := []struct {
+		name  string
+		attrs AccessAttributes
+		want  []string
+	}{
+		{"list pods", AccessAttributes{Verb: "list", Resource: "pods", Namespace: "team-a"}, []string{"Group/system:masters", "ServiceAccount/ci", "User/alice"}},
+		{"pod logs", AccessAttributes{Verb: "get", Resource: "pods", Subresource: "log", Namespace: "team-a"}, []string{"Group/system:masters", "ServiceAccount/ci", "User/alice"}},
+		{"other namespace", AccessAttributes{Verb: "list", Resource: "pods", Namespace: "team-b"}, []string{"Group/system:masters"}},
+		{"delete pods", AccessAttributes{Verb: "delete", Resource: "pods", Namespace: "team-a"}, []string{"Group/system:masters"}},
+		{"scale named", AccessAttributes{Verb: "update", Group: "apps", Resource: "deployments", Subresource: "scale", Namespace: "team-a", Name: "web"}, []string{"Group/oncall", "Group/system:masters"}},
+		{"scale other name", AccessAttributes{Verb: "update", Group: "apps", Resource: "deployments", Subresource: "scale", Namespace: "team-a", Name: "api"}, []string{"Group/system:masters"}},
+	}
+
+	for _, tt := range tests {
+		subjects := snapshot.WhoCan(tt.attrs)
+		var got []string
+		for _, subject := range subjects {
+			got = append(got, subject.Kind+"/"+subject.Name)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: subjects = %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range tt.want {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: subjects = %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+
+	subjects := snapshot.WhoCan(AccessAttributes{Verb: "get", Resource: "pods", Namespace: "team-a"})
+	for _, subject := range subjects {
+		if subject.Kind == rbacv1.ServiceAccountKind && subject.Namespace != "team-a" {
+			t.Errorf("service account namespace = %q, want the binding namespace", subject.Namespace)
+		}
+	}
+}
+
+func TestSubjectGrants(t *testing.T) {
+	snapshot := newTestSnapshot()
+
+	grants := snapshot.SubjectGrants(Subject{Kind: rbacv1.UserKind, Name: "alice", Groups: []string{"oncall"}})
+	if len(grants) != 3 {
+		t.Fatalf("grants = %+v, want readers, scalers and dangling", grants)
+	}
+	missing := 0
+	for _, grant := range grants {
+		if grant.Missing {
+			missing++
+		}
+	}
+	if missing != 1 {
+		t.Errorf("missing grants = %d, want 1", missing)
+	}
+	if !Allows(grants, AccessAttributes{Verb: "list", Resource: "pods", Namespace: "team-a"}) {
+		t.Error("alice should be allowed to list pods in team-a")
+	}
+	if Allows(grants, AccessAttributes{Verb: "list", Resource: "pods", Namespace: "team-b"}) {
+		t.Error("alice should not be allowed to list pods in team-b")
+	}
+
+	// Service accounts are also matched when bound as their user name
+	snapshot.ClusterRoleBindings = append(snapshot.ClusterRoleBindings, rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "ci-admin"},
+		RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "system:serviceaccount:team-a:ci"}},
+	})
+	grants = snapshot.SubjectGrants(Subject{Kind: rbacv1.ServiceAccountKind, Name: "ci", Namespace: "team-a"})
+	if len(grants) != 2 || grants[0].Scope != ScopeCluster {
+		t.Errorf("service account grants = %+v, want ci-admin first and readers", grants)
+	}
+}
+
+func TestReviewSelfAccess(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		review.Status.Allowed = review.Spec.ResourceAttributes.Verb == "get"
+		return true, review, nil
+	})
+	api := NewRBACAPI(clientset, log.New(io.Discard, "", 0))
+
+	results, err := api.ReviewSelfAccess(context.Background(), []AccessAttributes{
+		{Verb: "get", Resource: "pods", Namespace: "team-a"},
+		{Verb: "delete", Resource: "pods", Namespace: "team-a"},
+	})
+	if err != nil {
+		t.Fatalf("ReviewSelfAccess: %v", err)
+	}
+	if results[0]["allowed"] != true || results[1]["allowed"] != false {
+		t.Errorf("results = %v", results)
+	}
+}
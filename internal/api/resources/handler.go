@@ -0,0 +1,268 @@
+package resources
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// patchTypes maps the ?type= query values to patch types
+var patchTypes = map[string]types.PatchType{
+	"json":      types.JSONPatchType,
+	"merge":     types.MergePatchType,
+	"strategic": types.StrategicMergePatchType,
+}
+
+type Handler struct {
+	api *ResourceAPI
+}
+
+func NewHandler(clientset *kubernetes.Clientset, dynamicClient dynamic.Interface, logger *log.Logger) *Handler {
+	if logger == nil {
+		logger = log.New(gin.DefaultWriter, "[RESOURCE-API] ", log.LstdFlags)
+	}
+
+	return &Handler{
+		api: NewResourceAPI(clientset, dynamicClient, logger),
+	}
+}
+
+// ListAPIResources handles GET /api/v1/resources
+func (h *Handler) ListAPIResources(c *gin.Context) {
+	resources, err := h.api.ListAPIResources(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    resources,
+	})
+}
+
+// ListResources handles GET /api/v1/resources/:group/:version/:resource[/namespaces/:namespace]
+func (h *Handler) ListResources(c *gin.Context) {
+	opts := ListOptions{
+		LabelSelector: c.Query("labelSelector"),
+		FieldSelector: c.Query("fieldSelector"),
+		Continue:      c.Query("continue"),
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.ParseInt(limitStr, 10, 64)
+		if err != nil || limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "Invalid limit value",
+			})
+			return
+		}
+		opts.Limit = limit
+	}
+
+	list, err := h.api.ListResources(c.Request.Context(), resourceRef(c), opts)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	items := make([]map[string]interface{}, 0, len(list.Items))
+	for _, item := range list.Items {
+		items = append(items, item.Object)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"data":     items,
+		"continue": list.GetContinue(),
+	})
+}
+
+// GetResource handles GET /api/v1/resources/:group/:version/:resource[/namespaces/:namespace]/:name
+func (h *Handler) GetResource(c *gin.Context) {
+	obj, err := h.api.GetResource(c.Request.Context(), resourceRef(c))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    obj.Object,
+	})
+}
+
+// CreateResource handles POST /api/v1/resources/:group/:version/:resource[/namespaces/:namespace]
+func (h *Handler) CreateResource(c *gin.Context) {
+	obj, ok := bindObject(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.api.CreateResource(c.Request.Context(), resourceRef(c), obj)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result.Object,
+	})
+}
+
+// UpdateResource handles PUT /api/v1/resources/:group/:version/:resource[/namespaces/:namespace]/:name
+func (h *Handler) UpdateResource(c *gin.Context) {
+	obj, ok := bindObject(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.api.UpdateResource(c.Request.Context(), resourceRef(c), obj)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result.Object,
+	})
+}
+
+// PatchResource handles PATCH /api/v1/resources/:group/:version/:resource[/namespaces/:namespace]/:name.
+// The patch type comes from a patch Content-Type header or ?type=json|merge|strategic and defaults to merge.
+func (h *Handler) PatchResource(c *gin.Context) {
+	patchType := types.MergePatchType
+	switch contentType := types.PatchType(c.ContentType()); contentType {
+	case types.JSONPatchType, types.MergePatchType, types.StrategicMergePatchType:
+		patchType = contentType
+	default:
+		if typeStr := c.Query("type"); typeStr != "" {
+			pt, ok := patchTypes[typeStr]
+			if !ok {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"success": false,
+					"error":   "Invalid patch type, must be one of json, merge or strategic",
+				})
+				return
+			}
+			patchType = pt
+		}
+	}
+
+	data, err := c.GetRawData()
+	if err != nil || len(data) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Patch body is required",
+		})
+		return
+	}
+
+	result, err := h.api.PatchResource(c.Request.Context(), resourceRef(c), patchType, data)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result.Object,
+	})
+}
+
+// DeleteResource handles DELETE /api/v1/resources/:group/:version/:resource[/namespaces/:namespace]/:name
+func (h *Handler) DeleteResource(c *gin.Context) {
+	err := h.api.DeleteResource(c.Request.Context(), resourceRef(c))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Resource deleted successfully",
+	})
+}
+
+// Helper functions
+
+func resourceRef(c *gin.Context) ResourceRef {
+	return ResourceRef{
+		Group:     c.Param("group"),
+		Version:   c.Param("version"),
+		Resource:  c.Param("resource"),
+		Namespace: c.Param("namespace"),
+		Name:      c.Param("name"),
+	}
+}
+
+func bindObject(c *gin.Context) (*unstructured.Unstructured, bool) {
+	// Decoding through Unstructured keeps integers as int64, which the dynamic client requires
+	obj := &unstructured.Unstructured{}
+	data, err := c.GetRawData()
+	if err == nil {
+		err = obj.UnmarshalJSON(data)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request format: " + err.Error(),
+		})
+		return nil, false
+	}
+	return obj, true
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrResourceNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrVerbNotSupported):
+		return http.StatusMethodNotAllowed
+	case errors.Is(err, ErrScopeMismatch), errors.Is(err, ErrInvalidObject):
+		return http.StatusBadRequest
+	// Errors returned by the API server for the object itself
+	case apierrors.IsNotFound(err):
+		return http.StatusNotFound
+	case apierrors.IsAlreadyExists(err), apierrors.IsConflict(err):
+		return http.StatusConflict
+	case apierrors.IsInvalid(err):
+		return http.StatusUnprocessableEntity
+	case apierrors.IsBadRequest(err):
+		return http.StatusBadRequest
+	case apierrors.IsForbidden(err):
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
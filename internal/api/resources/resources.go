@@ -0,0 +1,402 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s-glance-backend/internal/api/base"
+)
+
+// CoreGroup is the path segment used for the legacy core API group, whose real name is empty
+const CoreGroup = "core"
+
+var (
+	// ErrResourceNotFound is returned when discovery does not know the requested resource
+	ErrResourceNotFound = errors.New("resource not found")
+	// ErrVerbNotSupported is returned when the resource does not support the requested operation
+	ErrVerbNotSupported = errors.New("operation not supported by resource")
+	// ErrScopeMismatch is returned when a namespace is given for a cluster-scoped resource or vice versa
+	ErrScopeMismatch = errors.New("resource scope mismatch")
+	// ErrInvalidObject is returned when a request body does not describe the addressed object
+	ErrInvalidObject = errors.New("invalid object")
+)
+
+var (
+	// secretsGVR is redacted like the secret package does, so the browser never returns secret values
+	secretsGVR = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+	// namespacesGVR may only be changed through the namespace API, which enforces the deletion safeguards
+	namespacesGVR = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+)
+
+// ResourceAPI handles operations on arbitrary API resources through discovery and the dynamic client
+type ResourceAPI struct {
+	*base.BaseAPI
+	dynamicClient dynamic.Interface
+	discovery     discovery.CachedDiscoveryInterface
+}
+
+// NewResourceAPI creates a new ResourceAPI instance
+func NewResourceAPI(clientset kubernetes.Interface, dynamicClient dynamic.Interface, logger *log.Logger) *ResourceAPI {
+	return &ResourceAPI{
+		BaseAPI:       base.NewBaseAPI(clientset, logger),
+		dynamicClient: dynamicClient,
+		discovery:     memory.NewMemCacheClient(clientset.Discovery()),
+	}
+}
+
+// ResourceRef addresses a resource type and optionally a namespace and object name.
+// Group uses CoreGroup for the core API group.
+type ResourceRef struct {
+	Group     string
+	Version   string
+	Resource  string
+	Namespace string
+	Name      string
+}
+
+func (r ResourceRef) String() string {
+	path := r.Group + "/" + r.Version + "/" + r.Resource
+	if r.Namespace != "" {
+		path += " in namespace " + r.Namespace
+	}
+	if r.Name != "" {
+		path = r.Name + " (" + path + ")"
+	}
+	return path
+}
+
+// GroupVersionResource converts the reference to the form used by the dynamic client
+func (r ResourceRef) GroupVersionResource() schema.GroupVersionResource {
+	group := r.Group
+	if group == CoreGroup {
+		group = ""
+	}
+	return schema.GroupVersionResource{Group: group, Version: r.Version, Resource: r.Resource}
+}
+
+// ListOptions are the query options supported when listing resources
+type ListOptions struct {
+	LabelSelector string
+	FieldSelector string
+	Limit         int64
+	Continue      string
+}
+
+// ListAPIResources returns every resource type the cluster serves, grouped by kind, for building menus.
+// Groups that fail discovery (e.g. an unavailable aggregated API) are reported instead of failing the call.
+func (api *ResourceAPI) ListAPIResources(ctx context.Context) (map[string]interface{}, error) {
+	api.LogInfo(ctx, "ListAPIResources", "Discovering API resources")
+
+	groups, lists, err := api.GetClientset().Discovery().ServerGroupsAndResources()
+	failed := []map[string]string{}
+	if err != nil {
+		var groupErr *discovery.ErrGroupDiscoveryFailed
+		if !errors.As(err, &groupErr) {
+			api.LogError(ctx, "ListAPIResources", err)
+			return nil, api.HandleError(err, "discover api resources")
+		}
+		for gv, cause := range groupErr.Groups {
+			failed = append(failed, map[string]string{"groupVersion": gv.String(), "error": cause.Error()})
+		}
+		sort.Slice(failed, func(i, j int) bool { return failed[i]["groupVersion"] < failed[j]["groupVersion"] })
+	}
+
+	preferred := make(map[string]bool)
+	for _, group := range groups {
+		preferred[group.PreferredVersion.GroupVersion] = true
+	}
+
+	resources := []map[string]interface{}{}
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		group := gv.Group
+		if group == "" {
+			group = CoreGroup
+		}
+		for _, resource := range list.APIResources {
+			// Subresources such as pods/log are reached through their parent
+			if strings.Contains(resource.Name, "/") {
+				continue
+			}
+			resources = append(resources, map[string]interface{}{
+				"group":      group,
+				"version":    gv.Version,
+				"resource":   resource.Name,
+				"kind":       resource.Kind,
+				"namespaced": resource.Namespaced,
+				"verbs":      []string(resource.Verbs),
+				"shortNames": resource.ShortNames,
+				"categories": resource.Categories,
+				"preferred":  preferred[list.GroupVersion],
+			})
+		}
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		a, b := resources[i], resources[j]
+		if a["group"] != b["group"] {
+			return a["group"].(string) < b["group"].(string)
+		}
+		if a["resource"] != b["resource"] {
+			return a["resource"].(string) < b["resource"].(string)
+		}
+		return a["version"].(string) < b["version"].(string)
+	})
+
+	return map[string]interface{}{
+		"resources":    resources,
+		"failedGroups": failed,
+	}, nil
+}
+
+// ListResources returns the objects of a resource type. Namespaced resources are listed across
+// all namespaces when no namespace is given.
+func (api *ResourceAPI) ListResources(ctx context.Context, ref ResourceRef, opts ListOptions) (*unstructured.UnstructuredList, error) {
+	api.LogInfo(ctx, "ListResources", fmt.Sprintf("Listing %s", ref))
+
+	client, err := api.resourceClient(ctx, ref, "list", true)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.List(ctx, metav1.ListOptions{
+		LabelSelector: opts.LabelSelector,
+		FieldSelector: opts.FieldSelector,
+		Limit:         opts.Limit,
+		Continue:      opts.Continue,
+	})
+	if err != nil {
+		api.LogError(ctx, "ListResources", err)
+		return nil, api.HandleError(err, "list "+ref.Resource)
+	}
+
+	for i := range list.Items {
+		redactObject(ref, &list.Items[i])
+	}
+	return list, nil
+}
+
+// GetResource returns a single object
+func (api *ResourceAPI) GetResource(ctx context.Context, ref ResourceRef) (*unstructured.Unstructured, error) {
+	api.LogInfo(ctx, "GetResource", fmt.Sprintf("Fetching %s", ref))
+
+	client, err := api.resourceClient(ctx, ref, "get", false)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := client.Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetResource", err)
+		return nil, api.HandleError(err, "get "+ref.Resource)
+	}
+
+	redactObject(ref, obj)
+	return obj, nil
+}
+
+// CreateResource creates an object. The namespace from the reference wins over an empty one in the body.
+func (api *ResourceAPI) CreateResource(ctx context.Context, ref ResourceRef, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	api.LogInfo(ctx, "CreateResource", fmt.Sprintf("Creating %s %s", ref, obj.GetName()))
+
+	client, err := api.resourceClient(ctx, ref, "create", false)
+	if err != nil {
+		return nil, err
+	}
+	if err := prepareObject(ref, obj); err != nil {
+		return nil, err
+	}
+
+	result, err := client.Create(ctx, obj, metav1.CreateOptions{})
+	if err != nil {
+		api.LogError(ctx, "CreateResource", err)
+		return nil, api.HandleError(err, "create "+ref.Resource)
+	}
+
+	redactObject(ref, result)
+	return result, nil
+}
+
+// UpdateResource replaces an object. The body must carry the current resourceVersion, as with kubectl replace.
+func (api *ResourceAPI) UpdateResource(ctx context.Context, ref ResourceRef, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	api.LogInfo(ctx, "UpdateResource", fmt.Sprintf("Updating %s", ref))
+
+	client, err := api.resourceClient(ctx, ref, "update", false)
+	if err != nil {
+		return nil, err
+	}
+	if obj.GetName() != "" && obj.GetName() != ref.Name {
+		return nil, fmt.Errorf("%w: body name %q does not match %q", ErrInvalidObject, obj.GetName(), ref.Name)
+	}
+	obj.SetName(ref.Name)
+	if err := prepareObject(ref, obj); err != nil {
+		return nil, err
+	}
+
+	result, err := client.Update(ctx, obj, metav1.UpdateOptions{})
+	if err != nil {
+		api.LogError(ctx, "UpdateResource", err)
+		return nil, api.HandleError(err, "update "+ref.Resource)
+	}
+
+	redactObject(ref, result)
+	return result, nil
+}
+
+// PatchResource applies a JSON, merge or strategic merge patch to an object
+func (api *ResourceAPI) PatchResource(ctx context.Context, ref ResourceRef, patchType types.PatchType, data []byte) (*unstructured.Unstructured, error) {
+	api.LogInfo(ctx, "PatchResource", fmt.Sprintf("Patching %s with %s", ref, patchType))
+
+	client, err := api.resourceClient(ctx, ref, "patch", false)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.Patch(ctx, ref.Name, patchType, data, metav1.PatchOptions{})
+	if err != nil {
+		api.LogError(ctx, "PatchResource", err)
+		return nil, api.HandleError(err, "patch "+ref.Resource)
+	}
+
+	redactObject(ref, result)
+	return result, nil
+}
+
+// DeleteResource deletes an object
+func (api *ResourceAPI) DeleteResource(ctx context.Context, ref ResourceRef) error {
+	api.LogInfo(ctx, "DeleteResource", fmt.Sprintf("Deleting %s", ref))
+
+	client, err := api.resourceClient(ctx, ref, "delete", false)
+	if err != nil {
+		return err
+	}
+
+	propagation := metav1.DeletePropagationBackground
+	err = client.Delete(ctx, ref.Name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if err != nil {
+		api.LogError(ctx, "DeleteResource", err)
+		return api.HandleError(err, "delete "+ref.Resource)
+	}
+
+	return nil
+}
+
+// Helper functions
+
+// resourceClient resolves the reference through discovery, checks the verb and scope, and returns
+// a dynamic client for it. allNamespaces permits a namespaced resource without a namespace.
+func (api *ResourceAPI) resourceClient(ctx context.Context, ref ResourceRef, verb string, allNamespaces bool) (dynamic.ResourceInterface, error) {
+	resource, err := api.discoverResource(ref)
+	if err != nil {
+		api.LogError(ctx, "resourceClient", err)
+		return nil, err
+	}
+
+	if !containsVerb(resource.Verbs, verb) {
+		return nil, fmt.Errorf("%w: %s does not support %s", ErrVerbNotSupported, ref.Resource, verb)
+	}
+	if ref.GroupVersionResource() == namespacesGVR && verb != "get" && verb != "list" && verb != "create" {
+		return nil, fmt.Errorf("%w: %s namespaces through /api/v1/namespaces", ErrVerbNotSupported, verb)
+	}
+
+	gvr := ref.GroupVersionResource()
+	if !resource.Namespaced {
+		if ref.Namespace != "" {
+			return nil, fmt.Errorf("%w: %s is cluster-scoped", ErrScopeMismatch, ref.Resource)
+		}
+		return api.dynamicClient.Resource(gvr), nil
+	}
+
+	if ref.Namespace == "" {
+		if !allNamespaces {
+			return nil, fmt.Errorf("%w: %s is namespaced", ErrScopeMismatch, ref.Resource)
+		}
+		return api.dynamicClient.Resource(gvr), nil
+	}
+	return api.dynamicClient.Resource(gvr).Namespace(ref.Namespace), nil
+}
+
+// discoverResource looks the resource up in the cached discovery information. On a miss the cache
+// is refreshed once, so resources of CRDs installed since the last refresh are found.
+func (api *ResourceAPI) discoverResource(ref ResourceRef) (*metav1.APIResource, error) {
+	resource, err := api.lookupResource(ref)
+	if err != nil {
+		api.discovery.Invalidate()
+		resource, err = api.lookupResource(ref)
+	}
+	return resource, err
+}
+
+func (api *ResourceAPI) lookupResource(ref ResourceRef) (*metav1.APIResource, error) {
+	gv := ref.GroupVersionResource().GroupVersion()
+
+	list, err := api.discovery.ServerResourcesForGroupVersion(gv.String())
+	if err != nil {
+		if apierrors.IsNotFound(err) || errors.Is(err, memory.ErrCacheNotFound) {
+			return nil, fmt.Errorf("%w: group version %s is not served", ErrResourceNotFound, gv)
+		}
+		return nil, api.HandleError(err, "discover "+gv.String())
+	}
+
+	for i := range list.APIResources {
+		if list.APIResources[i].Name == ref.Resource {
+			return &list.APIResources[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s is not served by %s", ErrResourceNotFound, ref.Resource, gv)
+}
+
+// redactObject removes the values of Secrets, which the secret API never returns either
+func redactObject(ref ResourceRef, obj *unstructured.Unstructured) {
+	if ref.GroupVersionResource() != secretsGVR {
+		return
+	}
+	unstructured.RemoveNestedField(obj.Object, "data")
+	unstructured.RemoveNestedField(obj.Object, "stringData")
+}
+
+// prepareObject fills in the apiVersion and namespace from the reference and rejects bodies that contradict it
+func prepareObject(ref ResourceRef, obj *unstructured.Unstructured) error {
+	gv := ref.GroupVersionResource().GroupVersion()
+	if obj.GetAPIVersion() == "" {
+		obj.SetAPIVersion(gv.String())
+	} else if obj.GetAPIVersion() != gv.String() {
+		return fmt.Errorf("%w: apiVersion %q does not match %q", ErrInvalidObject, obj.GetAPIVersion(), gv.String())
+	}
+	if obj.GetKind() == "" {
+		return fmt.Errorf("%w: kind is required", ErrInvalidObject)
+	}
+
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(ref.Namespace)
+	} else if obj.GetNamespace() != ref.Namespace {
+		return fmt.Errorf("%w: namespace %q does not match %q", ErrInvalidObject, obj.GetNamespace(), ref.Namespace)
+	}
+	return nil
+}
+
+func containsVerb(verbs metav1.Verbs, verb string) bool {
+	for _, v := range verbs {
+		if v == verb {
+			return true
+		}
+	}
+	return false
+}
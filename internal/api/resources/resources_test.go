@@ -0,0 +1,273 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var (
+	certificateGVR = schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+	issuerGVR      = schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "clusterissuers"}
+)
+
+func newTestObject(kind, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("cert-manager.io/v1")
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func newTestAPI(objects ...runtime.Object) *ResourceAPI {
+	clientset := fake.NewSimpleClientset()
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+				{Name: "pods/log", Kind: "Pod", Namespaced: true, Verbs: metav1.Verbs{"get"}},
+			},
+		},
+		{
+			GroupVersion: "cert-manager.io/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "certificates", Kind: "Certificate", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "create", "update", "patch", "delete"}},
+				{Name: "clusterissuers", Kind: "ClusterIssuer", Verbs: metav1.Verbs{"get", "list"}},
+			},
+		},
+	}
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		certificateGVR: "CertificateList",
+		issuerGVR:      "ClusterIssuerList",
+		secretsGVR:     "SecretList",
+	}, objects...)
+
+	return NewResourceAPI(clientset, dynamicClient, log.New(io.Discard, "", 0))
+}
+
+func TestListAPIResources(t *testing.T) {
+	api := newTestAPI()
+
+	result, err := api.ListAPIResources(context.Background())
+	if err != nil {
+		t.Fatalf("ListAPIResources: %v", err)
+	}
+
+	resources := result["resources"].([]map[string]interface{})
+	if len(resources) != 3 {
+		t.Fatalf("resources = %v, want 3 without subresources", resources)
+	}
+	if resources[0]["resource"] != "certificates" || resources[2]["group"] != CoreGroup {
+		t.Errorf("resources should be sorted by group then resource with the core group named %q: %v", CoreGroup, resources)
+	}
+	if resources[1]["namespaced"] != false {
+		t.Error("clusterissuers should be cluster-scoped")
+	}
+}
+
+func TestResourceLifecycle(t *testing.T) {
+	api := newTestAPI(
+		newTestObject("Certificate", "team-a", "web-tls"),
+		newTestObject("Certificate", "team-b", "api-tls"),
+		newTestObject("ClusterIssuer", "", "letsencrypt"),
+	)
+	ctx := context.Background()
+	certificates := ResourceRef{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+
+	all, err := api.ListResources(ctx, certificates, ListOptions{})
+	if err != nil {
+		t.Fatalf("ListResources across namespaces: %v", err)
+	}
+	if len(all.Items) != 2 {
+		t.Errorf("certificates = %d, want 2", len(all.Items))
+	}
+
+	ref := certificates
+	ref.Namespace = "team-a"
+	created, err := api.CreateResource(ctx, ref, &unstructured.Unstructured{Object: map[string]interface{}{
+		"kind":     "Certificate",
+		"metadata": map[string]interface{}{"name": "docs-tls"},
+		"spec":     map[string]interface{}{"secretName": "docs-tls"},
+	}})
+	if err != nil {
+		t.Fatalf("CreateResource: %v", err)
+	}
+	if created.GetNamespace() != "team-a" || created.GetAPIVersion() != "cert-manager.io/v1" {
+		t.Errorf("created namespace/apiVersion = %s/%s", created.GetNamespace(), created.GetAPIVersion())
+	}
+
+	ref.Name = "docs-tls"
+	patched, err := api.PatchResource(ctx, ref, types.MergePatchType, []byte(`{"spec":{"secretName":"docs-cert"}}`))
+	if err != nil {
+		t.Fatalf("PatchResource: %v", err)
+	}
+	if name, _, _ := unstructured.NestedString(patched.Object, "spec", "secretName"); name != "docs-cert" {
+		t.Errorf("secretName = %q, want docs-cert", name)
+	}
+
+	if err := api.DeleteResource(ctx, ref); err != nil {
+		t.Fatalf("DeleteResource: %v", err)
+	}
+	if _, err := api.GetResource(ctx, ref); err == nil {
+		t.Error("deleted certificate should not be found")
+	}
+
+	issuer, err := api.GetResource(ctx, ResourceRef{Group: "cert-manager.io", Version: "v1", Resource: "clusterissuers", Name: "letsencrypt"})
+	if err != nil || issuer.GetName() != "letsencrypt" {
+		t.Errorf("GetResource clusterissuer: %v, %v", issuer, err)
+	}
+}
+
+func TestResourceErrors(t *testing.T) {
+	api := newTestAPI()
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		ref  ResourceRef
+		want error
+	}{
+		{"unknown group", ResourceRef{Group: "argoproj.io", Version: "v1alpha1", Resource: "workflows", Namespace: "ci", Name: "build"}, ErrResourceNotFound},
+		{"unknown resource", ResourceRef{Group: CoreGroup, Version: "v1", Resource: "widgets", Namespace: "ci", Name: "build"}, ErrResourceNotFound},
+		{"namespace on cluster-scoped", ResourceRef{Group: "cert-manager.io", Version: "v1", Resource: "clusterissuers", Namespace: "ci", Name: "letsencrypt"}, ErrScopeMismatch},
+		{"namespaced without namespace", ResourceRef{Group: "cert-manager.io", Version: "v1", Resource: "certificates", Name: "web-tls"}, ErrScopeMismatch},
+		{"unsupported verb", ResourceRef{Group: CoreGroup, Version: "v1", Resource: "pods", Namespace: "ci", Name: "build"}, ErrVerbNotSupported},
+	}
+	for _, tt := range tests {
+		var err error
+		if tt.want == ErrVerbNotSupported {
+			err = api.DeleteResource(ctx, tt.ref)
+		} else {
+			_, err = api.GetResource(ctx, tt.ref)
+		}
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	ref := ResourceRef{Group: "cert-manager.io", Version: "v1", Resource: "certificates", Namespace: "team-a"}
+	if _, err := api.CreateResource(ctx, ref, newTestObject("Certificate", "team-b", "web-tls")); !errors.Is(err, ErrInvalidObject) {
+		t.Errorf("create in other namespace: err = %v, want ErrInvalidObject", err)
+	}
+}
+
+func TestNamespaceMutationsRejected(t *testing.T) {
+	api := newTestAPI()
+	ctx := context.Background()
+	fakeDiscovery := api.GetClientset().Discovery().(*fakediscovery.FakeDiscovery)
+	fakeDiscovery.Resources[0].APIResources = append(fakeDiscovery.Resources[0].APIResources, metav1.APIResource{
+		Name: "namespaces", Kind: "Namespace", Verbs: metav1.Verbs{"get", "list", "create", "update", "patch", "delete"},
+	})
+	ref := ResourceRef{Group: CoreGroup, Version: "v1", Resource: "namespaces", Name: "kube-system"}
+
+	if err := api.DeleteResource(ctx, ref); !errors.Is(err, ErrVerbNotSupported) {
+		t.Errorf("delete namespace: err = %v, want ErrVerbNotSupported", err)
+	}
+	if _, err := api.PatchResource(ctx, ref, types.MergePatchType, []byte(`{"spec":{"finalizers":[]}}`)); !errors.Is(err, ErrVerbNotSupported) {
+		t.Errorf("patch namespace: err = %v, want ErrVerbNotSupported", err)
+	}
+	if _, err := api.UpdateResource(ctx, ref, &unstructured.Unstructured{Object: map[string]interface{}{"kind": "Namespace"}}); !errors.Is(err, ErrVerbNotSupported) {
+		t.Errorf("update namespace: err = %v, want ErrVerbNotSupported", err)
+	}
+}
+
+func TestSecretValuesRedacted(t *testing.T) {
+	secret := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]interface{}{"name": "db-credentials", "namespace": "team-a"},
+		"type":       "Opaque",
+		"data":       map[string]interface{}{"password": "c2VjcmV0"},
+	}}
+	api := newTestAPI(secret)
+	ctx := context.Background()
+	fakeDiscovery := api.GetClientset().Discovery().(*fakediscovery.FakeDiscovery)
+	fakeDiscovery.Resources[0].APIResources = append(fakeDiscovery.Resources[0].APIResources, metav1.APIResource{
+		Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: metav1.Verbs{"get", "list"},
+	})
+	ref := ResourceRef{Group: CoreGroup, Version: "v1", Resource: "secrets", Namespace: "team-a"}
+
+	list, err := api.ListResources(ctx, ref, ListOptions{})
+	if err != nil {
+		t.Fatalf("ListResources: %v", err)
+	}
+	if len(list.Items) != 1 {
+		t.Fatalf("secrets = %d, want 1", len(list.Items))
+	}
+	if _, ok := list.Items[0].Object["data"]; ok {
+		t.Errorf("listed secret has data: %v", list.Items[0].Object)
+	}
+
+	ref.Name = "db-credentials"
+	obj, err := api.GetResource(ctx, ref)
+	if err != nil {
+		t.Fatalf("GetResource: %v", err)
+	}
+	if _, ok := obj.Object["data"]; ok {
+		t.Errorf("fetched secret has data: %v", obj.Object)
+	}
+	if obj.Object["type"] != "Opaque" {
+		t.Errorf("type = %v, want the rest of the secret to be kept", obj.Object["type"])
+	}
+}
+
+func TestResourceAPIErrorStatus(t *testing.T) {
+	api := newTestAPI(newTestObject("Certificate", "team-a", "web-tls"))
+	ctx := context.Background()
+	ref := ResourceRef{Group: "cert-manager.io", Version: "v1", Resource: "certificates", Namespace: "team-a", Name: "missing"}
+
+	_, err := api.GetResource(ctx, ref)
+	if status := errorStatus(err); status != http.StatusNotFound {
+		t.Errorf("get missing object: status = %d, want %d (%v)", status, http.StatusNotFound, err)
+	}
+
+	ref.Name = ""
+	_, err = api.CreateResource(ctx, ref, newTestObject("Certificate", "team-a", "web-tls"))
+	if status := errorStatus(err); status != http.StatusConflict {
+		t.Errorf("create existing object: status = %d, want %d (%v)", status, http.StatusConflict, err)
+	}
+}
+
+func TestDiscoveryRefreshesOnMiss(t *testing.T) {
+	api := newTestAPI()
+	ctx := context.Background()
+	ref := ResourceRef{Group: "argoproj.io", Version: "v1alpha1", Resource: "workflows", Namespace: "ci"}
+
+	if _, err := api.ListResources(ctx, ref, ListOptions{}); !errors.Is(err, ErrResourceNotFound) {
+		t.Fatalf("before install: err = %v, want ErrResourceNotFound", err)
+	}
+
+	// The CRD is installed after the cache was filled
+	fakeDiscovery := api.GetClientset().Discovery().(*fakediscovery.FakeDiscovery)
+	fakeDiscovery.Resources = append(fakeDiscovery.Resources, &metav1.APIResourceList{
+		GroupVersion: "argoproj.io/v1alpha1",
+		APIResources: []metav1.APIResource{{Name: "workflows", Kind: "Workflow", Namespaced: true, Verbs: metav1.Verbs{"get"}}},
+	})
+	if _, err := api.discoverResource(ref); err != nil {
+		t.Errorf("after install: err = %v", err)
+	}
+
+	// Known resources are served from the cache without another discovery round trip
+	fakeDiscovery.ClearActions()
+	if _, err := api.discoverResource(ref); err != nil {
+		t.Fatalf("cached lookup: %v", err)
+	}
+	if actions := fakeDiscovery.Actions(); len(actions) != 0 {
+		t.Errorf("cached lookup made discovery requests: %v", actions)
+	}
+}
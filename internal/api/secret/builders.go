@@ -0,0 +1,289 @@
+package secret
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DefaultRegistryServer is used when a docker-registry secret does not name a server
+const DefaultRegistryServer = "https://index.docker.io/v1/"
+
+// DockerRegistryAuth holds the credentials for a kubernetes.io/dockerconfigjson Secret
+type DockerRegistryAuth struct {
+	Server   string `json:"server"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Email    string `json:"email"`
+}
+
+// TLSKeyPair holds a PEM encoded certificate chain and private key for a kubernetes.io/tls Secret
+type TLSKeyPair struct {
+	Cert string `json:"cert"`
+	Key  string `json:"key"`
+}
+
+// BasicAuth holds the credentials for a kubernetes.io/basic-auth Secret
+type BasicAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// SSHAuth holds the private key for a kubernetes.io/ssh-auth Secret
+type SSHAuth struct {
+	PrivateKey string `json:"privateKey"`
+}
+
+// dockerConfigJSON mirrors the .dockerconfigjson format understood by the kubelet
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+type dockerConfigEntry struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Auth     string `json:"auth,omitempty"`
+}
+
+// BuildDockerRegistryData generates the data of a kubernetes.io/dockerconfigjson Secret
+func BuildDockerRegistryData(auth *DockerRegistryAuth) (map[string]string, error) {
+	if auth.Username == "" {
+		return nil, fmt.Errorf("dockerRegistry.username is required")
+	}
+	if auth.Password == "" {
+		return nil, fmt.Errorf("dockerRegistry.password is required")
+	}
+
+	server := auth.Server
+	if server == "" {
+		server = DefaultRegistryServer
+	}
+
+	config := dockerConfigJSON{
+		Auths: map[string]dockerConfigEntry{
+			server: {
+				Username: auth.Username,
+				Password: auth.Password,
+				Email:    auth.Email,
+				Auth:     base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + auth.Password)),
+			},
+		},
+	}
+
+	content, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode docker config: %v", err)
+	}
+
+	return map[string]string{
+		corev1.DockerConfigJsonKey: string(content),
+	}, nil
+}
+
+// BuildTLSData generates the data of a kubernetes.io/tls Secret
+func BuildTLSData(pair *TLSKeyPair) (map[string]string, error) {
+	data := map[string]string{
+		corev1.TLSCertKey:       pair.Cert,
+		corev1.TLSPrivateKeyKey: pair.Key,
+	}
+	if err := validateTLSData(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// BuildBasicAuthData generates the data of a kubernetes.io/basic-auth Secret
+func BuildBasicAuthData(auth *BasicAuth) (map[string]string, error) {
+	data := make(map[string]string)
+	if auth.Username != "" {
+		data[corev1.BasicAuthUsernameKey] = auth.Username
+	}
+	if auth.Password != "" {
+		data[corev1.BasicAuthPasswordKey] = auth.Password
+	}
+	if err := validateBasicAuthData(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// BuildSSHAuthData generates the data of a kubernetes.io/ssh-auth Secret
+func BuildSSHAuthData(auth *SSHAuth) (map[string]string, error) {
+	data := map[string]string{
+		corev1.SSHAuthPrivateKey: auth.PrivateKey,
+	}
+	if err := validateSSHAuthData(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ValidateSecretData checks that the string data of a Secret satisfies the
+// requirements of its type, so broken typed Secrets are rejected before they
+// reach the API server
+func ValidateSecretData(secretType corev1.SecretType, data map[string]string) error {
+	switch secretType {
+	case corev1.SecretTypeDockerConfigJson:
+		return validateDockerConfigData(data)
+	case corev1.SecretTypeTLS:
+		return validateTLSData(data)
+	case corev1.SecretTypeBasicAuth:
+		return validateBasicAuthData(data)
+	case corev1.SecretTypeSSHAuth:
+		return validateSSHAuthData(data)
+	}
+	return nil
+}
+
+func validateDockerConfigData(data map[string]string) error {
+	content, ok := data[corev1.DockerConfigJsonKey]
+	if !ok {
+		return fmt.Errorf("%s is required for type %s", corev1.DockerConfigJsonKey, corev1.SecretTypeDockerConfigJson)
+	}
+
+	var config dockerConfigJSON
+	if err := json.Unmarshal([]byte(content), &config); err != nil {
+		return fmt.Errorf("%s is not valid JSON: %v", corev1.DockerConfigJsonKey, err)
+	}
+	if len(config.Auths) == 0 {
+		return fmt.Errorf("%s must contain at least one entry in auths", corev1.DockerConfigJsonKey)
+	}
+	return nil
+}
+
+func validateTLSData(data map[string]string) error {
+	certPEM := data[corev1.TLSCertKey]
+	keyPEM := data[corev1.TLSPrivateKeyKey]
+	if certPEM == "" {
+		return fmt.Errorf("%s is required for type %s", corev1.TLSCertKey, corev1.SecretTypeTLS)
+	}
+	if keyPEM == "" {
+		return fmt.Errorf("%s is required for type %s", corev1.TLSPrivateKeyKey, corev1.SecretTypeTLS)
+	}
+
+	chain, err := parseCertificateChain([]byte(certPEM))
+	if err != nil {
+		return err
+	}
+	if err := verifyCertificateChain(chain); err != nil {
+		return err
+	}
+
+	if _, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM)); err != nil {
+		return fmt.Errorf("certificate and private key do not match: %v", err)
+	}
+	return nil
+}
+
+func validateBasicAuthData(data map[string]string) error {
+	if data[corev1.BasicAuthUsernameKey] == "" && data[corev1.BasicAuthPasswordKey] == "" {
+		return fmt.Errorf("%s or %s is required for type %s",
+			corev1.BasicAuthUsernameKey, corev1.BasicAuthPasswordKey, corev1.SecretTypeBasicAuth)
+	}
+	return nil
+}
+
+func validateSSHAuthData(data map[string]string) error {
+	privateKey, ok := data[corev1.SSHAuthPrivateKey]
+	if !ok || privateKey == "" {
+		return fmt.Errorf("%s is required for type %s", corev1.SSHAuthPrivateKey, corev1.SecretTypeSSHAuth)
+	}
+
+	block, _ := pem.Decode([]byte(privateKey))
+	if block == nil || !strings.HasSuffix(block.Type, "PRIVATE KEY") {
+		return fmt.Errorf("%s is not a PEM encoded private key", corev1.SSHAuthPrivateKey)
+	}
+	return nil
+}
+
+// parseCertificateChain decodes all CERTIFICATE blocks of a PEM bundle, leaf first
+func parseCertificateChain(certPEM []byte) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+	rest := certPEM
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate %d in chain: %v", len(chain)+1, err)
+		}
+		chain = append(chain, cert)
+	}
+
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("%s does not contain a PEM encoded certificate", corev1.TLSCertKey)
+	}
+	return chain, nil
+}
+
+// verifyCertificateChain checks that every certificate is signed by the next one in the chain
+func verifyCertificateChain(chain []*x509.Certificate) error {
+	for i := 0; i < len(chain)-1; i++ {
+		if err := chain[i].CheckSignatureFrom(chain[i+1]); err != nil {
+			return fmt.Errorf("certificate %q is not signed by %q: %v",
+				chain[i].Subject.String(), chain[i+1].Subject.String(), err)
+		}
+	}
+	return nil
+}
+
+// TypedSecretRequest selects a typed creation mode for a Secret. At most one mode may be set.
+type TypedSecretRequest struct {
+	DockerRegistry *DockerRegistryAuth `json:"dockerRegistry"`
+	TLS            *TLSKeyPair         `json:"tls"`
+	BasicAuth      *BasicAuth          `json:"basicAuth"`
+	SSHAuth        *SSHAuth            `json:"sshAuth"`
+}
+
+// Build returns the Secret type and generated data of the selected mode.
+// An empty type is returned when no typed mode is set.
+func (r *TypedSecretRequest) Build() (corev1.SecretType, map[string]string, error) {
+	var (
+		secretType corev1.SecretType
+		data       map[string]string
+		err        error
+		modes      int
+	)
+
+	if r.DockerRegistry != nil {
+		modes++
+		secretType = corev1.SecretTypeDockerConfigJson
+		data, err = BuildDockerRegistryData(r.DockerRegistry)
+	}
+	if r.TLS != nil {
+		modes++
+		secretType = corev1.SecretTypeTLS
+		data, err = BuildTLSData(r.TLS)
+	}
+	if r.BasicAuth != nil {
+		modes++
+		secretType = corev1.SecretTypeBasicAuth
+		data, err = BuildBasicAuthData(r.BasicAuth)
+	}
+	if r.SSHAuth != nil {
+		modes++
+		secretType = corev1.SecretTypeSSHAuth
+		data, err = BuildSSHAuthData(r.SSHAuth)
+	}
+
+	if modes > 1 {
+		return "", nil, fmt.Errorf("only one of dockerRegistry, tls, basicAuth or sshAuth may be set")
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	return secretType, data, nil
+}
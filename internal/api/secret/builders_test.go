@@ -0,0 +1,145 @@
+package secret
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM string
+	keyPEM  string
+}
+
+// newTestCert creates a certificate for the given DNS names, signed by parent or self-signed when parent is nil
+func newTestCert(t *testing.T, commonName string, dnsNames []string, isCA bool, notAfter time.Time, parent *testCert) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		DNSNames:              dnsNames,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+
+	signerCert, signerKey := template, key
+	if parent != nil {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		keyPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+	}
+}
+
+func TestBuildDockerRegistryData(t *testing.T) {
+	data, err := BuildDockerRegistryData(&DockerRegistryAuth{Username: "bot", Password: "pw", Email: "bot@example.com"})
+	if err != nil {
+		t.Fatalf("BuildDockerRegistryData: %v", err)
+	}
+
+	var config dockerConfigJSON
+	if err := json.Unmarshal([]byte(data[corev1.DockerConfigJsonKey]), &config); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	entry, ok := config.Auths[DefaultRegistryServer]
+	if !ok {
+		t.Fatalf("auths = %v, want entry for %s", config.Auths, DefaultRegistryServer)
+	}
+	if want := base64.StdEncoding.EncodeToString([]byte("bot:pw")); entry.Auth != want {
+		t.Errorf("auth = %q, want %q", entry.Auth, want)
+	}
+	if err := ValidateSecretData(corev1.SecretTypeDockerConfigJson, data); err != nil {
+		t.Errorf("ValidateSecretData: %v", err)
+	}
+
+	if _, err := BuildDockerRegistryData(&DockerRegistryAuth{Username: "bot"}); err == nil {
+		t.Errorf("missing password: want error")
+	}
+}
+
+func TestBuildTLSData(t *testing.T) {
+	notAfter := time.Now().Add(24 * time.Hour)
+	ca := newTestCert(t, "test-ca", nil, true, notAfter, nil)
+	leaf := newTestCert(t, "example.com", []string{"example.com"}, false, notAfter, ca)
+	other := newTestCert(t, "other-ca", nil, true, notAfter, nil)
+
+	if _, err := BuildTLSData(&TLSKeyPair{Cert: leaf.certPEM + ca.certPEM, Key: leaf.keyPEM}); err != nil {
+		t.Errorf("valid chain: %v", err)
+	}
+	if _, err := BuildTLSData(&TLSKeyPair{Cert: leaf.certPEM, Key: ca.keyPEM}); err == nil {
+		t.Errorf("mismatched key: want error")
+	}
+	if _, err := BuildTLSData(&TLSKeyPair{Cert: leaf.certPEM + other.certPEM, Key: leaf.keyPEM}); err == nil {
+		t.Errorf("broken chain: want error")
+	}
+	if _, err := BuildTLSData(&TLSKeyPair{Cert: "not a certificate", Key: leaf.keyPEM}); err == nil {
+		t.Errorf("invalid PEM: want error")
+	}
+}
+
+func TestTypedSecretRequestBuild(t *testing.T) {
+	req := TypedSecretRequest{BasicAuth: &BasicAuth{Username: "admin"}}
+	secretType, data, err := req.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if secretType != corev1.SecretTypeBasicAuth || data[corev1.BasicAuthUsernameKey] != "admin" {
+		t.Errorf("Build() = %s, %v", secretType, data)
+	}
+
+	req = TypedSecretRequest{
+		BasicAuth: &BasicAuth{Username: "admin"},
+		SSHAuth:   &SSHAuth{PrivateKey: "key"},
+	}
+	if _, _, err := req.Build(); err == nil {
+		t.Errorf("multiple modes: want error")
+	}
+
+	req = TypedSecretRequest{SSHAuth: &SSHAuth{PrivateKey: "not a key"}}
+	if _, _, err := req.Build(); err == nil {
+		t.Errorf("invalid ssh key: want error")
+	}
+
+	secretType, _, err = (&TypedSecretRequest{}).Build()
+	if err != nil || secretType != "" {
+		t.Errorf("no mode: Build() = %q, %v", secretType, err)
+	}
+}
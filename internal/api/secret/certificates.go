@@ -0,0 +1,203 @@
+package secret
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+
+	"k8s-glance-backend/internal/api/base"
+)
+
+// caCertKey is the conventional key for the issuing CA in kubernetes.io/tls Secrets
+const caCertKey = "ca.crt"
+
+// Errors returned by InspectSecretCertificate for Secrets without a usable certificate
+var (
+	ErrNoCertificate      = errors.New("secret has no certificate")
+	ErrInvalidCertificate = errors.New("invalid certificate")
+)
+
+// CertificateInfo describes the leaf certificate of a kubernetes.io/tls Secret.
+// The private key is never part of it.
+type CertificateInfo struct {
+	Subject       string    `json:"subject"`
+	Issuer        string    `json:"issuer"`
+	SerialNumber  string    `json:"serialNumber"`
+	DNSNames      []string  `json:"dnsNames"`
+	IPAddresses   []string  `json:"ipAddresses,omitempty"`
+	NotBefore     time.Time `json:"notBefore"`
+	NotAfter      time.Time `json:"notAfter"`
+	DaysRemaining int       `json:"daysRemaining"`
+	Expired       bool      `json:"expired"`
+	SelfSigned    bool      `json:"selfSigned"`
+	ChainLength   int       `json:"chainLength"`
+	ChainValid    bool      `json:"chainValid"`
+	ChainError    string    `json:"chainError,omitempty"`
+
+	leaf *x509.Certificate
+}
+
+// Covers reports whether the certificate is valid for the given host, including wildcard SANs
+func (info *CertificateInfo) Covers(host string) bool {
+	return info.leaf != nil && info.leaf.VerifyHostname(host) == nil
+}
+
+// InspectCertificate parses a PEM encoded certificate chain. When caPEM is set, the
+// chain is additionally verified against it as the root. A chain that cannot be parsed
+// returns ErrInvalidCertificate.
+func InspectCertificate(certPEM, caPEM []byte, now time.Time) (*CertificateInfo, error) {
+	chain, err := parseCertificateChain(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCertificate, err)
+	}
+	leaf := chain[0]
+
+	info := &CertificateInfo{
+		Subject:       leaf.Subject.String(),
+		Issuer:        leaf.Issuer.String(),
+		SerialNumber:  leaf.SerialNumber.String(),
+		DNSNames:      leaf.DNSNames,
+		NotBefore:     leaf.NotBefore,
+		NotAfter:      leaf.NotAfter,
+		DaysRemaining: int(math.Floor(leaf.NotAfter.Sub(now).Hours() / 24)),
+		Expired:       now.After(leaf.NotAfter),
+		SelfSigned:    leaf.CheckSignatureFrom(leaf) == nil,
+		ChainLength:   len(chain),
+		ChainValid:    true,
+		leaf:          leaf,
+	}
+	for _, ip := range leaf.IPAddresses {
+		info.IPAddresses = append(info.IPAddresses, ip.String())
+	}
+
+	if err := verifyCertificateChain(chain); err != nil {
+		info.ChainValid = false
+		info.ChainError = err.Error()
+	} else if len(caPEM) > 0 {
+		if err := verifyAgainstCA(chain, caPEM, now); err != nil {
+			info.ChainValid = false
+			info.ChainError = err.Error()
+		}
+	}
+
+	return info, nil
+}
+
+// GetSecretCertificate returns the parsed certificate of a kubernetes.io/tls Secret
+func (api *SecretAPI) GetSecretCertificate(ctx context.Context, namespace, name string) (*base.APIResponse, error) {
+	api.LogInfo(ctx, "GetSecretCertificate", fmt.Sprintf("Inspecting certificate of Secret %s in namespace %s", name, namespace))
+
+	secret, err := api.GetClientset().CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetSecretCertificate", err)
+		return nil, api.HandleError(err, "get secret certificate")
+	}
+
+	info, err := InspectSecretCertificate(secret, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	response := base.NewSuccessResponse(info)
+	return &response, nil
+}
+
+// InspectSecretCertificate parses the tls.crt (and optional ca.crt) of a Secret
+func InspectSecretCertificate(secret *corev1.Secret, now time.Time) (*CertificateInfo, error) {
+	certPEM, ok := secret.Data[corev1.TLSCertKey]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s/%s of type %s has no %s key",
+			ErrNoCertificate, secret.Namespace, secret.Name, secret.Type, corev1.TLSCertKey)
+	}
+	return InspectCertificate(certPEM, secret.Data[caCertKey], now)
+}
+
+// ListExpiringCertificates returns all kubernetes.io/tls Secrets in the cluster whose
+// certificate expires within the given number of days, soonest first
+func (api *SecretAPI) ListExpiringCertificates(ctx context.Context, days int) (*base.APIResponse, error) {
+	api.LogInfo(ctx, "ListExpiringCertificates", fmt.Sprintf("Fetching certificates expiring within %d days", days))
+
+	secrets, err := api.GetClientset().CoreV1().Secrets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", string(corev1.SecretTypeTLS)).String(),
+	})
+	if err != nil {
+		api.LogError(ctx, "ListExpiringCertificates", err)
+		return nil, api.HandleError(err, "list tls secrets")
+	}
+
+	now := time.Now()
+	deadline := now.Add(time.Duration(days) * 24 * time.Hour)
+
+	type expiringCertificate struct {
+		Namespace string `json:"namespace"`
+		Name      string `json:"name"`
+		*CertificateInfo
+	}
+
+	var expiring []expiringCertificate
+	var invalid []map[string]interface{}
+
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		info, err := InspectSecretCertificate(secret, now)
+		if err != nil {
+			invalid = append(invalid, map[string]interface{}{
+				"namespace": secret.Namespace,
+				"name":      secret.Name,
+				"error":     err.Error(),
+			})
+			continue
+		}
+		if info.NotAfter.Before(deadline) {
+			expiring = append(expiring, expiringCertificate{
+				Namespace:       secret.Namespace,
+				Name:            secret.Name,
+				CertificateInfo: info,
+			})
+		}
+	}
+
+	sort.Slice(expiring, func(i, j int) bool {
+		return expiring[i].NotAfter.Before(expiring[j].NotAfter)
+	})
+
+	response := base.NewSuccessResponse(map[string]interface{}{
+		"withinDays":          days,
+		"certificates":        expiring,
+		"total":               len(expiring),
+		"invalidCertificates": invalid,
+	})
+	return &response, nil
+}
+
+func verifyAgainstCA(chain []*x509.Certificate, caPEM []byte, now time.Time) error {
+	roots, err := parseCertificateChain(caPEM)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %v", caCertKey, err)
+	}
+
+	rootPool := x509.NewCertPool()
+	for _, root := range roots {
+		rootPool.AddCert(root)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err = chain[0].Verify(x509.VerifyOptions{
+		Roots:         rootPool,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err
+}
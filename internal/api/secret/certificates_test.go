@@ -0,0 +1,70 @@
+package secret
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInspectCertificate(t *testing.T) {
+	now := time.Now()
+	ca := newTestCert(t, "test-ca", nil, true, now.Add(365*24*time.Hour), nil)
+	leaf := newTestCert(t, "example.com", []string{"example.com", "*.apps.example.com"}, false, now.Add(10*24*time.Hour+time.Hour), ca)
+
+	info, err := InspectCertificate([]byte(leaf.certPEM+ca.certPEM), []byte(ca.certPEM), now)
+	if err != nil {
+		t.Fatalf("InspectCertificate: %v", err)
+	}
+
+	if info.DaysRemaining != 10 || info.Expired {
+		t.Errorf("daysRemaining = %d, expired = %v, want 10, false", info.DaysRemaining, info.Expired)
+	}
+	if !info.ChainValid || info.ChainLength != 2 || info.SelfSigned {
+		t.Errorf("chainValid = %v, chainLength = %d, selfSigned = %v", info.ChainValid, info.ChainLength, info.SelfSigned)
+	}
+
+	for host, want := range map[string]bool{
+		"example.com":          true,
+		"web.apps.example.com": true,
+		"other.example.com":    false,
+	} {
+		if got := info.Covers(host); got != want {
+			t.Errorf("Covers(%q) = %v, want %v", host, got, want)
+		}
+	}
+
+	other := newTestCert(t, "other-ca", nil, true, now.Add(time.Hour), nil)
+	info, err = InspectCertificate([]byte(leaf.certPEM), []byte(other.certPEM), now)
+	if err != nil {
+		t.Fatalf("InspectCertificate: %v", err)
+	}
+	if info.ChainValid || info.ChainError == "" {
+		t.Errorf("untrusted chain: chainValid = %v, chainError = %q", info.ChainValid, info.ChainError)
+	}
+}
+
+func TestInspectSecretCertificateErrors(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "default"},
+		Type:       corev1.SecretTypeOpaque,
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+
+	if _, err := InspectSecretCertificate(secret, time.Now()); !errors.Is(err, ErrNoCertificate) {
+		t.Errorf("InspectSecretCertificate error = %v, want ErrNoCertificate", err)
+	}
+
+	secret.Type = corev1.SecretTypeTLS
+	for name, certPEM := range map[string]string{
+		"not PEM":         "not a certificate",
+		"unparsable cert": "-----BEGIN CERTIFICATE-----\nbm90IGEgY2VydGlmaWNhdGU=\n-----END CERTIFICATE-----\n",
+	} {
+		secret.Data = map[string][]byte{corev1.TLSCertKey: []byte(certPEM)}
+		if _, err := InspectSecretCertificate(secret, time.Now()); !errors.Is(err, ErrInvalidCertificate) {
+			t.Errorf("%s: error = %v, want ErrInvalidCertificate", name, err)
+		}
+	}
+}
@@ -1,8 +1,11 @@
 package secret
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	corev1 "k8s.io/api/core/v1"
@@ -105,6 +108,7 @@ func (h *Handler) CreateSecret(c *gin.Context) {
 		StringData  map[string]string `json:"stringData"`
 		Labels      map[string]string `json:"labels"`
 		Annotations map[string]string `json:"annotations"`
+		TypedSecretRequest
 	}
 
 	if err := c.ShouldBindJSON(&secretRequest); err != nil {
@@ -117,11 +121,54 @@ func (h *Handler) CreateSecret(c *gin.Context) {
 
 	namespace := c.Param("namespace")
 
+	// Generate data for typed creation modes
+	typedType, typedData, err := secretRequest.TypedSecretRequest.Build()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if typedType != "" {
+		if secretRequest.Type != "" && secretRequest.Type != string(typedType) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   fmt.Sprintf("type %q conflicts with typed mode %q", secretRequest.Type, typedType),
+			})
+			return
+		}
+		secretRequest.Type = string(typedType)
+
+		if secretRequest.StringData == nil {
+			secretRequest.StringData = make(map[string]string)
+		}
+		for k, v := range typedData {
+			if _, ok := secretRequest.StringData[k]; ok {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"success": false,
+					"error":   fmt.Sprintf("key %q is generated by the typed mode and cannot be set in stringData", k),
+				})
+				return
+			}
+			secretRequest.StringData[k] = v
+		}
+	}
+
 	// Set default type if not provided
 	if secretRequest.Type == "" {
 		secretRequest.Type = "Opaque"
 	}
 
+	if err := ValidateSecretData(corev1.SecretType(secretRequest.Type), secretRequest.StringData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        secretRequest.Name,
@@ -154,11 +201,7 @@ func (h *Handler) CreateSecret(c *gin.Context) {
 
 // UpdateSecret handles PUT /api/v1/secrets/namespaces/:namespace/:name
 func (h *Handler) UpdateSecret(c *gin.Context) {
-	var updateRequest struct {
-		StringData  map[string]string `json:"stringData"`
-		Labels      map[string]string `json:"labels"`
-		Annotations map[string]string `json:"annotations"`
-	}
+	var updateRequest SecretUpdate
 
 	if err := c.ShouldBindJSON(&updateRequest); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -168,38 +211,18 @@ func (h *Handler) UpdateSecret(c *gin.Context) {
 		return
 	}
 
-	namespace := c.Param("namespace")
-	name := c.Param("name")
-
-	// Get existing secret
-	existing, err := h.api.GetSecret(c.Request.Context(), namespace, name)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+	if err := updateRequest.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
 			"error":   err.Error(),
 		})
 		return
 	}
 
-	// Update fields if provided
-	if updateRequest.StringData != nil {
-		// Convert string data to Secret Data
-		if existing.Data == nil {
-			existing.Data = make(map[string][]byte)
-		}
-		for k, v := range updateRequest.StringData {
-			existing.Data[k] = []byte(v)
-		}
-	}
-
-	if updateRequest.Labels != nil {
-		existing.Labels = updateRequest.Labels
-	}
-	if updateRequest.Annotations != nil {
-		existing.Annotations = updateRequest.Annotations
-	}
+	namespace := c.Param("namespace")
+	name := c.Param("name")
 
-	result, err := h.api.UpdateSecret(c.Request.Context(), namespace, existing)
+	result, err := h.api.UpdateSecret(c.Request.Context(), namespace, name, &updateRequest)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
@@ -214,6 +237,7 @@ func (h *Handler) UpdateSecret(c *gin.Context) {
 			"name":      result.Name,
 			"namespace": result.Namespace,
 			"type":      string(result.Type),
+			"mode":      updateRequest.mode(),
 			"status":    "updated",
 		},
 	})
@@ -255,3 +279,47 @@ func (h *Handler) GetSecretUsage(c *gin.Context) {
 
 	c.JSON(http.StatusOK, usage)
 }
+
+// GetSecretCertificate handles GET /api/v1/secrets/namespaces/:namespace/:name/certificate
+func (h *Handler) GetSecretCertificate(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	certificate, err := h.api.GetSecretCertificate(c.Request.Context(), namespace, name)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrNoCertificate) || errors.Is(err, ErrInvalidCertificate) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, certificate)
+}
+
+// ListExpiringCertificates handles GET /api/v1/secrets/certificates/expiring?days=N
+func (h *Handler) ListExpiringCertificates(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid days value",
+		})
+		return
+	}
+
+	certificates, err := h.api.ListExpiringCertificates(c.Request.Context(), days)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, certificates)
+}
@@ -2,11 +2,16 @@ package secret
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/kubernetes"
 
 	"k8s-glance-backend/internal/api/base"
@@ -18,12 +23,83 @@ type SecretAPI struct {
 }
 
 // NewSecretAPI creates a new SecretAPI instance
-func NewSecretAPI(clientset *kubernetes.Clientset, logger *log.Logger) *SecretAPI {
+func NewSecretAPI(clientset kubernetes.Interface, logger *log.Logger) *SecretAPI {
 	return &SecretAPI{
 		BaseAPI: base.NewBaseAPI(clientset, logger),
 	}
 }
 
+// Update modes for SecretUpdate
+const (
+	// UpdateModeMerge upserts the given keys and keeps every other key
+	UpdateModeMerge = "merge"
+	// UpdateModeReplace makes the given keys the complete content of the Secret
+	UpdateModeReplace = "replace"
+)
+
+// SecretUpdate describes a change to an existing Secret.
+// StringData holds plain-text values, Data holds base64-encoded (binary) values.
+// Labels and Annotations are only touched when provided.
+type SecretUpdate struct {
+	Mode        string            `json:"mode"`
+	StringData  map[string]string `json:"stringData"`
+	Data        map[string][]byte `json:"data"`
+	RemoveKeys  []string          `json:"removeKeys"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// Validate checks the update for unknown modes, invalid key names and conflicting keys
+func (u *SecretUpdate) Validate() error {
+	mode := u.mode()
+	if mode != UpdateModeMerge && mode != UpdateModeReplace {
+		return fmt.Errorf("invalid mode %q: must be %q or %q", u.Mode, UpdateModeMerge, UpdateModeReplace)
+	}
+
+	for k := range u.StringData {
+		if _, ok := u.Data[k]; ok {
+			return fmt.Errorf("key %q is set in both stringData and data", k)
+		}
+	}
+
+	for _, k := range u.keys() {
+		if errs := validation.IsConfigMapKey(k); len(errs) > 0 {
+			return fmt.Errorf("invalid key %q: %s", k, strings.Join(errs, ", "))
+		}
+	}
+
+	for _, k := range u.RemoveKeys {
+		if mode == UpdateModeReplace {
+			return fmt.Errorf("removeKeys cannot be combined with mode %q", UpdateModeReplace)
+		}
+		_, inString := u.StringData[k]
+		_, inData := u.Data[k]
+		if inString || inData {
+			return fmt.Errorf("key %q is both set and removed", k)
+		}
+	}
+
+	return nil
+}
+
+func (u *SecretUpdate) mode() string {
+	if u.Mode == "" {
+		return UpdateModeMerge
+	}
+	return u.Mode
+}
+
+func (u *SecretUpdate) keys() []string {
+	keys := make([]string, 0, len(u.StringData)+len(u.Data))
+	for k := range u.StringData {
+		keys = append(keys, k)
+	}
+	for k := range u.Data {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
 // ListSecrets returns all Secrets in a namespace (without their values)
 func (api *SecretAPI) ListSecrets(ctx context.Context, namespace string) (*corev1.SecretList, error) {
 	api.LogInfo(ctx, "ListSecrets", fmt.Sprintf("Fetching Secrets in namespace: %s", namespace))
@@ -75,6 +151,7 @@ func (api *SecretAPI) GetSecretKeys(ctx context.Context, namespace, name string)
 	for k := range secret.Data {
 		keys = append(keys, k)
 	}
+	sort.Strings(keys)
 
 	response := base.NewSuccessResponse(map[string]interface{}{
 		"keys": keys,
@@ -100,11 +177,27 @@ func (api *SecretAPI) CreateSecret(ctx context.Context, namespace string, secret
 	return result, nil
 }
 
-// UpdateSecret updates an existing Secret
-func (api *SecretAPI) UpdateSecret(ctx context.Context, namespace string, secret *corev1.Secret) (*corev1.Secret, error) {
-	api.LogInfo(ctx, "UpdateSecret", fmt.Sprintf("Updating Secret %s in namespace %s", secret.Name, namespace))
+// UpdateSecret applies a JSON merge patch built from the given update to an existing Secret.
+// Keys that are not mentioned in a merge update are left untouched.
+func (api *SecretAPI) UpdateSecret(ctx context.Context, namespace, name string, update *SecretUpdate) (*corev1.Secret, error) {
+	api.LogInfo(ctx, "UpdateSecret", fmt.Sprintf("Updating Secret %s in namespace %s (mode: %s)", name, namespace, update.mode()))
+
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
 
-	result, err := api.GetClientset().CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	existing, err := api.GetClientset().CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		api.LogError(ctx, "UpdateSecret", err)
+		return nil, api.HandleError(err, "get secret for update")
+	}
+
+	patch, err := buildSecretPatch(existing, update)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := api.GetClientset().CoreV1().Secrets(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
 	if err != nil {
 		api.LogError(ctx, "UpdateSecret", err)
 		return nil, api.HandleError(err, "update secret")
@@ -130,7 +223,7 @@ func (api *SecretAPI) DeleteSecret(ctx context.Context, namespace, name string)
 	return nil
 }
 
-// GetSecretUsage returns information about which Pods are using this Secret
+// GetSecretUsage returns information about which Pods, ServiceAccounts and Ingresses are using this Secret
 func (api *SecretAPI) GetSecretUsage(ctx context.Context, namespace, name string) (*base.APIResponse, error) {
 	api.LogInfo(ctx, "GetSecretUsage", fmt.Sprintf("Checking usage of Secret %s in namespace %s", name, namespace))
 
@@ -140,51 +233,221 @@ func (api *SecretAPI) GetSecretUsage(ctx context.Context, namespace, name string
 		return nil, api.HandleError(err, "list pods for secret usage")
 	}
 
-	var usingPods []map[string]interface{}
+	serviceAccounts, err := api.GetClientset().CoreV1().ServiceAccounts(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetSecretUsage", err)
+		return nil, api.HandleError(err, "list service accounts for secret usage")
+	}
 
-	for _, pod := range pods.Items {
-		isUsed := false
-		usageDetails := make(map[string][]string)
+	ingresses, err := api.GetClientset().NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetSecretUsage", err)
+		return nil, api.HandleError(err, "list ingresses for secret usage")
+	}
 
-		// Check volumes
-		for _, volume := range pod.Spec.Volumes {
-			if volume.Secret != nil && volume.Secret.SecretName == name {
-				isUsed = true
-				usageDetails["volumeMounts"] = append(usageDetails["volumeMounts"], volume.Name)
-			}
+	// Service accounts referencing the Secret, either as a mountable or an image pull secret
+	accountUsage := make(map[string][]string)
+	var usingServiceAccounts []map[string]interface{}
+	for _, sa := range serviceAccounts.Items {
+		usage := getServiceAccountSecretReferences(&sa, name)
+		if len(usage) > 0 {
+			accountUsage[sa.Name] = usage
+			usingServiceAccounts = append(usingServiceAccounts, map[string]interface{}{
+				"name":  sa.Name,
+				"usage": usage,
+			})
 		}
+	}
 
-		// Check environment variables
-		for _, container := range pod.Spec.Containers {
-			for _, env := range container.EnvFrom {
-				if env.SecretRef != nil && env.SecretRef.Name == name {
-					isUsed = true
-					usageDetails["envFrom"] = append(usageDetails["envFrom"], container.Name)
-				}
-			}
+	consumedKeys := make(map[string]bool)
+	var usingPods []map[string]interface{}
 
-			for _, env := range container.Env {
-				if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil &&
-					env.ValueFrom.SecretKeyRef.Name == name {
-					isUsed = true
-					usageDetails["envVars"] = append(usageDetails["envVars"],
-						fmt.Sprintf("%s:%s", container.Name, env.Name))
-				}
+	for _, pod := range pods.Items {
+		usageDetails, keys := GetPodSecretReferences(&pod, name)
+
+		serviceAccountName := pod.Spec.ServiceAccountName
+		if serviceAccountName == "" {
+			serviceAccountName = "default"
+		}
+		if usage, ok := accountUsage[serviceAccountName]; ok {
+			for _, u := range usage {
+				usageDetails["serviceAccount"] = append(usageDetails["serviceAccount"],
+					fmt.Sprintf("%s:%s", serviceAccountName, u))
 			}
+			keys = base.AppendKey(keys, "*")
 		}
 
-		if isUsed {
+		if len(usageDetails) > 0 {
+			for _, k := range keys {
+				consumedKeys[k] = true
+			}
 			usingPods = append(usingPods, map[string]interface{}{
 				"name":   pod.Name,
 				"status": pod.Status.Phase,
 				"usage":  usageDetails,
+				"keys":   keys,
 			})
 		}
 	}
 
+	var usingIngresses []map[string]interface{}
+	for _, ing := range ingresses.Items {
+		var hosts []string
+		isUsed := false
+		for _, t := range ing.Spec.TLS {
+			if t.SecretName == name {
+				isUsed = true
+				hosts = append(hosts, t.Hosts...)
+			}
+		}
+		if isUsed {
+			consumedKeys[corev1.TLSCertKey] = true
+			consumedKeys[corev1.TLSPrivateKeyKey] = true
+			usingIngresses = append(usingIngresses, map[string]interface{}{
+				"name":  ing.Name,
+				"hosts": hosts,
+			})
+		}
+	}
+
+	keys := make([]string, 0, len(consumedKeys))
+	for k := range consumedKeys {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
 	response := base.NewSuccessResponse(map[string]interface{}{
-		"podsUsingSecret": usingPods,
-		"totalPods":       len(usingPods),
+		"podsUsingSecret":            usingPods,
+		"totalPods":                  len(usingPods),
+		"serviceAccountsUsingSecret": usingServiceAccounts,
+		"ingressesUsingSecret":       usingIngresses,
+		"consumedKeys":               keys,
 	})
 	return &response, nil
 }
+
+// Helper functions
+
+// buildSecretPatch builds a JSON merge patch for the update. Keys mapped to null are
+// deleted by the API server. The existing resourceVersion is included so that a
+// concurrent modification results in a conflict instead of lost keys.
+func buildSecretPatch(existing *corev1.Secret, update *SecretUpdate) ([]byte, error) {
+	data := make(map[string]interface{})
+	if update.mode() == UpdateModeReplace {
+		for k := range existing.Data {
+			data[k] = nil
+		}
+	}
+	for _, k := range update.RemoveKeys {
+		data[k] = nil
+	}
+	for k, v := range update.StringData {
+		data[k] = []byte(v)
+	}
+	for k, v := range update.Data {
+		data[k] = v
+	}
+
+	metadata := map[string]interface{}{
+		"resourceVersion": existing.ResourceVersion,
+	}
+	if update.Labels != nil {
+		metadata["labels"] = mergeStringMap(existing.Labels, update.Labels, update.mode())
+	}
+	if update.Annotations != nil {
+		metadata["annotations"] = mergeStringMap(existing.Annotations, update.Annotations, update.mode())
+	}
+
+	patch := map[string]interface{}{
+		"metadata": metadata,
+	}
+	if len(data) > 0 {
+		patch["data"] = data
+	}
+
+	return json.Marshal(patch)
+}
+
+// mergeStringMap returns the merge patch for a string map. In replace mode, existing
+// entries missing from the desired map are removed.
+func mergeStringMap(existing, desired map[string]string, mode string) map[string]interface{} {
+	result := make(map[string]interface{})
+	if mode == UpdateModeReplace {
+		for k := range existing {
+			result[k] = nil
+		}
+	}
+	for k, v := range desired {
+		result[k] = v
+	}
+	return result
+}
+
+// GetPodSecretReferences returns how a Pod references the named Secret, grouped by
+// kind of reference, and the keys it consumes. A key of "*" means the whole Secret.
+func GetPodSecretReferences(pod *corev1.Pod, name string) (map[string][]string, []string) {
+	usageDetails := make(map[string][]string)
+	var keys []string
+
+	// Check volumes
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Secret != nil && volume.Secret.SecretName == name {
+			usageDetails["volumeMounts"] = append(usageDetails["volumeMounts"], volume.Name)
+			keys = base.AppendItemKeys(keys, volume.Secret.Items)
+		}
+
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil && source.Secret.Name == name {
+					usageDetails["projectedVolumes"] = append(usageDetails["projectedVolumes"], volume.Name)
+					keys = base.AppendItemKeys(keys, source.Secret.Items)
+				}
+			}
+		}
+	}
+
+	// Check image pull secrets
+	for _, ref := range pod.Spec.ImagePullSecrets {
+		if ref.Name == name {
+			usageDetails["imagePullSecrets"] = append(usageDetails["imagePullSecrets"], ref.Name)
+			keys = base.AppendKey(keys, "*")
+		}
+	}
+
+	// Check environment variables of regular, init and ephemeral containers
+	for _, container := range base.GetContainerEnvs(pod) {
+		for _, env := range container.EnvFrom {
+			if env.SecretRef != nil && env.SecretRef.Name == name {
+				usageDetails["envFrom"] = append(usageDetails["envFrom"], container.Name)
+				keys = base.AppendKey(keys, "*")
+			}
+		}
+
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil &&
+				env.ValueFrom.SecretKeyRef.Name == name {
+				usageDetails["envVars"] = append(usageDetails["envVars"],
+					fmt.Sprintf("%s:%s", container.Name, env.Name))
+				keys = base.AppendKey(keys, env.ValueFrom.SecretKeyRef.Key)
+			}
+		}
+	}
+
+	return usageDetails, keys
+}
+
+// getServiceAccountSecretReferences returns how a ServiceAccount references the named Secret
+func getServiceAccountSecretReferences(sa *corev1.ServiceAccount, name string) []string {
+	var usage []string
+	for _, ref := range sa.Secrets {
+		if ref.Name == name {
+			usage = append(usage, "secrets")
+		}
+	}
+	for _, ref := range sa.ImagePullSecrets {
+		if ref.Name == name {
+			usage = append(usage, "imagePullSecrets")
+		}
+	}
+	return usage
+}
@@ -0,0 +1,297 @@
+package secret
+
+import (
+	"context"
+	"log"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestSecretAPI(t *testing.T) (*SecretAPI, *fake.Clientset) {
+	t.Helper()
+
+	clientset := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "app-credentials",
+			Namespace:   "default",
+			Labels:      map[string]string{"app": "myapp", "tier": "backend"},
+			Annotations: map[string]string{"owner": "team-a"},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"username": []byte("admin"),
+			"password": []byte("secret"),
+			"api.key":  []byte("abcdef"),
+		},
+	})
+
+	return NewSecretAPI(clientset, log.New(testWriter{t}, "", 0)), clientset
+}
+
+type testWriter struct{ t *testing.T }
+
+func (w testWriter) Write(p []byte) (int, error) {
+	w.t.Log(string(p))
+	return len(p), nil
+}
+
+func getStoredSecret(t *testing.T, clientset *fake.Clientset) *corev1.Secret {
+	t.Helper()
+
+	secret, err := clientset.CoreV1().Secrets("default").Get(context.Background(), "app-credentials", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get secret: %v", err)
+	}
+	return secret
+}
+
+func TestUpdateSecretMergeKeepsOtherKeys(t *testing.T) {
+	api, clientset := newTestSecretAPI(t)
+
+	_, err := api.UpdateSecret(context.Background(), "default", "app-credentials", &SecretUpdate{
+		StringData: map[string]string{"password": "rotated"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateSecret: %v", err)
+	}
+
+	want := map[string][]byte{
+		"username": []byte("admin"),
+		"password": []byte("rotated"),
+		"api.key":  []byte("abcdef"),
+	}
+	if got := getStoredSecret(t, clientset).Data; !reflect.DeepEqual(got, want) {
+		t.Errorf("data = %q, want %q", got, want)
+	}
+}
+
+func TestUpdateSecretRemoveKeysAndBinaryData(t *testing.T) {
+	api, clientset := newTestSecretAPI(t)
+
+	_, err := api.UpdateSecret(context.Background(), "default", "app-credentials", &SecretUpdate{
+		Data:       map[string][]byte{"cert.der": {0x30, 0x82, 0x00, 0xff}},
+		RemoveKeys: []string{"api.key"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateSecret: %v", err)
+	}
+
+	want := map[string][]byte{
+		"username": []byte("admin"),
+		"password": []byte("secret"),
+		"cert.der": {0x30, 0x82, 0x00, 0xff},
+	}
+	if got := getStoredSecret(t, clientset).Data; !reflect.DeepEqual(got, want) {
+		t.Errorf("data = %q, want %q", got, want)
+	}
+}
+
+func TestUpdateSecretReplace(t *testing.T) {
+	api, clientset := newTestSecretAPI(t)
+
+	_, err := api.UpdateSecret(context.Background(), "default", "app-credentials", &SecretUpdate{
+		Mode:       UpdateModeReplace,
+		StringData: map[string]string{"token": "xyz"},
+		Labels:     map[string]string{"app": "other"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateSecret: %v", err)
+	}
+
+	stored := getStoredSecret(t, clientset)
+	if want := map[string][]byte{"token": []byte("xyz")}; !reflect.DeepEqual(stored.Data, want) {
+		t.Errorf("data = %q, want %q", stored.Data, want)
+	}
+	if want := map[string]string{"app": "other"}; !reflect.DeepEqual(stored.Labels, want) {
+		t.Errorf("labels = %v, want %v", stored.Labels, want)
+	}
+	if want := map[string]string{"owner": "team-a"}; !reflect.DeepEqual(stored.Annotations, want) {
+		t.Errorf("annotations = %v, want %v", stored.Annotations, want)
+	}
+}
+
+func TestUpdateSecretMetadataOnly(t *testing.T) {
+	api, clientset := newTestSecretAPI(t)
+
+	_, err := api.UpdateSecret(context.Background(), "default", "app-credentials", &SecretUpdate{
+		Labels: map[string]string{"tier": "frontend"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateSecret: %v", err)
+	}
+
+	stored := getStoredSecret(t, clientset)
+	if len(stored.Data) != 3 {
+		t.Errorf("data has %d keys, want 3", len(stored.Data))
+	}
+	if want := map[string]string{"app": "myapp", "tier": "frontend"}; !reflect.DeepEqual(stored.Labels, want) {
+		t.Errorf("labels = %v, want %v", stored.Labels, want)
+	}
+}
+
+func TestUpdateSecretStripsReturnedData(t *testing.T) {
+	api, _ := newTestSecretAPI(t)
+
+	result, err := api.UpdateSecret(context.Background(), "default", "app-credentials", &SecretUpdate{
+		StringData: map[string]string{"password": "rotated"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateSecret: %v", err)
+	}
+	if result.Data != nil || result.StringData != nil {
+		t.Errorf("returned secret exposes data")
+	}
+}
+
+func TestSecretUpdateValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		update SecretUpdate
+	}{
+		{"unknown mode", SecretUpdate{Mode: "overwrite"}},
+		{"key in stringData and data", SecretUpdate{
+			StringData: map[string]string{"a": "1"},
+			Data:       map[string][]byte{"a": []byte("1")},
+		}},
+		{"set and removed", SecretUpdate{
+			StringData: map[string]string{"a": "1"},
+			RemoveKeys: []string{"a"},
+		}},
+		{"remove in replace mode", SecretUpdate{Mode: UpdateModeReplace, RemoveKeys: []string{"a"}}},
+		{"invalid key", SecretUpdate{StringData: map[string]string{"bad/key": "1"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.update.Validate(); err == nil {
+				t.Errorf("Validate() = nil, want error")
+			}
+		})
+	}
+
+	valid := SecretUpdate{StringData: map[string]string{"a": "1"}, RemoveKeys: []string{"b"}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
+
+func TestGetPodSecretReferences(t *testing.T) {
+	keyRef := func(key string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "app-credentials"}, Key: key,
+		}}
+	}
+	envFrom := []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "app-credentials"},
+	}}}
+
+	tests := []struct {
+		name      string
+		spec      corev1.PodSpec
+		wantUsage map[string][]string
+		wantKeys  []string
+	}{
+		{
+			name: "volume with items",
+			spec: corev1.PodSpec{Volumes: []corev1.Volume{{Name: "creds", VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: "app-credentials", Items: []corev1.KeyToPath{{Key: "username"}}},
+			}}}},
+			wantUsage: map[string][]string{"volumeMounts": {"creds"}},
+			wantKeys:  []string{"username"},
+		},
+		{
+			name: "projected volume",
+			spec: corev1.PodSpec{Volumes: []corev1.Volume{{Name: "all", VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{{
+					Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "app-credentials"}},
+				}}},
+			}}}},
+			wantUsage: map[string][]string{"projectedVolumes": {"all"}},
+			wantKeys:  []string{"*"},
+		},
+		{
+			name:      "image pull secret",
+			spec:      corev1.PodSpec{ImagePullSecrets: []corev1.LocalObjectReference{{Name: "app-credentials"}}},
+			wantUsage: map[string][]string{"imagePullSecrets": {"app-credentials"}},
+			wantKeys:  []string{"*"},
+		},
+		{
+			name:      "init container env",
+			spec:      corev1.PodSpec{InitContainers: []corev1.Container{{Name: "migrate", Env: []corev1.EnvVar{{Name: "DB_PASSWORD", ValueFrom: keyRef("password")}}}}},
+			wantUsage: map[string][]string{"envVars": {"init:migrate:DB_PASSWORD"}},
+			wantKeys:  []string{"password"},
+		},
+		{
+			name: "ephemeral container envFrom",
+			spec: corev1.PodSpec{EphemeralContainers: []corev1.EphemeralContainer{{
+				EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug", EnvFrom: envFrom},
+			}}},
+			wantUsage: map[string][]string{"envFrom": {"ephemeral:debug"}},
+			wantKeys:  []string{"*"},
+		},
+		{
+			name:      "other secret",
+			spec:      corev1.PodSpec{ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}}},
+			wantUsage: map[string][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usage, keys := GetPodSecretReferences(&corev1.Pod{Spec: tt.spec}, "app-credentials")
+			if !reflect.DeepEqual(usage, tt.wantUsage) {
+				t.Errorf("usage = %v, want %v", usage, tt.wantUsage)
+			}
+			if !reflect.DeepEqual(keys, tt.wantKeys) {
+				t.Errorf("keys = %v, want %v", keys, tt.wantKeys)
+			}
+		})
+	}
+}
+
+func TestGetSecretUsageServiceAccountsAndIngresses(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.ServiceAccount{
+			ObjectMeta:       metav1.ObjectMeta{Name: "builder", Namespace: "default"},
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "app-credentials"}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "build-1", Namespace: "default"},
+			Spec:       corev1.PodSpec{ServiceAccountName: "builder"},
+		},
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: networkingv1.IngressSpec{TLS: []networkingv1.IngressTLS{
+				{Hosts: []string{"shop.example.com"}, SecretName: "app-credentials"},
+			}},
+		},
+	)
+	api := NewSecretAPI(clientset, log.New(testWriter{t}, "", 0))
+
+	response, err := api.GetSecretUsage(context.Background(), "default", "app-credentials")
+	if err != nil {
+		t.Fatalf("GetSecretUsage: %v", err)
+	}
+	data := response.Data.(map[string]interface{})
+
+	accounts := data["serviceAccountsUsingSecret"].([]map[string]interface{})
+	if len(accounts) != 1 || !reflect.DeepEqual(accounts[0]["usage"], []string{"imagePullSecrets"}) {
+		t.Errorf("service accounts = %v", accounts)
+	}
+	pods := data["podsUsingSecret"].([]map[string]interface{})
+	if len(pods) != 1 || !reflect.DeepEqual(pods[0]["usage"], map[string][]string{"serviceAccount": {"builder:imagePullSecrets"}}) {
+		t.Errorf("pods = %v", pods)
+	}
+	ingresses := data["ingressesUsingSecret"].([]map[string]interface{})
+	if len(ingresses) != 1 || !reflect.DeepEqual(ingresses[0]["hosts"], []string{"shop.example.com"}) {
+		t.Errorf("ingresses = %v", ingresses)
+	}
+	if keys := data["consumedKeys"].([]string); !reflect.DeepEqual(keys, []string{"*", corev1.TLSCertKey, corev1.TLSPrivateKeyKey}) {
+		t.Errorf("consumedKeys = %v", keys)
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/gin-gonic/gin"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -73,16 +72,23 @@ func (h *Handler) GetService(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data": map[string]interface{}{
-			"name":            service.Name,
-			"namespace":       service.Namespace,
-			"type":            string(service.Spec.Type),
-			"clusterIP":       service.Spec.ClusterIP,
-			"ports":           service.Spec.Ports,
-			"selector":        service.Spec.Selector,
-			"sessionAffinity": string(service.Spec.SessionAffinity),
-			"created":         service.CreationTimestamp,
-			"labels":          service.Labels,
-			"annotations":     service.Annotations,
+			"name":                     service.Name,
+			"namespace":                service.Namespace,
+			"type":                     string(service.Spec.Type),
+			"clusterIP":                service.Spec.ClusterIP,
+			"ports":                    service.Spec.Ports,
+			"selector":                 service.Spec.Selector,
+			"sessionAffinity":          string(service.Spec.SessionAffinity),
+			"headless":                 service.Spec.ClusterIP == corev1.ClusterIPNone,
+			"externalName":             service.Spec.ExternalName,
+			"externalIPs":              service.Spec.ExternalIPs,
+			"ipFamilies":               service.Spec.IPFamilies,
+			"ipFamilyPolicy":           service.Spec.IPFamilyPolicy,
+			"created":                  service.CreationTimestamp,
+			"labels":                   service.Labels,
+			"annotations":              service.Annotations,
+			"externalTrafficPolicy":    string(service.Spec.ExternalTrafficPolicy),
+			"loadBalancerSourceRanges": service.Spec.LoadBalancerSourceRanges,
 		},
 	})
 }
@@ -90,18 +96,8 @@ func (h *Handler) GetService(c *gin.Context) {
 // CreateService handles POST /api/v1/services/namespaces/:namespace
 func (h *Handler) CreateService(c *gin.Context) {
 	var serviceRequest struct {
-		Name  string `json:"name" binding:"required"`
-		Type  string `json:"type" binding:"required"`
-		Ports []struct {
-			Name       string `json:"name"`
-			Port       int32  `json:"port" binding:"required"`
-			TargetPort int32  `json:"targetPort"`
-			NodePort   int32  `json:"nodePort,omitempty"`
-			Protocol   string `json:"protocol,omitempty"`
-		} `json:"ports" binding:"required"`
-		Selector    map[string]string `json:"selector" binding:"required"`
-		Labels      map[string]string `json:"labels"`
-		ExternalIPs []string          `json:"externalIPs"`
+		Name string `json:"name" binding:"required"`
+		ServiceRequest
 	}
 
 	if err := c.ShouldBindJSON(&serviceRequest); err != nil {
@@ -112,44 +108,29 @@ func (h *Handler) CreateService(c *gin.Context) {
 		return
 	}
 
-	namespace := c.Param("namespace")
-
-	// Create service ports
-	var servicePorts []corev1.ServicePort
-	for _, p := range serviceRequest.Ports {
-		protocol := corev1.ProtocolTCP
-		if p.Protocol != "" {
-			protocol = corev1.Protocol(p.Protocol)
-		}
-
-		servicePort := corev1.ServicePort{
-			Name:       p.Name,
-			Port:       p.Port,
-			TargetPort: intstr.FromInt(int(p.TargetPort)),
-			Protocol:   protocol,
-		}
-
-		if p.NodePort > 0 {
-			servicePort.NodePort = p.NodePort
-		}
-
-		servicePorts = append(servicePorts, servicePort)
+	if err := serviceRequest.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
 	}
 
-	// Create service object
+	namespace := c.Param("namespace")
+
 	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      serviceRequest.Name,
 			Namespace: namespace,
-			Labels:    serviceRequest.Labels,
-		},
-		Spec: corev1.ServiceSpec{
-			Type:        corev1.ServiceType(serviceRequest.Type),
-			Ports:       servicePorts,
-			Selector:    serviceRequest.Selector,
-			ExternalIPs: serviceRequest.ExternalIPs,
 		},
 	}
+	if err := serviceRequest.Apply(service); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
 
 	result, err := h.api.CreateService(c.Request.Context(), namespace, service)
 	if err != nil {
@@ -173,18 +154,7 @@ func (h *Handler) CreateService(c *gin.Context) {
 
 // UpdateService handles PUT /api/v1/services/namespaces/:namespace/:name
 func (h *Handler) UpdateService(c *gin.Context) {
-	var updateRequest struct {
-		Ports []struct {
-			Name       string `json:"name"`
-			Port       int32  `json:"port"`
-			TargetPort int32  `json:"targetPort"`
-			NodePort   int32  `json:"nodePort,omitempty"`
-			Protocol   string `json:"protocol,omitempty"`
-		} `json:"ports"`
-		Selector    map[string]string `json:"selector"`
-		Labels      map[string]string `json:"labels"`
-		ExternalIPs []string          `json:"externalIPs"`
-	}
+	var updateRequest ServiceRequest
 
 	if err := c.ShouldBindJSON(&updateRequest); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -194,6 +164,14 @@ func (h *Handler) UpdateService(c *gin.Context) {
 		return
 	}
 
+	if err := updateRequest.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
 	namespace := c.Param("namespace")
 	name := c.Param("name")
 
@@ -207,41 +185,12 @@ func (h *Handler) UpdateService(c *gin.Context) {
 		return
 	}
 
-	// Update fields if provided
-	if len(updateRequest.Ports) > 0 {
-		var servicePorts []corev1.ServicePort
-		for _, p := range updateRequest.Ports {
-			protocol := corev1.ProtocolTCP
-			if p.Protocol != "" {
-				protocol = corev1.Protocol(p.Protocol)
-			}
-
-			servicePort := corev1.ServicePort{
-				Name:       p.Name,
-				Port:       p.Port,
-				TargetPort: intstr.FromInt(int(p.TargetPort)),
-				Protocol:   protocol,
-			}
-
-			if p.NodePort > 0 {
-				servicePort.NodePort = p.NodePort
-			}
-
-			servicePorts = append(servicePorts, servicePort)
-		}
-		existing.Spec.Ports = servicePorts
-	}
-
-	if updateRequest.Selector != nil {
-		existing.Spec.Selector = updateRequest.Selector
-	}
-
-	if updateRequest.Labels != nil {
-		existing.Labels = updateRequest.Labels
-	}
-
-	if updateRequest.ExternalIPs != nil {
-		existing.Spec.ExternalIPs = updateRequest.ExternalIPs
+	if err := updateRequest.Apply(existing); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
 	}
 
 	result, err := h.api.UpdateService(c.Request.Context(), namespace, existing)
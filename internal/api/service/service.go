@@ -4,9 +4,15 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/kubernetes"
 
 	"k8s-glance-backend/internal/api/base"
@@ -18,12 +24,256 @@ type ServiceAPI struct {
 }
 
 // NewServiceAPI creates a new ServiceAPI instance
-func NewServiceAPI(clientset *kubernetes.Clientset, logger *log.Logger) *ServiceAPI {
+func NewServiceAPI(clientset kubernetes.Interface, logger *log.Logger) *ServiceAPI {
 	return &ServiceAPI{
 		BaseAPI: base.NewBaseAPI(clientset, logger),
 	}
 }
 
+// ServicePortRequest describes a service port. TargetPort accepts a number or the name of a container
+// port and defaults to Port when omitted.
+type ServicePortRequest struct {
+	Name        string              `json:"name"`
+	Port        int32               `json:"port"`
+	TargetPort  *intstr.IntOrString `json:"targetPort"`
+	NodePort    int32               `json:"nodePort,omitempty"`
+	Protocol    string              `json:"protocol,omitempty"`
+	AppProtocol *string             `json:"appProtocol,omitempty"`
+}
+
+// ServiceRequest describes a service to create or the fields of one to update; unset fields are left
+// unchanged. A ClusterIP of "None" makes the service headless.
+type ServiceRequest struct {
+	Type                          string               `json:"type"`
+	Ports                         []ServicePortRequest `json:"ports"`
+	Selector                      map[string]string    `json:"selector"`
+	ClusterIP                     *string              `json:"clusterIP"`
+	ExternalName                  *string              `json:"externalName"`
+	ExternalIPs                   []string             `json:"externalIPs"`
+	SessionAffinity               string               `json:"sessionAffinity"`
+	SessionAffinityTimeoutSeconds *int32               `json:"sessionAffinityTimeoutSeconds"`
+	ExternalTrafficPolicy         string               `json:"externalTrafficPolicy"`
+	LoadBalancerSourceRanges      []string             `json:"loadBalancerSourceRanges"`
+	IPFamilies                    []string             `json:"ipFamilies"`
+	IPFamilyPolicy                string               `json:"ipFamilyPolicy"`
+	Labels                        map[string]string    `json:"labels"`
+	Annotations                   map[string]string    `json:"annotations"`
+}
+
+var (
+	serviceTypes = map[string]bool{
+		string(corev1.ServiceTypeClusterIP):    true,
+		string(corev1.ServiceTypeNodePort):     true,
+		string(corev1.ServiceTypeLoadBalancer): true,
+		string(corev1.ServiceTypeExternalName): true,
+	}
+	serviceProtocols = map[string]bool{
+		string(corev1.ProtocolTCP):  true,
+		string(corev1.ProtocolUDP):  true,
+		string(corev1.ProtocolSCTP): true,
+	}
+	ipFamilyPolicies = map[string]bool{
+		string(corev1.IPFamilyPolicySingleStack):      true,
+		string(corev1.IPFamilyPolicyPreferDualStack):  true,
+		string(corev1.IPFamilyPolicyRequireDualStack): true,
+	}
+)
+
+// maxSessionAffinityTimeout is the longest ClientIP affinity the API server accepts (one day)
+const maxSessionAffinityTimeout = 86400
+
+// Validate checks the request fields that are set
+func (r *ServiceRequest) Validate() error {
+	if r.Type != "" && !serviceTypes[r.Type] {
+		return fmt.Errorf("type must be one of ClusterIP, NodePort, LoadBalancer or ExternalName")
+	}
+
+	names := make(map[string]bool)
+	for i, port := range r.Ports {
+		if port.Port < 1 || port.Port > 65535 {
+			return fmt.Errorf("ports[%d].port must be between 1 and 65535", i)
+		}
+		if len(r.Ports) > 1 && port.Name == "" {
+			return fmt.Errorf("ports[%d].name is required when the service has more than one port", i)
+		}
+		if port.Name != "" {
+			if names[port.Name] {
+				return fmt.Errorf("ports[%d].name %q is used more than once", i, port.Name)
+			}
+			names[port.Name] = true
+		}
+		if port.TargetPort != nil {
+			if port.TargetPort.Type == intstr.String {
+				if errs := validation.IsValidPortName(port.TargetPort.StrVal); len(errs) > 0 {
+					return fmt.Errorf("ports[%d].targetPort %q is not a valid port name: %s", i, port.TargetPort.StrVal, strings.Join(errs, "; "))
+				}
+			} else if port.TargetPort.IntVal < 1 || port.TargetPort.IntVal > 65535 {
+				return fmt.Errorf("ports[%d].targetPort must be between 1 and 65535", i)
+			}
+		}
+		if port.NodePort < 0 || port.NodePort > 65535 {
+			return fmt.Errorf("ports[%d].nodePort must be between 1 and 65535", i)
+		}
+		if port.Protocol != "" && !serviceProtocols[port.Protocol] {
+			return fmt.Errorf("ports[%d].protocol must be one of TCP, UDP or SCTP", i)
+		}
+	}
+
+	if r.ClusterIP != nil && *r.ClusterIP != "" && *r.ClusterIP != corev1.ClusterIPNone && net.ParseIP(*r.ClusterIP) == nil {
+		return fmt.Errorf("clusterIP must be an IP address or %q", corev1.ClusterIPNone)
+	}
+	if r.ExternalName != nil && *r.ExternalName != "" {
+		if errs := validation.IsDNS1123Subdomain(*r.ExternalName); len(errs) > 0 {
+			return fmt.Errorf("externalName must be a DNS name: %s", strings.Join(errs, "; "))
+		}
+	}
+	for _, ip := range r.ExternalIPs {
+		if net.ParseIP(ip) == nil {
+			return fmt.Errorf("externalIPs: %q is not an IP address", ip)
+		}
+	}
+
+	if r.SessionAffinity != "" && r.SessionAffinity != string(corev1.ServiceAffinityNone) && r.SessionAffinity != string(corev1.ServiceAffinityClientIP) {
+		return fmt.Errorf("sessionAffinity must be None or ClientIP")
+	}
+	if r.SessionAffinityTimeoutSeconds != nil && (*r.SessionAffinityTimeoutSeconds < 1 || *r.SessionAffinityTimeoutSeconds > maxSessionAffinityTimeout) {
+		return fmt.Errorf("sessionAffinityTimeoutSeconds must be between 1 and %d", maxSessionAffinityTimeout)
+	}
+	if r.ExternalTrafficPolicy != "" && r.ExternalTrafficPolicy != string(corev1.ServiceExternalTrafficPolicyCluster) && r.ExternalTrafficPolicy != string(corev1.ServiceExternalTrafficPolicyLocal) {
+		return fmt.Errorf("externalTrafficPolicy must be Cluster or Local")
+	}
+	for _, cidr := range r.LoadBalancerSourceRanges {
+		if _, _, err := net.ParseCIDR(strings.TrimSpace(cidr)); err != nil {
+			return fmt.Errorf("loadBalancerSourceRanges: %q is not a CIDR", cidr)
+		}
+	}
+
+	if len(r.IPFamilies) > 2 {
+		return fmt.Errorf("ipFamilies accepts at most two families")
+	}
+	for i, family := range r.IPFamilies {
+		if family != string(corev1.IPv4Protocol) && family != string(corev1.IPv6Protocol) {
+			return fmt.Errorf("ipFamilies must contain IPv4 or IPv6")
+		}
+		if i > 0 && family == r.IPFamilies[0] {
+			return fmt.Errorf("ipFamilies must not repeat a family")
+		}
+	}
+	if r.IPFamilyPolicy != "" && !ipFamilyPolicies[r.IPFamilyPolicy] {
+		return fmt.Errorf("ipFamilyPolicy must be one of SingleStack, PreferDualStack or RequireDualStack")
+	}
+
+	return nil
+}
+
+// Apply sets the requested fields on the service and checks that the resulting spec is a valid
+// combination. Fields that only apply to the previous type are cleared when the type changes.
+func (r *ServiceRequest) Apply(service *corev1.Service) error {
+	spec := &service.Spec
+
+	if r.Type != "" && corev1.ServiceType(r.Type) != spec.Type {
+		spec.Type = corev1.ServiceType(r.Type)
+		if !exposesNodePorts(spec.Type) {
+			for i := range spec.Ports {
+				spec.Ports[i].NodePort = 0
+			}
+			spec.ExternalTrafficPolicy = ""
+		}
+		if spec.Type != corev1.ServiceTypeLoadBalancer {
+			spec.LoadBalancerSourceRanges = nil
+		}
+		if spec.Type == corev1.ServiceTypeExternalName {
+			// An ExternalName service is a DNS alias without ports or endpoints; requests
+			// that still send ports or a selector are rejected by the validation below
+			spec.Ports = nil
+			spec.Selector = nil
+			spec.ClusterIP = ""
+			spec.ClusterIPs = nil
+			spec.IPFamilies = nil
+			spec.IPFamilyPolicy = nil
+		} else {
+			spec.ExternalName = ""
+		}
+	}
+	if spec.Type == "" {
+		spec.Type = corev1.ServiceTypeClusterIP
+	}
+
+	if r.Ports != nil {
+		ports := make([]corev1.ServicePort, 0, len(r.Ports))
+		for _, p := range r.Ports {
+			protocol := corev1.ProtocolTCP
+			if p.Protocol != "" {
+				protocol = corev1.Protocol(p.Protocol)
+			}
+			targetPort := intstr.FromInt32(p.Port)
+			if p.TargetPort != nil {
+				targetPort = *p.TargetPort
+			}
+			ports = append(ports, corev1.ServicePort{
+				Name:        p.Name,
+				Port:        p.Port,
+				TargetPort:  targetPort,
+				NodePort:    p.NodePort,
+				Protocol:    protocol,
+				AppProtocol: p.AppProtocol,
+			})
+		}
+		spec.Ports = ports
+	}
+	if r.Selector != nil {
+		spec.Selector = r.Selector
+	}
+	if r.ClusterIP != nil && *r.ClusterIP != spec.ClusterIP {
+		if spec.ClusterIP != "" && service.ResourceVersion != "" {
+			return fmt.Errorf("clusterIP is immutable; delete and recreate the service to change it")
+		}
+		spec.ClusterIP = *r.ClusterIP
+		spec.ClusterIPs = nil
+	}
+	if r.ExternalName != nil {
+		spec.ExternalName = *r.ExternalName
+	}
+	if r.ExternalIPs != nil {
+		spec.ExternalIPs = r.ExternalIPs
+	}
+	if r.SessionAffinity != "" {
+		spec.SessionAffinity = corev1.ServiceAffinity(r.SessionAffinity)
+		if spec.SessionAffinity == corev1.ServiceAffinityNone {
+			spec.SessionAffinityConfig = nil
+		}
+	}
+	if r.SessionAffinityTimeoutSeconds != nil {
+		spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+			ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: r.SessionAffinityTimeoutSeconds},
+		}
+	}
+	if r.ExternalTrafficPolicy != "" {
+		spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicy(r.ExternalTrafficPolicy)
+	}
+	if r.LoadBalancerSourceRanges != nil {
+		spec.LoadBalancerSourceRanges = r.LoadBalancerSourceRanges
+	}
+	if r.IPFamilies != nil {
+		spec.IPFamilies = nil
+		for _, family := range r.IPFamilies {
+			spec.IPFamilies = append(spec.IPFamilies, corev1.IPFamily(family))
+		}
+	}
+	if r.IPFamilyPolicy != "" {
+		policy := corev1.IPFamilyPolicy(r.IPFamilyPolicy)
+		spec.IPFamilyPolicy = &policy
+	}
+	if r.Labels != nil {
+		service.Labels = r.Labels
+	}
+	if r.Annotations != nil {
+		service.Annotations = r.Annotations
+	}
+
+	return validateServiceSpec(spec)
+}
+
 // ListServices returns all services in a namespace
 func (api *ServiceAPI) ListServices(ctx context.Context, namespace string) (*corev1.ServiceList, error) {
 	api.LogInfo(ctx, "ListServices", fmt.Sprintf("Fetching services in namespace: %s", namespace))
@@ -89,7 +339,9 @@ func (api *ServiceAPI) DeleteService(ctx context.Context, namespace, name string
 	return nil
 }
 
-// GetServiceStatus returns the status and endpoints of a service
+// GetServiceStatus returns the status of a service with its EndpointSlice endpoints and the pods its selector matches.
+// Issues lists problems that keep traffic from reaching pods: no matching pods, no ready pods, or a named
+// targetPort that the matched containers do not declare.
 func (api *ServiceAPI) GetServiceStatus(ctx context.Context, namespace, name string) (*base.APIResponse, error) {
 	api.LogInfo(ctx, "GetServiceStatus", fmt.Sprintf("Fetching status for service %s in namespace %s", name, namespace))
 
@@ -99,11 +351,19 @@ func (api *ServiceAPI) GetServiceStatus(ctx context.Context, namespace, name str
 		return nil, err
 	}
 
-	// Get endpoints for the service
-	endpoints, err := api.GetClientset().CoreV1().Endpoints(namespace).Get(ctx, name, metav1.GetOptions{})
+	// Get the EndpointSlices managed for the service
+	slices, err := api.GetClientset().DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set{discoveryv1.LabelServiceName: name}.String(),
+	})
 	if err != nil {
 		api.LogError(ctx, "GetServiceStatus", err)
-		return nil, api.HandleError(err, "get service endpoints")
+		return nil, api.HandleError(err, "list endpointslices")
+	}
+
+	pods, err := ListSelectedPods(ctx, api.GetClientset(), service)
+	if err != nil {
+		api.LogError(ctx, "GetServiceStatus", err)
+		return nil, api.HandleError(err, "list pods")
 	}
 
 	// Collect LoadBalancer status if applicable
@@ -117,6 +377,9 @@ func (api *ServiceAPI) GetServiceStatus(ctx context.Context, namespace, name str
 		}
 	}
 
+	endpoints, counts := getSliceEndpoints(slices.Items)
+	issues := getServiceIssues(service, pods)
+
 	// Build detailed status response
 	status := map[string]interface{}{
 		"type":            service.Spec.Type,
@@ -124,9 +387,13 @@ func (api *ServiceAPI) GetServiceStatus(ctx context.Context, namespace, name str
 		"externalIPs":     service.Spec.ExternalIPs,
 		"loadBalancer":    lbStatus,
 		"ports":           getServicePorts(service.Spec.Ports),
-		"endpoints":       getEndpointAddresses(endpoints),
+		"endpoints":       endpoints,
+		"endpointCounts":  counts,
 		"selector":        service.Spec.Selector,
+		"matchedPods":     GetMatchedPods(pods),
 		"sessionAffinity": string(service.Spec.SessionAffinity),
+		"issues":          issues,
+		"healthy":         len(issues) == 0,
 	}
 
 	response := base.NewSuccessResponse(status)
@@ -149,17 +416,227 @@ func getServicePorts(ports []corev1.ServicePort) []map[string]interface{} {
 	return result
 }
 
-func getEndpointAddresses(endpoints *corev1.Endpoints) []map[string]interface{} {
-	var result []map[string]interface{}
-	for _, subset := range endpoints.Subsets {
-		for _, addr := range subset.Addresses {
-			result = append(result, map[string]interface{}{
-				"ip":        addr.IP,
-				"hostname":  addr.Hostname,
-				"nodeName":  addr.NodeName,
-				"targetRef": addr.TargetRef,
-			})
+// getSliceEndpoints flattens the endpoints of all slices and counts them by condition.
+// Unset ready and serving conditions mean unknown, which consumers treat as true.
+func getSliceEndpoints(slices []discoveryv1.EndpointSlice) ([]map[string]interface{}, map[string]int) {
+	result := []map[string]interface{}{}
+	counts := map[string]int{"total": 0, "ready": 0, "serving": 0, "terminating": 0}
+
+	for _, slice := range slices {
+		var ports []map[string]interface{}
+		for _, port := range slice.Ports {
+			entry := map[string]interface{}{"port": port.Port, "protocol": port.Protocol}
+			if port.Name != nil {
+				entry["name"] = *port.Name
+			}
+			ports = append(ports, entry)
+		}
+
+		for _, endpoint := range slice.Endpoints {
+			ready := endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+			serving := endpoint.Conditions.Serving == nil || *endpoint.Conditions.Serving
+			terminating := endpoint.Conditions.Terminating != nil && *endpoint.Conditions.Terminating
+
+			entry := map[string]interface{}{
+				"addresses":   endpoint.Addresses,
+				"addressType": slice.AddressType,
+				"ready":       ready,
+				"serving":     serving,
+				"terminating": terminating,
+				"ports":       ports,
+				"slice":       slice.Name,
+			}
+			if endpoint.TargetRef != nil && endpoint.TargetRef.Kind == "Pod" {
+				entry["pod"] = endpoint.TargetRef.Name
+			}
+			if endpoint.NodeName != nil {
+				entry["node"] = *endpoint.NodeName
+			}
+			if endpoint.Zone != nil {
+				entry["zone"] = *endpoint.Zone
+			}
+			if endpoint.Hostname != nil {
+				entry["hostname"] = *endpoint.Hostname
+			}
+			result = append(result, entry)
+
+			counts["total"]++
+			if ready {
+				counts["ready"]++
+			}
+			if serving {
+				counts["serving"]++
+			}
+			if terminating {
+				counts["terminating"]++
+			}
 		}
 	}
+	return result, counts
+}
+
+// ListSelectedPods returns the pods matched by the selector of a service. Services without a
+// selector have manually managed endpoints and match no pods.
+func ListSelectedPods(ctx context.Context, clientset kubernetes.Interface, service *corev1.Service) ([]corev1.Pod, error) {
+	if len(service.Spec.Selector) == 0 {
+		return nil, nil
+	}
+	pods, err := clientset.CoreV1().Pods(service.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+// GetMatchedPods summarises pods selected by a service
+func GetMatchedPods(pods []corev1.Pod) []map[string]interface{} {
+	result := []map[string]interface{}{}
+	for i := range pods {
+		result = append(result, map[string]interface{}{
+			"name":  pods[i].Name,
+			"phase": pods[i].Status.Phase,
+			"ready": IsPodReady(&pods[i]),
+			"node":  pods[i].Spec.NodeName,
+			"ip":    pods[i].Status.PodIP,
+		})
+	}
 	return result
 }
+
+// getServiceIssues checks the selector and named target ports against the matched pods.
+// Services without a selector have manually managed endpoints and are not checked.
+func getServiceIssues(service *corev1.Service, pods []corev1.Pod) []string {
+	issues := []string{}
+	if service.Spec.Type == corev1.ServiceTypeExternalName || len(service.Spec.Selector) == 0 {
+		return issues
+	}
+
+	if len(pods) == 0 {
+		return append(issues, fmt.Sprintf("selector %s matches no pods", labels.SelectorFromSet(service.Spec.Selector)))
+	}
+
+	ready := 0
+	for i := range pods {
+		if IsPodReady(&pods[i]) {
+			ready++
+		}
+	}
+	if ready == 0 {
+		issues = append(issues, fmt.Sprintf("selector matches %d pod(s) but none are ready", len(pods)))
+	}
+
+	for _, port := range service.Spec.Ports {
+		if port.TargetPort.Type != intstr.String {
+			continue
+		}
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+
+		var missing []string
+		for i := range pods {
+			if !hasNamedPort(&pods[i], port.TargetPort.StrVal, protocol) {
+				missing = append(missing, pods[i].Name)
+			}
+		}
+		if len(missing) > 0 {
+			issues = append(issues, fmt.Sprintf("port %s targetPort %q is not declared by %d of %d matched pod(s): %s",
+				servicePortName(port), port.TargetPort.StrVal, len(missing), len(pods), strings.Join(missing, ", ")))
+		}
+	}
+
+	return issues
+}
+
+func hasNamedPort(pod *corev1.Pod, name string, protocol corev1.Protocol) bool {
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			portProtocol := port.Protocol
+			if portProtocol == "" {
+				portProtocol = corev1.ProtocolTCP
+			}
+			if port.Name == name && portProtocol == protocol {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateServiceSpec checks the field combinations the API server would reject, so the
+// caller gets a precise message instead of a generic invalid-object error
+func validateServiceSpec(spec *corev1.ServiceSpec) error {
+	headless := spec.ClusterIP == corev1.ClusterIPNone
+
+	if spec.Type == corev1.ServiceTypeExternalName {
+		switch {
+		case spec.ExternalName == "":
+			return fmt.Errorf("externalName is required for ExternalName services")
+		case len(spec.Ports) > 0:
+			return fmt.Errorf("ExternalName services must not define ports")
+		case len(spec.Selector) > 0:
+			return fmt.Errorf("ExternalName services must not define a selector")
+		case spec.ClusterIP != "":
+			return fmt.Errorf("ExternalName services must not set clusterIP")
+		case len(spec.IPFamilies) > 0 || spec.IPFamilyPolicy != nil:
+			return fmt.Errorf("ExternalName services must not set ipFamilies or ipFamilyPolicy")
+		}
+	} else {
+		if spec.ExternalName != "" {
+			return fmt.Errorf("externalName is only allowed on ExternalName services")
+		}
+		if len(spec.Ports) == 0 && !headless {
+			return fmt.Errorf("at least one port is required unless the service is headless")
+		}
+	}
+
+	if headless && spec.Type != corev1.ServiceTypeClusterIP {
+		return fmt.Errorf("headless services (clusterIP: None) must be of type ClusterIP")
+	}
+	if !exposesNodePorts(spec.Type) {
+		for _, port := range spec.Ports {
+			if port.NodePort != 0 {
+				return fmt.Errorf("nodePort is only allowed on NodePort and LoadBalancer services")
+			}
+		}
+		if spec.ExternalTrafficPolicy != "" {
+			return fmt.Errorf("externalTrafficPolicy is only allowed on NodePort and LoadBalancer services")
+		}
+	}
+	if len(spec.LoadBalancerSourceRanges) > 0 && spec.Type != corev1.ServiceTypeLoadBalancer {
+		return fmt.Errorf("loadBalancerSourceRanges is only allowed on LoadBalancer services")
+	}
+	if spec.SessionAffinityConfig != nil && spec.SessionAffinity != corev1.ServiceAffinityClientIP {
+		return fmt.Errorf("sessionAffinityTimeoutSeconds requires sessionAffinity ClientIP")
+	}
+	// The API server defaults a missing policy to SingleStack
+	if len(spec.IPFamilies) == 2 && (spec.IPFamilyPolicy == nil || *spec.IPFamilyPolicy == corev1.IPFamilyPolicySingleStack) {
+		return fmt.Errorf("two ipFamilies require ipFamilyPolicy PreferDualStack or RequireDualStack")
+	}
+
+	return nil
+}
+
+func exposesNodePorts(serviceType corev1.ServiceType) bool {
+	return serviceType == corev1.ServiceTypeNodePort || serviceType == corev1.ServiceTypeLoadBalancer
+}
+
+func servicePortName(port corev1.ServicePort) string {
+	if port.Name != "" {
+		return port.Name
+	}
+	return fmt.Sprintf("%d", port.Port)
+}
+
+// IsPodReady reports whether the Ready condition of the pod is true
+func IsPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
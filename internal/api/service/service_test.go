@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testPod(name string, ready bool, portName string) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "web"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "web"}}},
+		Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}},
+	}
+	if portName != "" {
+		pod.Spec.Containers[0].Ports = []corev1.ContainerPort{{Name: portName, ContainerPort: 8080}}
+	}
+	return pod
+}
+
+func testService(targetPort intstr.IntOrString) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "web"},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: targetPort}},
+		},
+	}
+}
+
+func TestGetServiceStatus(t *testing.T) {
+	notReady := false
+	terminating := true
+	node := "node-1"
+	zone := "zone-a"
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta:  metav1.ObjectMeta{Name: "web-abc", Namespace: "default", Labels: map[string]string{discoveryv1.LabelServiceName: "web"}},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{
+			{
+				Addresses: []string{"10.0.0.1"},
+				TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "web-1"},
+				NodeName:  &node,
+				Zone:      &zone,
+			},
+			{
+				Addresses:  []string{"10.0.0.2"},
+				Conditions: discoveryv1.EndpointConditions{Ready: &notReady, Terminating: &terminating},
+				TargetRef:  &corev1.ObjectReference{Kind: "Pod", Name: "web-2"},
+			},
+		},
+	}
+	other := slice.DeepCopy()
+	other.Name = "api-abc"
+	other.Labels = map[string]string{discoveryv1.LabelServiceName: "api"}
+
+	api := NewServiceAPI(fake.NewSimpleClientset(
+		testService(intstr.FromString("http")),
+		slice, other,
+		testPod("web-1", true, "http"),
+		testPod("web-2", false, "web"),
+	), log.New(io.Discard, "", 0))
+
+	response, err := api.GetServiceStatus(context.Background(), "default", "web")
+	if err != nil {
+		t.Fatalf("GetServiceStatus: %v", err)
+	}
+	status := response.Data.(map[string]interface{})
+
+	endpoints := status["endpoints"].([]map[string]interface{})
+	if len(endpoints) != 2 {
+		t.Fatalf("endpoints = %v, want only the slice for web", endpoints)
+	}
+	if endpoints[0]["pod"] != "web-1" || endpoints[0]["node"] != "node-1" || endpoints[0]["zone"] != "zone-a" || endpoints[0]["ready"] != true {
+		t.Errorf("first endpoint = %v", endpoints[0])
+	}
+	if endpoints[1]["ready"] != false || endpoints[1]["serving"] != true || endpoints[1]["terminating"] != true {
+		t.Errorf("second endpoint = %v", endpoints[1])
+	}
+	counts := status["endpointCounts"].(map[string]int)
+	if counts["total"] != 2 || counts["ready"] != 1 || counts["terminating"] != 1 {
+		t.Errorf("endpointCounts = %v", counts)
+	}
+
+	issues := status["issues"].([]string)
+	if len(issues) != 1 || !strings.Contains(issues[0], `targetPort "http"`) || !strings.Contains(issues[0], "web-2") {
+		t.Errorf("issues = %v, want the missing named port on web-2", issues)
+	}
+}
+
+func TestGetServiceIssues(t *testing.T) {
+	tests := []struct {
+		name    string
+		service *corev1.Service
+		pods    []corev1.Pod
+		want    string
+	}{
+		{"no pods", testService(intstr.FromInt(8080)), nil, "matches no pods"},
+		{"none ready", testService(intstr.FromInt(8080)), []corev1.Pod{*testPod("web-1", false, "")}, "none are ready"},
+		{"healthy", testService(intstr.FromString("http")), []corev1.Pod{*testPod("web-1", true, "http")}, ""},
+		{"selectorless", &corev1.Service{Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 80}}}}, nil, ""},
+	}
+
+	for _, tt := range tests {
+		issues := getServiceIssues(tt.service, tt.pods)
+		if tt.want == "" {
+			if len(issues) != 0 {
+				t.Errorf("%s: issues = %v, want none", tt.name, issues)
+			}
+			continue
+		}
+		if len(issues) != 1 || !strings.Contains(issues[0], tt.want) {
+			t.Errorf("%s: issues = %v, want %q", tt.name, issues, tt.want)
+		}
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func TestServiceRequestValidate(t *testing.T) {
+	named := intstr.FromString("http")
+	badName := intstr.FromString("not_a_port_name")
+
+	if err := (&ServiceRequest{Ports: []ServicePortRequest{{Port: 80, TargetPort: &named}}}).Validate(); err != nil {
+		t.Errorf("named targetPort should be valid: %v", err)
+	}
+
+	invalid := []ServiceRequest{
+		{Type: "Internal"},
+		{Ports: []ServicePortRequest{{Port: 0}}},
+		{Ports: []ServicePortRequest{{Port: 80, TargetPort: &badName}}},
+		{Ports: []ServicePortRequest{{Name: "http", Port: 80}, {Port: 443}}},
+		{Ports: []ServicePortRequest{{Port: 80, Protocol: "ICMP"}}},
+		{ClusterIP: stringPtr("headless")},
+		{SessionAffinity: "Cookie"},
+		{ExternalTrafficPolicy: "Nearest"},
+		{LoadBalancerSourceRanges: []string{"10.0.0.1"}},
+		{IPFamilies: []string{"IPv4", "IPv4"}},
+	}
+	for _, req := range invalid {
+		if err := req.Validate(); err == nil {
+			t.Errorf("Validate(%+v) should fail", req)
+		}
+	}
+}
+
+func TestServiceRequestApply(t *testing.T) {
+	named := intstr.FromString("http")
+
+	service := &corev1.Service{}
+	req := ServiceRequest{Ports: []ServicePortRequest{{Port: 80, TargetPort: &named}, {Port: 9090}}}
+	if err := req.Apply(service); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if service.Spec.Type != corev1.ServiceTypeClusterIP || service.Spec.Ports[0].TargetPort != named || service.Spec.Ports[1].TargetPort != intstr.FromInt32(9090) {
+		t.Errorf("spec = %+v", service.Spec)
+	}
+
+	invalid := []ServiceRequest{
+		{Type: "ClusterIP", Ports: []ServicePortRequest{{Port: 80, NodePort: 30080}}},
+		{Type: "ExternalName", ExternalName: stringPtr("db.example.com"), Ports: []ServicePortRequest{{Port: 5432}}},
+		{Type: "ExternalName"},
+		{Type: "NodePort", ClusterIP: stringPtr(corev1.ClusterIPNone), Ports: []ServicePortRequest{{Port: 80}}},
+		{Type: "NodePort", Ports: []ServicePortRequest{{Port: 80}}, LoadBalancerSourceRanges: []string{"10.0.0.0/8"}},
+		{Ports: []ServicePortRequest{{Port: 80}}, ExternalTrafficPolicy: "Local"},
+		{Ports: []ServicePortRequest{{Port: 80}}, SessionAffinityTimeoutSeconds: int32Ptr(60)},
+		{Ports: []ServicePortRequest{{Port: 80}}, IPFamilies: []string{"IPv4", "IPv6"}},
+		{},
+	}
+	for _, req := range invalid {
+		if err := req.Apply(&corev1.Service{}); err == nil {
+			t.Errorf("Apply(%+v) should fail", req)
+		}
+	}
+
+	valid := []ServiceRequest{
+		{ClusterIP: stringPtr(corev1.ClusterIPNone)},
+		{Type: "ExternalName", ExternalName: stringPtr("db.example.com")},
+		{Type: "LoadBalancer", Ports: []ServicePortRequest{{Port: 443, NodePort: 30443}}, ExternalTrafficPolicy: "Local", LoadBalancerSourceRanges: []string{"10.0.0.0/8"}},
+		{Ports: []ServicePortRequest{{Port: 80}}, IPFamilies: []string{"IPv4", "IPv6"}, IPFamilyPolicy: "PreferDualStack"},
+	}
+	for _, req := range valid {
+		if err := req.Apply(&corev1.Service{}); err != nil {
+			t.Errorf("Apply(%+v): %v", req, err)
+		}
+	}
+
+	// Switching an existing NodePort service to ExternalName drops the fields that no longer apply
+	existing := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{ResourceVersion: "1"},
+		Spec: corev1.ServiceSpec{
+			Type:                  corev1.ServiceTypeNodePort,
+			ClusterIP:             "10.96.0.10",
+			ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyLocal,
+			Ports:                 []corev1.ServicePort{{Port: 80, NodePort: 30080}},
+			Selector:              map[string]string{"app": "db"},
+		},
+	}
+	req = ServiceRequest{Type: "ExternalName", ExternalName: stringPtr("db.example.com")}
+	if err := req.Apply(existing); err != nil {
+		t.Fatalf("Apply type change: %v", err)
+	}
+	if existing.Spec.ClusterIP != "" || existing.Spec.ExternalTrafficPolicy != "" {
+		t.Errorf("spec = %+v, want clusterIP and externalTrafficPolicy cleared", existing.Spec)
+	}
+	if existing.Spec.Ports != nil || existing.Spec.Selector != nil {
+		t.Errorf("spec = %+v, want ports and selector cleared", existing.Spec)
+	}
+	if err := (&ServiceRequest{ClusterIP: stringPtr("10.96.0.20")}).Apply(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{ResourceVersion: "1"},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ClusterIP: "10.96.0.10", Ports: []corev1.ServicePort{{Port: 80}}},
+	}); err == nil {
+		t.Error("changing clusterIP should fail")
+	}
+}
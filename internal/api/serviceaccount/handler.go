@@ -0,0 +1,186 @@
+package serviceaccount
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type Handler struct {
+	api *ServiceAccountAPI
+}
+
+func NewHandler(clientset *kubernetes.Clientset, logger *log.Logger) *Handler {
+	if logger == nil {
+		logger = log.New(gin.DefaultWriter, "[SERVICEACCOUNT-API] ", log.LstdFlags)
+	}
+
+	return &Handler{
+		api: NewServiceAccountAPI(clientset, logger),
+	}
+}
+
+// ListServiceAccounts handles GET /api/v1/serviceaccounts/namespaces/:namespace
+func (h *Handler) ListServiceAccounts(c *gin.Context) {
+	namespace := c.Param("namespace")
+	accounts, err := h.api.ListServiceAccounts(c.Request.Context(), namespace)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    accounts,
+	})
+}
+
+// GetServiceAccount handles GET /api/v1/serviceaccounts/namespaces/:namespace/:name
+func (h *Handler) GetServiceAccount(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	details, err := h.api.GetServiceAccountDetails(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, details)
+}
+
+// CreateServiceAccount handles POST /api/v1/serviceaccounts/namespaces/:namespace
+func (h *Handler) CreateServiceAccount(c *gin.Context) {
+	var createRequest struct {
+		Name                         string            `json:"name" binding:"required"`
+		AutomountServiceAccountToken *bool             `json:"automountServiceAccountToken"`
+		ImagePullSecrets             []string          `json:"imagePullSecrets"`
+		Labels                       map[string]string `json:"labels"`
+		Annotations                  map[string]string `json:"annotations"`
+	}
+
+	if err := c.ShouldBindJSON(&createRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	namespace := c.Param("namespace")
+
+	account := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        createRequest.Name,
+			Namespace:   namespace,
+			Labels:      createRequest.Labels,
+			Annotations: createRequest.Annotations,
+		},
+		AutomountServiceAccountToken: createRequest.AutomountServiceAccountToken,
+	}
+	for _, secret := range createRequest.ImagePullSecrets {
+		account.ImagePullSecrets = append(account.ImagePullSecrets, corev1.LocalObjectReference{Name: secret})
+	}
+
+	result, err := h.api.CreateServiceAccount(c.Request.Context(), namespace, account)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"name":      result.Name,
+			"namespace": result.Namespace,
+			"status":    "created",
+		},
+	})
+}
+
+// DeleteServiceAccount handles DELETE /api/v1/serviceaccounts/namespaces/:namespace/:name
+func (h *Handler) DeleteServiceAccount(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	err := h.api.DeleteServiceAccount(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "ServiceAccount deleted successfully",
+	})
+}
+
+// CreateToken handles POST /api/v1/serviceaccounts/namespaces/:namespace/:name/token
+func (h *Handler) CreateToken(c *gin.Context) {
+	var tokenRequest struct {
+		Audiences         []string `json:"audiences"`
+		ExpirationSeconds int64    `json:"expirationSeconds"`
+		Reason            string   `json:"reason"`
+	}
+
+	if err := c.ShouldBindJSON(&tokenRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	requester := Requester{
+		ClientIP:   c.ClientIP(),
+		RemoteAddr: c.Request.RemoteAddr,
+		UserAgent:  c.Request.UserAgent(),
+		Reason:     tokenRequest.Reason,
+	}
+	expiration := time.Duration(tokenRequest.ExpirationSeconds) * time.Second
+
+	result, err := h.api.CreateToken(c.Request.Context(), namespace, name, tokenRequest.Audiences, expiration, requester)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidTokenRequest) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Tokens must not be cached by browsers or proxies
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"token":               result.Status.Token,
+			"expirationTimestamp": result.Status.ExpirationTimestamp,
+			"audiences":           result.Spec.Audiences,
+		},
+	})
+}
@@ -0,0 +1,272 @@
+package serviceaccount
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s-glance-backend/internal/api/base"
+	"k8s-glance-backend/internal/api/rbac"
+)
+
+const (
+	// MinTokenExpiration is the shortest lifetime the API server accepts for a requested token
+	MinTokenExpiration = 10 * time.Minute
+	// MaxTokenExpiration caps minted tokens so CI credentials stay short-lived
+	MaxTokenExpiration = 24 * time.Hour
+	// DefaultTokenExpiration is used when the request does not ask for a lifetime
+	DefaultTokenExpiration = time.Hour
+)
+
+// ErrInvalidTokenRequest is returned when a token request has no audience or an out-of-range expiration
+var ErrInvalidTokenRequest = errors.New("invalid token request")
+
+// ServiceAccountAPI handles service account operations
+type ServiceAccountAPI struct {
+	*base.BaseAPI
+}
+
+// NewServiceAccountAPI creates a new ServiceAccountAPI instance
+func NewServiceAccountAPI(clientset kubernetes.Interface, logger *log.Logger) *ServiceAccountAPI {
+	return &ServiceAccountAPI{
+		BaseAPI: base.NewBaseAPI(clientset, logger),
+	}
+}
+
+// Requester describes who asked for a token, for the audit log. ClientIP may come from
+// forwarding headers; RemoteAddr is the address of the connection and cannot be spoofed.
+type Requester struct {
+	ClientIP   string
+	RemoteAddr string
+	UserAgent  string
+	Reason     string
+}
+
+// ListServiceAccounts returns the service accounts in a namespace with the number of pods using each
+func (api *ServiceAccountAPI) ListServiceAccounts(ctx context.Context, namespace string) ([]map[string]interface{}, error) {
+	api.LogInfo(ctx, "ListServiceAccounts", fmt.Sprintf("Fetching serviceaccounts in namespace: %s", namespace))
+
+	accounts, err := api.GetClientset().CoreV1().ServiceAccounts(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListServiceAccounts", err)
+		return nil, api.HandleError(err, "list serviceaccounts")
+	}
+
+	pods, err := api.GetClientset().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListServiceAccounts", err)
+		return nil, api.HandleError(err, "list pods")
+	}
+	podCounts := make(map[string]int)
+	for i := range pods.Items {
+		podCounts[podServiceAccount(&pods.Items[i])]++
+	}
+
+	result := make([]map[string]interface{}, 0, len(accounts.Items))
+	for _, account := range accounts.Items {
+		result = append(result, map[string]interface{}{
+			"name":                         account.Name,
+			"namespace":                    account.Namespace,
+			"automountServiceAccountToken": automountEnabled(account.AutomountServiceAccountToken),
+			"imagePullSecrets":             secretNames(account.ImagePullSecrets),
+			"podCount":                     podCounts[account.Name],
+			"creationTime":                 account.CreationTimestamp,
+		})
+	}
+
+	return result, nil
+}
+
+// GetServiceAccount returns a specific service account
+func (api *ServiceAccountAPI) GetServiceAccount(ctx context.Context, namespace, name string) (*corev1.ServiceAccount, error) {
+	api.LogInfo(ctx, "GetServiceAccount", fmt.Sprintf("Fetching serviceaccount %s in namespace %s", name, namespace))
+
+	account, err := api.GetClientset().CoreV1().ServiceAccounts(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetServiceAccount", err)
+		return nil, api.HandleError(err, "get serviceaccount")
+	}
+
+	return account, nil
+}
+
+// GetServiceAccountDetails returns a service account with its RBAC grants, the pods running as it
+// and whether its image pull secrets exist
+func (api *ServiceAccountAPI) GetServiceAccountDetails(ctx context.Context, namespace, name string) (*base.APIResponse, error) {
+	account, err := api.GetServiceAccount(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := rbac.LoadSnapshot(ctx, api.GetClientset(), "", false)
+	if err != nil {
+		api.LogError(ctx, "GetServiceAccountDetails", err)
+		return nil, api.HandleError(err, "load rbac objects")
+	}
+	grants := snapshot.SubjectGrants(rbac.Subject{Kind: "ServiceAccount", Name: name, Namespace: namespace})
+
+	pods, err := api.GetClientset().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetServiceAccountDetails", err)
+		return nil, api.HandleError(err, "list pods")
+	}
+	accountAutomount := automountEnabled(account.AutomountServiceAccountToken)
+	usedBy := []map[string]interface{}{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if podServiceAccount(pod) != name {
+			continue
+		}
+		// The pod's own setting takes precedence over the service account's
+		automount := accountAutomount
+		if pod.Spec.AutomountServiceAccountToken != nil {
+			automount = *pod.Spec.AutomountServiceAccountToken
+		}
+		usedBy = append(usedBy, map[string]interface{}{
+			"name":           pod.Name,
+			"phase":          pod.Status.Phase,
+			"node":           pod.Spec.NodeName,
+			"tokenMounted":   automount,
+			"podOverridesSA": pod.Spec.AutomountServiceAccountToken != nil,
+		})
+	}
+
+	pullSecrets := []map[string]interface{}{}
+	for _, ref := range account.ImagePullSecrets {
+		entry := map[string]interface{}{"name": ref.Name, "exists": true}
+		secret, err := api.GetClientset().CoreV1().Secrets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			entry["exists"] = false
+		case err != nil:
+			api.LogError(ctx, "GetServiceAccountDetails", err)
+			return nil, api.HandleError(err, "get image pull secret")
+		default:
+			entry["type"] = secret.Type
+		}
+		pullSecrets = append(pullSecrets, entry)
+	}
+
+	details := map[string]interface{}{
+		"name":                         account.Name,
+		"namespace":                    account.Namespace,
+		"automountServiceAccountToken": accountAutomount,
+		"imagePullSecrets":             pullSecrets,
+		"secrets":                      account.Secrets,
+		"grants":                       grants,
+		"pods":                         usedBy,
+		"labels":                       account.Labels,
+		"annotations":                  account.Annotations,
+		"creationTime":                 account.CreationTimestamp,
+	}
+
+	response := base.NewSuccessResponse(details)
+	return &response, nil
+}
+
+// CreateServiceAccount creates a new service account
+func (api *ServiceAccountAPI) CreateServiceAccount(ctx context.Context, namespace string, account *corev1.ServiceAccount) (*corev1.ServiceAccount, error) {
+	api.LogInfo(ctx, "CreateServiceAccount", fmt.Sprintf("Creating serviceaccount %s in namespace %s", account.Name, namespace))
+
+	result, err := api.GetClientset().CoreV1().ServiceAccounts(namespace).Create(ctx, account, metav1.CreateOptions{})
+	if err != nil {
+		api.LogError(ctx, "CreateServiceAccount", err)
+		return nil, api.HandleError(err, "create serviceaccount")
+	}
+
+	return result, nil
+}
+
+// DeleteServiceAccount deletes a specific service account
+func (api *ServiceAccountAPI) DeleteServiceAccount(ctx context.Context, namespace, name string) error {
+	api.LogInfo(ctx, "DeleteServiceAccount", fmt.Sprintf("Deleting serviceaccount %s in namespace %s", name, namespace))
+
+	err := api.GetClientset().CoreV1().ServiceAccounts(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		api.LogError(ctx, "DeleteServiceAccount", err)
+		return api.HandleError(err, "delete serviceaccount")
+	}
+
+	return nil
+}
+
+// CreateToken mints a token for the service account through the TokenRequest API. Every attempt,
+// including rejected ones, is written to the audit log with the requester, audiences and expiry,
+// but never the token itself.
+func (api *ServiceAccountAPI) CreateToken(ctx context.Context, namespace, name string, audiences []string, expiration time.Duration, requester Requester) (*authenticationv1.TokenRequest, error) {
+	if expiration == 0 {
+		expiration = DefaultTokenExpiration
+	}
+	audit := fmt.Sprintf("serviceaccount=%s/%s audiences=%s expiration=%s client=%s remoteAddr=%s userAgent=%q reason=%q",
+		namespace, name, strings.Join(audiences, ","), expiration, requester.ClientIP, requester.RemoteAddr, requester.UserAgent, requester.Reason)
+
+	if err := validateTokenRequest(audiences, expiration); err != nil {
+		api.LogAudit(ctx, "CreateToken", fmt.Sprintf("%s result=rejected error=%q", audit, err))
+		return nil, err
+	}
+
+	seconds := int64(expiration.Seconds())
+	request := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         audiences,
+			ExpirationSeconds: &seconds,
+		},
+	}
+
+	result, err := api.GetClientset().CoreV1().ServiceAccounts(namespace).CreateToken(ctx, name, request, metav1.CreateOptions{})
+	if err != nil {
+		api.LogAudit(ctx, "CreateToken", audit+" result=failed")
+		api.LogError(ctx, "CreateToken", err)
+		return nil, api.HandleError(err, "create token")
+	}
+
+	api.LogAudit(ctx, "CreateToken", fmt.Sprintf("%s result=issued expiresAt=%s", audit, result.Status.ExpirationTimestamp.UTC().Format(time.RFC3339)))
+	return result, nil
+}
+
+// Helper functions
+
+func validateTokenRequest(audiences []string, expiration time.Duration) error {
+	if len(audiences) == 0 {
+		return fmt.Errorf("%w: at least one audience is required", ErrInvalidTokenRequest)
+	}
+	for _, audience := range audiences {
+		if strings.TrimSpace(audience) == "" {
+			return fmt.Errorf("%w: audiences must not be empty", ErrInvalidTokenRequest)
+		}
+	}
+	if expiration < MinTokenExpiration || expiration > MaxTokenExpiration {
+		return fmt.Errorf("%w: expiration must be between %s and %s", ErrInvalidTokenRequest, MinTokenExpiration, MaxTokenExpiration)
+	}
+	return nil
+}
+
+// podServiceAccount returns the account a pod runs as; pods without one use "default"
+func podServiceAccount(pod *corev1.Pod) string {
+	if pod.Spec.ServiceAccountName == "" {
+		return "default"
+	}
+	return pod.Spec.ServiceAccountName
+}
+
+// automountEnabled applies the API default of mounting the token when the field is unset
+func automountEnabled(automount *bool) bool {
+	return automount == nil || *automount
+}
+
+func secretNames(refs []corev1.LocalObjectReference) []string {
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, ref.Name)
+	}
+	return names
+}
@@ -0,0 +1,126 @@
+package serviceaccount
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"k8s-glance-backend/internal/api/rbac"
+)
+
+func TestGetServiceAccountDetails(t *testing.T) {
+	disabled := false
+	enabled := true
+	api := NewServiceAccountAPI(fake.NewSimpleClientset(
+		&corev1.ServiceAccount{
+			ObjectMeta:                   metav1.ObjectMeta{Name: "ci", Namespace: "team-a"},
+			AutomountServiceAccountToken: &disabled,
+			ImagePullSecrets:             []corev1.LocalObjectReference{{Name: "registry"}, {Name: "gone"}},
+		},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "team-a"}, Type: corev1.SecretTypeDockerConfigJson},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "build-1", Namespace: "team-a"}, Spec: corev1.PodSpec{ServiceAccountName: "ci"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "build-2", Namespace: "team-a"}, Spec: corev1.PodSpec{ServiceAccountName: "ci", AutomountServiceAccountToken: &enabled}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}},
+		&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "deployer", Namespace: "team-a"}},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "ci-deployer", Namespace: "team-a"},
+			RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "deployer"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "ci"}},
+		},
+	), log.New(io.Discard, "", 0))
+
+	response, err := api.GetServiceAccountDetails(context.Background(), "team-a", "ci")
+	if err != nil {
+		t.Fatalf("GetServiceAccountDetails: %v", err)
+	}
+	details := response.Data.(map[string]interface{})
+
+	if details["automountServiceAccountToken"] != false {
+		t.Error("automount should be disabled")
+	}
+	pods := details["pods"].([]map[string]interface{})
+	if len(pods) != 2 {
+		t.Fatalf("pods = %v, want build-1 and build-2", pods)
+	}
+	for _, pod := range pods {
+		if want := pod["name"] == "build-2"; pod["tokenMounted"] != want {
+			t.Errorf("%s tokenMounted = %v, want %v", pod["name"], pod["tokenMounted"], want)
+		}
+	}
+	pullSecrets := details["imagePullSecrets"].([]map[string]interface{})
+	if pullSecrets[0]["exists"] != true || pullSecrets[1]["exists"] != false {
+		t.Errorf("imagePullSecrets = %v", pullSecrets)
+	}
+	if grants := details["grants"].([]rbac.Grant); len(grants) != 1 || grants[0].Role != "deployer" {
+		t.Errorf("grants = %+v, want the deployer role", grants)
+	}
+}
+
+func TestCreateToken(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "ci", Namespace: "team-a"}})
+	clientset.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		request := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenRequest)
+		request.Status = authenticationv1.TokenRequestStatus{
+			Token:               "secret-token-value",
+			ExpirationTimestamp: metav1.NewTime(time.Now().Add(time.Duration(*request.Spec.ExpirationSeconds) * time.Second)),
+		}
+		return true, request, nil
+	})
+
+	var logs bytes.Buffer
+	api := NewServiceAccountAPI(clientset, log.New(&logs, "", 0))
+	ctx := context.Background()
+	requester := Requester{ClientIP: "10.1.2.3", RemoteAddr: "192.168.0.7:51234", Reason: "pipeline 42"}
+
+	result, err := api.CreateToken(ctx, "team-a", "ci", []string{"https://ci.example.com"}, 0, requester)
+	if err != nil {
+		t.Fatalf("CreateToken: %v", err)
+	}
+	if result.Status.Token != "secret-token-value" || *result.Spec.ExpirationSeconds != int64(DefaultTokenExpiration.Seconds()) {
+		t.Errorf("token request = %+v", result)
+	}
+
+	audit := logs.String()
+	if !strings.Contains(audit, "Audit [CreateToken]") || !strings.Contains(audit, "10.1.2.3") ||
+		!strings.Contains(audit, "remoteAddr=192.168.0.7:51234") || !strings.Contains(audit, "result=issued") {
+		t.Errorf("audit log = %q", audit)
+	}
+	if strings.Contains(audit, "secret-token-value") {
+		t.Error("the token must never be logged")
+	}
+
+	invalid := []struct {
+		audiences  []string
+		expiration time.Duration
+	}{
+		{nil, time.Hour},
+		{[]string{" "}, time.Hour},
+		{[]string{"ci"}, time.Minute},
+		{[]string{"ci"}, 48 * time.Hour},
+	}
+	for _, tt := range invalid {
+		logs.Reset()
+		if _, err := api.CreateToken(ctx, "team-a", "ci", tt.audiences, tt.expiration, requester); !errors.Is(err, ErrInvalidTokenRequest) {
+			t.Errorf("CreateToken(%v, %s): err = %v, want ErrInvalidTokenRequest", tt.audiences, tt.expiration, err)
+		}
+		if audit := logs.String(); !strings.Contains(audit, "Audit [CreateToken]") || !strings.Contains(audit, "result=rejected") {
+			t.Errorf("CreateToken(%v, %s): audit log = %q, want rejected attempt", tt.audiences, tt.expiration, audit)
+		}
+	}
+}
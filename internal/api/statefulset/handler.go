@@ -0,0 +1,501 @@
+package statefulset
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type Handler struct {
+	api *StatefulSetAPI
+}
+
+// VolumeClaimTemplateRequest describes a PersistentVolumeClaim created for every replica
+type VolumeClaimTemplateRequest struct {
+	Name             string   `json:"name" binding:"required"`
+	Storage          string   `json:"storage" binding:"required"`
+	StorageClassName *string  `json:"storageClassName"`
+	AccessModes      []string `json:"accessModes"`
+	MountPath        string   `json:"mountPath" binding:"required"`
+}
+
+func NewHandler(clientset *kubernetes.Clientset, logger *log.Logger) *Handler {
+	if logger == nil {
+		logger = log.New(gin.DefaultWriter, "[STATEFULSET-API] ", log.LstdFlags)
+	}
+
+	return &Handler{
+		api: NewStatefulSetAPI(clientset, logger),
+	}
+}
+
+// ListStatefulSets handles GET /api/v1/statefulsets/namespaces/:namespace
+func (h *Handler) ListStatefulSets(c *gin.Context) {
+	namespace := c.Param("namespace")
+	statefulSets, err := h.api.ListStatefulSets(c.Request.Context(), namespace)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	var response []map[string]interface{}
+	for _, statefulSet := range statefulSets.Items {
+		response = append(response, map[string]interface{}{
+			"name":           statefulSet.Name,
+			"namespace":      statefulSet.Namespace,
+			"replicas":       statefulSet.Status.Replicas,
+			"readyReplicas":  statefulSet.Status.ReadyReplicas,
+			"serviceName":    statefulSet.Spec.ServiceName,
+			"creationTime":   statefulSet.CreationTimestamp,
+			"labels":         statefulSet.Labels,
+			"updateStrategy": statefulSet.Spec.UpdateStrategy.Type,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    response,
+	})
+}
+
+// GetStatefulSet handles GET /api/v1/statefulsets/namespaces/:namespace/:name
+func (h *Handler) GetStatefulSet(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	statefulSet, err := h.api.GetStatefulSet(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"name":                 statefulSet.Name,
+			"namespace":            statefulSet.Namespace,
+			"replicas":             statefulSet.Status.Replicas,
+			"readyReplicas":        statefulSet.Status.ReadyReplicas,
+			"serviceName":          statefulSet.Spec.ServiceName,
+			"podManagementPolicy":  statefulSet.Spec.PodManagementPolicy,
+			"updateStrategy":       getUpdateStrategy(statefulSet),
+			"selector":             statefulSet.Spec.Selector,
+			"volumeClaimTemplates": statefulSet.Spec.VolumeClaimTemplates,
+			"creationTime":         statefulSet.CreationTimestamp,
+			"labels":               statefulSet.Labels,
+			"annotations":          statefulSet.Annotations,
+			"containers":           statefulSet.Spec.Template.Spec.Containers,
+		},
+	})
+}
+
+// GetStatefulSetStatus handles GET /api/v1/statefulsets/namespaces/:namespace/:name/status
+func (h *Handler) GetStatefulSetStatus(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	status, err := h.api.GetStatefulSetStatus(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// GetStatefulSetRevisions handles GET /api/v1/statefulsets/namespaces/:namespace/:name/revisions
+func (h *Handler) GetStatefulSetRevisions(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	revisions, err := h.api.GetStatefulSetRevisions(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// CreateStatefulSet handles POST /api/v1/statefulsets/namespaces/:namespace
+func (h *Handler) CreateStatefulSet(c *gin.Context) {
+	var statefulSetRequest struct {
+		Name                 string                       `json:"name" binding:"required"`
+		Image                string                       `json:"image" binding:"required"`
+		Replicas             int32                        `json:"replicas" binding:"required"`
+		ServiceName          string                       `json:"serviceName" binding:"required"`
+		ContainerPort        int32                        `json:"containerPort"`
+		Labels               map[string]string            `json:"labels"`
+		Annotations          map[string]string            `json:"annotations"`
+		EnvVars              []map[string]string          `json:"envVars"`
+		VolumeClaimTemplates []VolumeClaimTemplateRequest `json:"volumeClaimTemplates"`
+		PodManagementPolicy  string                       `json:"podManagementPolicy"`
+		Partition            *int32                       `json:"partition"`
+	}
+
+	if err := c.ShouldBindJSON(&statefulSetRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	namespace := c.Param("namespace")
+
+	// Create statefulset object
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        statefulSetRequest.Name,
+			Namespace:   namespace,
+			Labels:      statefulSetRequest.Labels,
+			Annotations: statefulSetRequest.Annotations,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:            &statefulSetRequest.Replicas,
+			ServiceName:         statefulSetRequest.ServiceName,
+			PodManagementPolicy: appsv1.PodManagementPolicyType(statefulSetRequest.PodManagementPolicy),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app": statefulSetRequest.Name,
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app": statefulSetRequest.Name,
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  statefulSetRequest.Name,
+							Image: statefulSetRequest.Image,
+							Ports: []corev1.ContainerPort{
+								{
+									ContainerPort: statefulSetRequest.ContainerPort,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Add environment variables if specified
+	if len(statefulSetRequest.EnvVars) > 0 {
+		var envVars []corev1.EnvVar
+		for _, env := range statefulSetRequest.EnvVars {
+			envVars = append(envVars, corev1.EnvVar{
+				Name:  env["name"],
+				Value: env["value"],
+			})
+		}
+		statefulSet.Spec.Template.Spec.Containers[0].Env = envVars
+	}
+
+	// Add a claim template and volume mount for every requested volume
+	for _, template := range statefulSetRequest.VolumeClaimTemplates {
+		claim, err := buildVolumeClaimTemplate(template)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+		statefulSet.Spec.VolumeClaimTemplates = append(statefulSet.Spec.VolumeClaimTemplates, claim)
+		statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts = append(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      template.Name,
+			MountPath: template.MountPath,
+		})
+	}
+
+	if statefulSetRequest.Partition != nil {
+		if err := SetRollingUpdatePartition(statefulSet, *statefulSetRequest.Partition); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+	}
+
+	result, err := h.api.CreateStatefulSet(c.Request.Context(), namespace, statefulSet)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"name":      result.Name,
+			"namespace": result.Namespace,
+			"status":    "created",
+		},
+	})
+}
+
+// UpdateStatefulSet handles PUT /api/v1/statefulsets/namespaces/:namespace/:name
+//
+// Setting partition together with image performs a partitioned rolling update:
+// only ordinals greater than or equal to the partition receive the new image.
+func (h *Handler) UpdateStatefulSet(c *gin.Context) {
+	var updateRequest struct {
+		Image          string              `json:"image"`
+		Replicas       *int32              `json:"replicas"`
+		Labels         map[string]string   `json:"labels"`
+		Annotations    map[string]string   `json:"annotations"`
+		EnvVars        []map[string]string `json:"envVars"`
+		UpdateStrategy string              `json:"updateStrategy"`
+		Partition      *int32              `json:"partition"`
+	}
+
+	if err := c.ShouldBindJSON(&updateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	// Get existing statefulset
+	existing, err := h.api.GetStatefulSet(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Update fields if provided
+	if updateRequest.UpdateStrategy != "" {
+		strategy := appsv1.StatefulSetUpdateStrategyType(updateRequest.UpdateStrategy)
+		if strategy != appsv1.RollingUpdateStatefulSetStrategyType && strategy != appsv1.OnDeleteStatefulSetStrategyType {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   fmt.Sprintf("updateStrategy must be %s or %s", appsv1.RollingUpdateStatefulSetStrategyType, appsv1.OnDeleteStatefulSetStrategyType),
+			})
+			return
+		}
+		existing.Spec.UpdateStrategy.Type = strategy
+		if strategy == appsv1.OnDeleteStatefulSetStrategyType {
+			existing.Spec.UpdateStrategy.RollingUpdate = nil
+		}
+	}
+	if updateRequest.Partition != nil {
+		if err := SetRollingUpdatePartition(existing, *updateRequest.Partition); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+	}
+	if updateRequest.Image != "" {
+		existing.Spec.Template.Spec.Containers[0].Image = updateRequest.Image
+	}
+	if updateRequest.Replicas != nil {
+		existing.Spec.Replicas = updateRequest.Replicas
+	}
+	if updateRequest.Labels != nil {
+		existing.Labels = updateRequest.Labels
+	}
+	if updateRequest.Annotations != nil {
+		existing.Annotations = updateRequest.Annotations
+	}
+	if len(updateRequest.EnvVars) > 0 {
+		var envVars []corev1.EnvVar
+		for _, env := range updateRequest.EnvVars {
+			envVars = append(envVars, corev1.EnvVar{
+				Name:  env["name"],
+				Value: env["value"],
+			})
+		}
+		existing.Spec.Template.Spec.Containers[0].Env = envVars
+	}
+
+	result, err := h.api.UpdateStatefulSet(c.Request.Context(), namespace, existing)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"name":           result.Name,
+			"namespace":      result.Namespace,
+			"updateStrategy": getUpdateStrategy(result),
+			"status":         "updated",
+		},
+	})
+}
+
+// DeleteStatefulSet handles DELETE /api/v1/statefulsets/namespaces/:namespace/:name
+func (h *Handler) DeleteStatefulSet(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	err := h.api.DeleteStatefulSet(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "StatefulSet deleted successfully",
+	})
+}
+
+// ScaleStatefulSet handles PUT /api/v1/statefulsets/namespaces/:namespace/:name/scale
+func (h *Handler) ScaleStatefulSet(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	replicasStr := c.Query("replicas")
+	replicas, err := strconv.ParseInt(replicasStr, 10, 32)
+	if err != nil || replicas < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid replicas value",
+		})
+		return
+	}
+
+	err = h.api.ScaleStatefulSet(c.Request.Context(), namespace, name, int32(replicas))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": fmt.Sprintf("StatefulSet scaled to %d replicas", replicas),
+	})
+}
+
+// RestartStatefulSet handles POST /api/v1/statefulsets/namespaces/:namespace/:name/restart
+func (h *Handler) RestartStatefulSet(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	err := h.api.RestartStatefulSet(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "StatefulSet restart triggered",
+	})
+}
+
+// SetPartition handles PUT /api/v1/statefulsets/namespaces/:namespace/:name/partition
+func (h *Handler) SetPartition(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	partition, err := strconv.ParseInt(c.Query("partition"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid partition value",
+		})
+		return
+	}
+
+	err = h.api.SetPartition(c.Request.Context(), namespace, name, int32(partition))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidPartition) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": fmt.Sprintf("StatefulSet partition set to %d", partition),
+	})
+}
+
+// Helper functions
+
+func buildVolumeClaimTemplate(template VolumeClaimTemplateRequest) (corev1.PersistentVolumeClaim, error) {
+	storage, err := resource.ParseQuantity(template.Storage)
+	if err != nil {
+		return corev1.PersistentVolumeClaim{}, fmt.Errorf("volumeClaimTemplates.%s.storage: %v", template.Name, err)
+	}
+
+	accessModes := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	if len(template.AccessModes) > 0 {
+		accessModes = nil
+		for _, mode := range template.AccessModes {
+			accessModes = append(accessModes, corev1.PersistentVolumeAccessMode(mode))
+		}
+	}
+
+	return corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: template.Name,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      accessModes,
+			StorageClassName: template.StorageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: storage,
+				},
+			},
+		},
+	}, nil
+}
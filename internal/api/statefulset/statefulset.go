@@ -0,0 +1,443 @@
+package statefulset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s-glance-backend/internal/api/base"
+)
+
+// restartedAtAnnotation is the pod template annotation used by `kubectl rollout restart`
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// ErrInvalidPartition is returned when a partition cannot be applied to a statefulset
+var ErrInvalidPartition = errors.New("invalid partition")
+
+// StatefulSetAPI handles statefulset-related operations
+type StatefulSetAPI struct {
+	*base.BaseAPI
+}
+
+// NewStatefulSetAPI creates a new StatefulSetAPI instance
+func NewStatefulSetAPI(clientset kubernetes.Interface, logger *log.Logger) *StatefulSetAPI {
+	return &StatefulSetAPI{
+		BaseAPI: base.NewBaseAPI(clientset, logger),
+	}
+}
+
+// ListStatefulSets returns all statefulsets in a namespace
+func (api *StatefulSetAPI) ListStatefulSets(ctx context.Context, namespace string) (*appsv1.StatefulSetList, error) {
+	api.LogInfo(ctx, "ListStatefulSets", fmt.Sprintf("Fetching statefulsets in namespace: %s", namespace))
+
+	statefulSets, err := api.GetClientset().AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListStatefulSets", err)
+		return nil, api.HandleError(err, "list statefulsets")
+	}
+
+	return statefulSets, nil
+}
+
+// GetStatefulSet returns a specific statefulset
+func (api *StatefulSetAPI) GetStatefulSet(ctx context.Context, namespace, name string) (*appsv1.StatefulSet, error) {
+	api.LogInfo(ctx, "GetStatefulSet", fmt.Sprintf("Fetching statefulset %s in namespace %s", name, namespace))
+
+	statefulSet, err := api.GetClientset().AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetStatefulSet", err)
+		return nil, api.HandleError(err, "get statefulset")
+	}
+
+	return statefulSet, nil
+}
+
+// GetStatefulSetStatus returns detailed status of a statefulset, including the pod and
+// PersistentVolumeClaims of every ordinal
+func (api *StatefulSetAPI) GetStatefulSetStatus(ctx context.Context, namespace, name string) (*base.APIResponse, error) {
+	api.LogInfo(ctx, "GetStatefulSetStatus", fmt.Sprintf("Fetching status for statefulset %s in namespace %s", name, namespace))
+
+	statefulSet, err := api.GetStatefulSet(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := api.listOwnedPods(ctx, statefulSet)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := api.GetClientset().CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetStatefulSetStatus", err)
+		return nil, api.HandleError(err, "list persistentvolumeclaims")
+	}
+
+	status := map[string]interface{}{
+		"replicas": map[string]int32{
+			"desired": desiredReplicas(statefulSet),
+			"current": statefulSet.Status.CurrentReplicas,
+			"updated": statefulSet.Status.UpdatedReplicas,
+			"ready":   statefulSet.Status.ReadyReplicas,
+		},
+		"conditions":      getStatefulSetConditions(statefulSet.Status.Conditions),
+		"updateStrategy":  getUpdateStrategy(statefulSet),
+		"currentRevision": statefulSet.Status.CurrentRevision,
+		"updateRevision":  statefulSet.Status.UpdateRevision,
+		"serviceName":     statefulSet.Spec.ServiceName,
+		"ordinals":        getOrdinalStatus(statefulSet, pods, claims.Items),
+		"age":             statefulSet.CreationTimestamp.Time,
+	}
+
+	response := base.NewSuccessResponse(status)
+	return &response, nil
+}
+
+// GetStatefulSetRevisions returns the rollout history of a statefulset from its ControllerRevisions
+func (api *StatefulSetAPI) GetStatefulSetRevisions(ctx context.Context, namespace, name string) (*base.APIResponse, error) {
+	api.LogInfo(ctx, "GetStatefulSetRevisions", fmt.Sprintf("Fetching revisions for statefulset %s in namespace %s", name, namespace))
+
+	statefulSet, err := api.GetStatefulSet(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(statefulSet.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector: %v", err)
+	}
+
+	revisions, err := api.GetClientset().AppsV1().ControllerRevisions(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		api.LogError(ctx, "GetStatefulSetRevisions", err)
+		return nil, api.HandleError(err, "list controllerrevisions")
+	}
+
+	var owned []appsv1.ControllerRevision
+	for _, revision := range revisions.Items {
+		if isOwnedBy(revision.OwnerReferences, statefulSet.UID) {
+			owned = append(owned, revision)
+		}
+	}
+	sort.Slice(owned, func(i, j int) bool {
+		return owned[i].Revision > owned[j].Revision
+	})
+
+	var result []map[string]interface{}
+	for _, revision := range owned {
+		result = append(result, map[string]interface{}{
+			"name":         revision.Name,
+			"revision":     revision.Revision,
+			"current":      revision.Name == statefulSet.Status.CurrentRevision,
+			"update":       revision.Name == statefulSet.Status.UpdateRevision,
+			"creationTime": revision.CreationTimestamp,
+		})
+	}
+
+	response := base.NewSuccessResponse(map[string]interface{}{
+		"currentRevision": statefulSet.Status.CurrentRevision,
+		"updateRevision":  statefulSet.Status.UpdateRevision,
+		"revisions":       result,
+	})
+	return &response, nil
+}
+
+// DeleteStatefulSet deletes a specific statefulset
+func (api *StatefulSetAPI) DeleteStatefulSet(ctx context.Context, namespace, name string) error {
+	api.LogInfo(ctx, "DeleteStatefulSet", fmt.Sprintf("Deleting statefulset %s in namespace %s", name, namespace))
+
+	err := api.GetClientset().AppsV1().StatefulSets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		api.LogError(ctx, "DeleteStatefulSet", err)
+		return api.HandleError(err, "delete statefulset")
+	}
+
+	return nil
+}
+
+// ScaleStatefulSet scales a statefulset to the specified number of replicas
+func (api *StatefulSetAPI) ScaleStatefulSet(ctx context.Context, namespace, name string, replicas int32) error {
+	api.LogInfo(ctx, "ScaleStatefulSet", fmt.Sprintf("Scaling statefulset %s in namespace %s to %d replicas", name, namespace, replicas))
+
+	statefulSet, err := api.GetStatefulSet(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+
+	statefulSet.Spec.Replicas = &replicas
+
+	_, err = api.GetClientset().AppsV1().StatefulSets(namespace).Update(ctx, statefulSet, metav1.UpdateOptions{})
+	if err != nil {
+		api.LogError(ctx, "ScaleStatefulSet", err)
+		return api.HandleError(err, "scale statefulset")
+	}
+
+	return nil
+}
+
+// RestartStatefulSet triggers a rolling restart by stamping the pod template, like `kubectl rollout restart`
+func (api *StatefulSetAPI) RestartStatefulSet(ctx context.Context, namespace, name string) error {
+	api.LogInfo(ctx, "RestartStatefulSet", fmt.Sprintf("Restarting statefulset %s in namespace %s", name, namespace))
+
+	patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`,
+		restartedAtAnnotation, time.Now().Format(time.RFC3339))
+
+	_, err := api.GetClientset().AppsV1().StatefulSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+	if err != nil {
+		api.LogError(ctx, "RestartStatefulSet", err)
+		return api.HandleError(err, "restart statefulset")
+	}
+
+	return nil
+}
+
+// SetPartition sets the rolling update partition. Only pods with an ordinal greater
+// than or equal to the partition are updated to the new revision.
+func (api *StatefulSetAPI) SetPartition(ctx context.Context, namespace, name string, partition int32) error {
+	api.LogInfo(ctx, "SetPartition", fmt.Sprintf("Setting partition of statefulset %s in namespace %s to %d", name, namespace, partition))
+
+	statefulSet, err := api.GetStatefulSet(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+
+	if err := SetRollingUpdatePartition(statefulSet, partition); err != nil {
+		return err
+	}
+
+	_, err = api.GetClientset().AppsV1().StatefulSets(namespace).Update(ctx, statefulSet, metav1.UpdateOptions{})
+	if err != nil {
+		api.LogError(ctx, "SetPartition", err)
+		return api.HandleError(err, "set statefulset partition")
+	}
+
+	return nil
+}
+
+// CreateStatefulSet creates a new statefulset
+func (api *StatefulSetAPI) CreateStatefulSet(ctx context.Context, namespace string, statefulSet *appsv1.StatefulSet) (*appsv1.StatefulSet, error) {
+	api.LogInfo(ctx, "CreateStatefulSet", fmt.Sprintf("Creating statefulset %s in namespace %s", statefulSet.Name, namespace))
+
+	result, err := api.GetClientset().AppsV1().StatefulSets(namespace).Create(ctx, statefulSet, metav1.CreateOptions{})
+	if err != nil {
+		api.LogError(ctx, "CreateStatefulSet", err)
+		return nil, api.HandleError(err, "create statefulset")
+	}
+
+	return result, nil
+}
+
+// UpdateStatefulSet updates an existing statefulset
+func (api *StatefulSetAPI) UpdateStatefulSet(ctx context.Context, namespace string, statefulSet *appsv1.StatefulSet) (*appsv1.StatefulSet, error) {
+	api.LogInfo(ctx, "UpdateStatefulSet", fmt.Sprintf("Updating statefulset %s in namespace %s", statefulSet.Name, namespace))
+
+	result, err := api.GetClientset().AppsV1().StatefulSets(namespace).Update(ctx, statefulSet, metav1.UpdateOptions{})
+	if err != nil {
+		api.LogError(ctx, "UpdateStatefulSet", err)
+		return nil, api.HandleError(err, "update statefulset")
+	}
+
+	return result, nil
+}
+
+func (api *StatefulSetAPI) listOwnedPods(ctx context.Context, statefulSet *appsv1.StatefulSet) ([]corev1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(statefulSet.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector: %v", err)
+	}
+
+	pods, err := api.GetClientset().CoreV1().Pods(statefulSet.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		api.LogError(ctx, "listOwnedPods", err)
+		return nil, api.HandleError(err, "list statefulset pods")
+	}
+
+	var owned []corev1.Pod
+	for _, pod := range pods.Items {
+		if isOwnedBy(pod.OwnerReferences, statefulSet.UID) {
+			owned = append(owned, pod)
+		}
+	}
+	return owned, nil
+}
+
+// Helper functions
+
+func desiredReplicas(statefulSet *appsv1.StatefulSet) int32 {
+	if statefulSet.Spec.Replicas == nil {
+		return 1
+	}
+	return *statefulSet.Spec.Replicas
+}
+
+func isOwnedBy(owners []metav1.OwnerReference, uid types.UID) bool {
+	for _, owner := range owners {
+		if owner.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+// SetRollingUpdatePartition sets the partition of a RollingUpdate strategy
+func SetRollingUpdatePartition(statefulSet *appsv1.StatefulSet, partition int32) error {
+	if partition < 0 {
+		return fmt.Errorf("%w: partition must not be negative", ErrInvalidPartition)
+	}
+	if statefulSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return fmt.Errorf("%w: partition requires the %s update strategy", ErrInvalidPartition, appsv1.RollingUpdateStatefulSetStrategyType)
+	}
+
+	statefulSet.Spec.UpdateStrategy.Type = appsv1.RollingUpdateStatefulSetStrategyType
+	if statefulSet.Spec.UpdateStrategy.RollingUpdate == nil {
+		statefulSet.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{}
+	}
+	statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition = &partition
+	return nil
+}
+
+func getUpdateStrategy(statefulSet *appsv1.StatefulSet) map[string]interface{} {
+	strategy := map[string]interface{}{
+		"type":      statefulSet.Spec.UpdateStrategy.Type,
+		"partition": int32(0),
+	}
+	if ru := statefulSet.Spec.UpdateStrategy.RollingUpdate; ru != nil {
+		if ru.Partition != nil {
+			strategy["partition"] = *ru.Partition
+		}
+		if ru.MaxUnavailable != nil {
+			strategy["maxUnavailable"] = ru.MaxUnavailable.String()
+		}
+	}
+	return strategy
+}
+
+// getOrdinalStatus returns the pod and claims of every desired ordinal, starting at spec.ordinals.start.
+// Pods and claims that are expected but missing are reported as such. Owned pods outside the desired
+// range, e.g. while scaling down, follow as surplus ordinals.
+func getOrdinalStatus(statefulSet *appsv1.StatefulSet, pods []corev1.Pod, claims []corev1.PersistentVolumeClaim) []map[string]interface{} {
+	podsByName := make(map[string]*corev1.Pod, len(pods))
+	for i := range pods {
+		podsByName[pods[i].Name] = &pods[i]
+	}
+	claimsByName := make(map[string]*corev1.PersistentVolumeClaim, len(claims))
+	for i := range claims {
+		claimsByName[claims[i].Name] = &claims[i]
+	}
+
+	start := int32(0)
+	if statefulSet.Spec.Ordinals != nil {
+		start = statefulSet.Spec.Ordinals.Start
+	}
+	end := start + desiredReplicas(statefulSet)
+
+	var ordinals []int32
+	for ordinal := start; ordinal < end; ordinal++ {
+		ordinals = append(ordinals, ordinal)
+	}
+	var surplus []int32
+	for _, pod := range pods {
+		ordinal, ok := podOrdinal(statefulSet.Name, pod.Name)
+		if ok && (ordinal < start || ordinal >= end) {
+			surplus = append(surplus, ordinal)
+		}
+	}
+	sort.Slice(surplus, func(i, j int) bool { return surplus[i] < surplus[j] })
+	ordinals = append(ordinals, surplus...)
+
+	var result []map[string]interface{}
+	for _, ordinal := range ordinals {
+		podName := fmt.Sprintf("%s-%d", statefulSet.Name, ordinal)
+
+		entry := map[string]interface{}{
+			"ordinal": ordinal,
+			"pod":     podName,
+			"exists":  false,
+			"surplus": ordinal < start || ordinal >= end,
+		}
+
+		if pod, ok := podsByName[podName]; ok {
+			revision := pod.Labels[appsv1.StatefulSetRevisionLabel]
+			var restarts int32
+			ready := len(pod.Status.ContainerStatuses) > 0
+			for _, cs := range pod.Status.ContainerStatuses {
+				restarts += cs.RestartCount
+				ready = ready && cs.Ready
+			}
+			entry["exists"] = true
+			entry["phase"] = pod.Status.Phase
+			entry["ready"] = ready
+			entry["restarts"] = restarts
+			entry["nodeName"] = pod.Spec.NodeName
+			entry["podIP"] = pod.Status.PodIP
+			entry["revision"] = revision
+			entry["updated"] = revision != "" && revision == statefulSet.Status.UpdateRevision
+			entry["terminating"] = pod.DeletionTimestamp != nil
+		}
+
+		var volumeClaims []map[string]interface{}
+		for _, template := range statefulSet.Spec.VolumeClaimTemplates {
+			claimName := fmt.Sprintf("%s-%s", template.Name, podName)
+			claimStatus := map[string]interface{}{
+				"template": template.Name,
+				"name":     claimName,
+				"exists":   false,
+			}
+			if claim, ok := claimsByName[claimName]; ok {
+				capacity := claim.Status.Capacity[corev1.ResourceStorage]
+				claimStatus["exists"] = true
+				claimStatus["phase"] = claim.Status.Phase
+				claimStatus["volumeName"] = claim.Spec.VolumeName
+				claimStatus["capacity"] = capacity.String()
+				claimStatus["storageClass"] = claim.Spec.StorageClassName
+			}
+			volumeClaims = append(volumeClaims, claimStatus)
+		}
+		entry["volumeClaims"] = volumeClaims
+
+		result = append(result, entry)
+	}
+	return result
+}
+
+// podOrdinal parses the ordinal from the name of a statefulset pod
+func podOrdinal(statefulSetName, podName string) (int32, bool) {
+	suffix, ok := strings.CutPrefix(podName, statefulSetName+"-")
+	if !ok {
+		return 0, false
+	}
+	ordinal, err := strconv.ParseInt(suffix, 10, 32)
+	if err != nil || ordinal < 0 {
+		return 0, false
+	}
+	return int32(ordinal), true
+}
+
+func getStatefulSetConditions(conditions []appsv1.StatefulSetCondition) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, condition := range conditions {
+		result = append(result, map[string]interface{}{
+			"type":               condition.Type,
+			"status":             condition.Status,
+			"lastTransitionTime": condition.LastTransitionTime,
+			"reason":             condition.Reason,
+			"message":            condition.Message,
+		})
+	}
+	return result
+}
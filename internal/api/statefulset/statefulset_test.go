@@ -0,0 +1,135 @@
+package statefulset
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestStatefulSet(replicas int32) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "team-a", UID: "sts-uid"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{ObjectMeta: metav1.ObjectMeta{Name: "data"}},
+			},
+		},
+		Status: appsv1.StatefulSetStatus{UpdateRevision: "db-2"},
+	}
+}
+
+func newTestPod(name, revision string, ready bool) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "team-a",
+			Labels: map[string]string{
+				"app":                           "db",
+				appsv1.StatefulSetRevisionLabel: revision,
+			},
+			OwnerReferences: []metav1.OwnerReference{{UID: "sts-uid"}},
+		},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "db", Ready: ready}},
+		},
+	}
+}
+
+func TestGetStatefulSetStatusOrdinals(t *testing.T) {
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data-db-0", Namespace: "team-a"},
+		Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pv-0"},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Phase:    corev1.ClaimBound,
+			Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+		},
+	}
+	api := NewStatefulSetAPI(fake.NewSimpleClientset(
+		newTestStatefulSet(3),
+		newTestPod("db-0", "db-1", true),
+		newTestPod("db-1", "db-2", false),
+		claim,
+	), log.New(io.Discard, "", 0))
+
+	response, err := api.GetStatefulSetStatus(context.Background(), "team-a", "db")
+	if err != nil {
+		t.Fatalf("GetStatefulSetStatus: %v", err)
+	}
+
+	ordinals := response.Data.(map[string]interface{})["ordinals"].([]map[string]interface{})
+	if len(ordinals) != 3 {
+		t.Fatalf("got %d ordinals, want 3", len(ordinals))
+	}
+
+	if ordinals[0]["ready"] != true || ordinals[0]["updated"] != false {
+		t.Errorf("ordinal 0 = %v, want ready and not updated", ordinals[0])
+	}
+	if ordinals[1]["ready"] != false || ordinals[1]["updated"] != true {
+		t.Errorf("ordinal 1 = %v, want not ready and updated", ordinals[1])
+	}
+	if ordinals[2]["exists"] != false {
+		t.Errorf("ordinal 2 = %v, want missing pod", ordinals[2])
+	}
+
+	claims := ordinals[0]["volumeClaims"].([]map[string]interface{})
+	if claims[0]["name"] != "data-db-0" || claims[0]["phase"] != corev1.ClaimBound || claims[0]["capacity"] != "1Gi" {
+		t.Errorf("ordinal 0 claims = %v", claims)
+	}
+	if missing := ordinals[1]["volumeClaims"].([]map[string]interface{}); missing[0]["exists"] != false {
+		t.Errorf("ordinal 1 claims = %v, want missing claim", missing)
+	}
+}
+
+func TestSetRollingUpdatePartition(t *testing.T) {
+	statefulSet := newTestStatefulSet(3)
+	if err := SetRollingUpdatePartition(statefulSet, 2); err != nil {
+		t.Fatalf("SetRollingUpdatePartition: %v", err)
+	}
+	if got := *statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition; got != 2 {
+		t.Errorf("partition = %d, want 2", got)
+	}
+
+	if err := SetRollingUpdatePartition(statefulSet, -1); !errors.Is(err, ErrInvalidPartition) {
+		t.Errorf("negative partition error = %v, want ErrInvalidPartition", err)
+	}
+
+	statefulSet.Spec.UpdateStrategy.Type = appsv1.OnDeleteStatefulSetStrategyType
+	if err := SetRollingUpdatePartition(statefulSet, 1); !errors.Is(err, ErrInvalidPartition) {
+		t.Errorf("OnDelete partition error = %v, want ErrInvalidPartition", err)
+	}
+}
+
+func TestGetOrdinalStatusStartAndSurplus(t *testing.T) {
+	statefulSet := newTestStatefulSet(2)
+	statefulSet.Spec.Ordinals = &appsv1.StatefulSetOrdinals{Start: 5}
+	pods := []corev1.Pod{
+		*newTestPod("db-5", "db-2", true),
+		*newTestPod("db-6", "db-2", true),
+		// Still present while scaling down from three replicas
+		*newTestPod("db-7", "db-2", true),
+	}
+
+	ordinals := getOrdinalStatus(statefulSet, pods, nil)
+	if len(ordinals) != 3 {
+		t.Fatalf("got %d ordinals, want 3: %v", len(ordinals), ordinals)
+	}
+	for i, want := range []int32{5, 6, 7} {
+		if ordinals[i]["ordinal"] != want || ordinals[i]["exists"] != true {
+			t.Errorf("ordinals[%d] = %v, want existing ordinal %d", i, ordinals[i], want)
+		}
+		if surplus := ordinals[i]["surplus"] == true; surplus != (want == 7) {
+			t.Errorf("ordinal %d: surplus = %v", want, surplus)
+		}
+	}
+}
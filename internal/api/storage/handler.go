@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes"
+)
+
+type Handler struct {
+	api *StorageAPI
+}
+
+func NewHandler(clientset *kubernetes.Clientset, logger *log.Logger) *Handler {
+	if logger == nil {
+		logger = log.New(gin.DefaultWriter, "[STORAGE-API] ", log.LstdFlags)
+	}
+
+	return &Handler{
+		api: NewStorageAPI(clientset, logger),
+	}
+}
+
+// ListPersistentVolumeClaims handles GET /api/v1/storage/persistentvolumeclaims/namespaces/:namespace
+func (h *Handler) ListPersistentVolumeClaims(c *gin.Context) {
+	namespace := c.Param("namespace")
+	claims, err := h.api.ListPersistentVolumeClaims(c.Request.Context(), namespace)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	summary := map[string]int{
+		"total":     len(claims),
+		"bound":     0,
+		"pending":   0,
+		"lost":      0,
+		"unhealthy": 0,
+	}
+	for _, claim := range claims {
+		switch claim["phase"] {
+		case corev1.ClaimBound:
+			summary["bound"]++
+		case corev1.ClaimPending:
+			summary["pending"]++
+		case corev1.ClaimLost:
+			summary["lost"]++
+		}
+		if claim["healthy"] == false {
+			summary["unhealthy"]++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    claims,
+		"summary": summary,
+	})
+}
+
+// GetPersistentVolumeClaim handles GET /api/v1/storage/persistentvolumeclaims/namespaces/:namespace/:name
+func (h *Handler) GetPersistentVolumeClaim(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	details, err := h.api.GetPersistentVolumeClaimDetails(c.Request.Context(), namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, details)
+}
+
+// ExpandPersistentVolumeClaim handles PUT /api/v1/storage/persistentvolumeclaims/namespaces/:namespace/:name/expand
+func (h *Handler) ExpandPersistentVolumeClaim(c *gin.Context) {
+	var expandRequest struct {
+		Storage string `json:"storage" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&expandRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	size, err := resource.ParseQuantity(expandRequest.Storage)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid storage value: " + err.Error(),
+		})
+		return
+	}
+
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	result, err := h.api.ExpandPersistentVolumeClaim(c.Request.Context(), namespace, name, size)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrExpansionNotAllowed) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	requested := result.Spec.Resources.Requests[corev1.ResourceStorage]
+	capacity := result.Status.Capacity[corev1.ResourceStorage]
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"name":      result.Name,
+			"namespace": result.Namespace,
+			"requested": requested.String(),
+			"capacity":  capacity.String(),
+			"status":    "expanding",
+		},
+	})
+}
+
+// ListPersistentVolumes handles GET /api/v1/storage/persistentvolumes
+func (h *Handler) ListPersistentVolumes(c *gin.Context) {
+	volumes, err := h.api.ListPersistentVolumes(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    volumes,
+	})
+}
+
+// ListStorageClasses handles GET /api/v1/storage/storageclasses
+func (h *Handler) ListStorageClasses(c *gin.Context) {
+	classes, err := h.api.ListStorageClasses(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    classes,
+	})
+}
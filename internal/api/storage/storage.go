@@ -0,0 +1,399 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s-glance-backend/internal/api/base"
+)
+
+// defaultClassAnnotation marks the default StorageClass of the cluster
+const defaultClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+// ErrExpansionNotAllowed is returned when a claim cannot be expanded to the requested size
+var ErrExpansionNotAllowed = errors.New("expansion not allowed")
+
+// StorageAPI handles PersistentVolumeClaim, PersistentVolume and StorageClass operations
+type StorageAPI struct {
+	*base.BaseAPI
+}
+
+// NewStorageAPI creates a new StorageAPI instance
+func NewStorageAPI(clientset kubernetes.Interface, logger *log.Logger) *StorageAPI {
+	return &StorageAPI{
+		BaseAPI: base.NewBaseAPI(clientset, logger),
+	}
+}
+
+// ListPersistentVolumeClaims returns all claims in a namespace with the pods mounting them
+// and any problems found
+func (api *StorageAPI) ListPersistentVolumeClaims(ctx context.Context, namespace string) ([]map[string]interface{}, error) {
+	api.LogInfo(ctx, "ListPersistentVolumeClaims", fmt.Sprintf("Fetching persistentvolumeclaims in namespace: %s", namespace))
+
+	claims, err := api.GetClientset().CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListPersistentVolumeClaims", err)
+		return nil, api.HandleError(err, "list persistentvolumeclaims")
+	}
+
+	pods, err := api.GetClientset().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListPersistentVolumeClaims", err)
+		return nil, api.HandleError(err, "list pods")
+	}
+
+	classes, err := api.getStorageClassMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mounts := getClaimMounts(pods.Items)
+
+	sort.Slice(claims.Items, func(i, j int) bool {
+		return claims.Items[i].Name < claims.Items[j].Name
+	})
+
+	result := make([]map[string]interface{}, 0, len(claims.Items))
+	for i := range claims.Items {
+		claim := &claims.Items[i]
+		result = append(result, getClaimSummary(claim, mounts[claim.Name], classes))
+	}
+	return result, nil
+}
+
+// GetPersistentVolumeClaim returns a specific claim
+func (api *StorageAPI) GetPersistentVolumeClaim(ctx context.Context, namespace, name string) (*corev1.PersistentVolumeClaim, error) {
+	api.LogInfo(ctx, "GetPersistentVolumeClaim", fmt.Sprintf("Fetching persistentvolumeclaim %s in namespace %s", name, namespace))
+
+	claim, err := api.GetClientset().CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetPersistentVolumeClaim", err)
+		return nil, api.HandleError(err, "get persistentvolumeclaim")
+	}
+
+	return claim, nil
+}
+
+// GetPersistentVolumeClaimDetails returns a claim with its bound volume, the pods mounting it
+// and its recent events
+func (api *StorageAPI) GetPersistentVolumeClaimDetails(ctx context.Context, namespace, name string) (*base.APIResponse, error) {
+	api.LogInfo(ctx, "GetPersistentVolumeClaimDetails", fmt.Sprintf("Fetching details for persistentvolumeclaim %s in namespace %s", name, namespace))
+
+	claim, err := api.GetPersistentVolumeClaim(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := api.GetClientset().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "GetPersistentVolumeClaimDetails", err)
+		return nil, api.HandleError(err, "list pods")
+	}
+
+	classes, err := api.getStorageClassMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := api.GetClientset().CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("involvedObject.kind=PersistentVolumeClaim,involvedObject.name=%s", name),
+	})
+	if err != nil {
+		api.LogError(ctx, "GetPersistentVolumeClaimDetails", err)
+		return nil, api.HandleError(err, "list persistentvolumeclaim events")
+	}
+
+	details := getClaimSummary(claim, getClaimMounts(pods.Items)[claim.Name], classes)
+	details["labels"] = claim.Labels
+	details["annotations"] = claim.Annotations
+	details["volumeMode"] = claim.Spec.VolumeMode
+	details["conditions"] = getClaimConditions(claim.Status.Conditions)
+	details["events"] = getEvents(events.Items)
+
+	if claim.Spec.VolumeName != "" {
+		volume, err := api.GetClientset().CoreV1().PersistentVolumes().Get(ctx, claim.Spec.VolumeName, metav1.GetOptions{})
+		if err == nil {
+			details["volume"] = getVolumeSummary(volume)
+		} else {
+			details["volumeError"] = err.Error()
+		}
+	}
+
+	response := base.NewSuccessResponse(details)
+	return &response, nil
+}
+
+// ExpandPersistentVolumeClaim raises the storage request of a bound claim. The storage class must
+// allow volume expansion and claims can only grow.
+func (api *StorageAPI) ExpandPersistentVolumeClaim(ctx context.Context, namespace, name string, size resource.Quantity) (*corev1.PersistentVolumeClaim, error) {
+	api.LogInfo(ctx, "ExpandPersistentVolumeClaim", fmt.Sprintf("Expanding persistentvolumeclaim %s in namespace %s to %s", name, namespace, size.String()))
+
+	claim, err := api.GetPersistentVolumeClaim(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if claim.Status.Phase != corev1.ClaimBound {
+		return nil, fmt.Errorf("%w: claim is %s, only bound claims can be expanded", ErrExpansionNotAllowed, claim.Status.Phase)
+	}
+	if claim.Spec.StorageClassName == nil || *claim.Spec.StorageClassName == "" {
+		return nil, fmt.Errorf("%w: claim has no storage class", ErrExpansionNotAllowed)
+	}
+
+	class, err := api.GetClientset().StorageV1().StorageClasses().Get(ctx, *claim.Spec.StorageClassName, metav1.GetOptions{})
+	if err != nil {
+		api.LogError(ctx, "ExpandPersistentVolumeClaim", err)
+		return nil, api.HandleError(err, "get storageclass")
+	}
+	if class.AllowVolumeExpansion == nil || !*class.AllowVolumeExpansion {
+		return nil, fmt.Errorf("%w: storage class %s does not allow volume expansion", ErrExpansionNotAllowed, class.Name)
+	}
+
+	current := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+	if size.Cmp(current) <= 0 {
+		return nil, fmt.Errorf("%w: requested size %s must be larger than the current request %s", ErrExpansionNotAllowed, size.String(), current.String())
+	}
+
+	if claim.Spec.Resources.Requests == nil {
+		claim.Spec.Resources.Requests = corev1.ResourceList{}
+	}
+	claim.Spec.Resources.Requests[corev1.ResourceStorage] = size
+
+	result, err := api.GetClientset().CoreV1().PersistentVolumeClaims(namespace).Update(ctx, claim, metav1.UpdateOptions{})
+	if err != nil {
+		api.LogError(ctx, "ExpandPersistentVolumeClaim", err)
+		return nil, api.HandleError(err, "expand persistentvolumeclaim")
+	}
+
+	return result, nil
+}
+
+// ListPersistentVolumes returns all persistent volumes
+func (api *StorageAPI) ListPersistentVolumes(ctx context.Context) ([]map[string]interface{}, error) {
+	api.LogInfo(ctx, "ListPersistentVolumes", "Fetching all persistentvolumes")
+
+	volumes, err := api.GetClientset().CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListPersistentVolumes", err)
+		return nil, api.HandleError(err, "list persistentvolumes")
+	}
+
+	sort.Slice(volumes.Items, func(i, j int) bool {
+		return volumes.Items[i].Name < volumes.Items[j].Name
+	})
+
+	result := make([]map[string]interface{}, 0, len(volumes.Items))
+	for i := range volumes.Items {
+		result = append(result, getVolumeSummary(&volumes.Items[i]))
+	}
+	return result, nil
+}
+
+// ListStorageClasses returns all storage classes
+func (api *StorageAPI) ListStorageClasses(ctx context.Context) ([]map[string]interface{}, error) {
+	api.LogInfo(ctx, "ListStorageClasses", "Fetching all storageclasses")
+
+	classes, err := api.GetClientset().StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "ListStorageClasses", err)
+		return nil, api.HandleError(err, "list storageclasses")
+	}
+
+	sort.Slice(classes.Items, func(i, j int) bool {
+		return classes.Items[i].Name < classes.Items[j].Name
+	})
+
+	result := make([]map[string]interface{}, 0, len(classes.Items))
+	for i := range classes.Items {
+		class := &classes.Items[i]
+		result = append(result, map[string]interface{}{
+			"name":                 class.Name,
+			"provisioner":          class.Provisioner,
+			"reclaimPolicy":        class.ReclaimPolicy,
+			"volumeBindingMode":    class.VolumeBindingMode,
+			"allowVolumeExpansion": class.AllowVolumeExpansion != nil && *class.AllowVolumeExpansion,
+			"default":              isDefaultClass(class),
+			"parameters":           class.Parameters,
+			"creationTime":         class.CreationTimestamp,
+		})
+	}
+	return result, nil
+}
+
+func (api *StorageAPI) getStorageClassMap(ctx context.Context) (map[string]*storagev1.StorageClass, error) {
+	classes, err := api.GetClientset().StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		api.LogError(ctx, "getStorageClassMap", err)
+		return nil, api.HandleError(err, "list storageclasses")
+	}
+
+	result := make(map[string]*storagev1.StorageClass, len(classes.Items))
+	for i := range classes.Items {
+		result[classes.Items[i].Name] = &classes.Items[i]
+	}
+	return result, nil
+}
+
+// Helper functions
+
+// getClaimMounts maps claim names to the pods using them, including generic ephemeral volumes,
+// whose claims are named <pod>-<volume>
+func getClaimMounts(pods []corev1.Pod) map[string][]map[string]interface{} {
+	mounts := make(map[string][]map[string]interface{})
+	for _, pod := range pods {
+		for _, volume := range pod.Spec.Volumes {
+			var claimName string
+			readOnly := false
+			switch {
+			case volume.PersistentVolumeClaim != nil:
+				claimName = volume.PersistentVolumeClaim.ClaimName
+				readOnly = volume.PersistentVolumeClaim.ReadOnly
+			case volume.Ephemeral != nil:
+				claimName = pod.Name + "-" + volume.Name
+			default:
+				continue
+			}
+
+			mounts[claimName] = append(mounts[claimName], map[string]interface{}{
+				"pod":      pod.Name,
+				"phase":    pod.Status.Phase,
+				"nodeName": pod.Spec.NodeName,
+				"volume":   volume.Name,
+				"readOnly": readOnly,
+			})
+		}
+	}
+	return mounts
+}
+
+// getClaimSummary describes a claim and lists its problems. A pending claim of a
+// WaitForFirstConsumer class without consumers is expected and not reported as a problem.
+func getClaimSummary(claim *corev1.PersistentVolumeClaim, mounts []map[string]interface{}, classes map[string]*storagev1.StorageClass) map[string]interface{} {
+	if mounts == nil {
+		mounts = []map[string]interface{}{}
+	}
+
+	className := ""
+	if claim.Spec.StorageClassName != nil {
+		className = *claim.Spec.StorageClassName
+	}
+	class := classes[className]
+
+	requested := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+	capacity := claim.Status.Capacity[corev1.ResourceStorage]
+
+	problems := []string{}
+	waitingForConsumer := false
+	switch claim.Status.Phase {
+	case corev1.ClaimPending:
+		if class != nil && class.VolumeBindingMode != nil && *class.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer && len(mounts) == 0 {
+			waitingForConsumer = true
+		} else {
+			problems = append(problems, "claim is not bound to a volume")
+		}
+	case corev1.ClaimLost:
+		problems = append(problems, fmt.Sprintf("bound volume %s no longer exists", claim.Spec.VolumeName))
+	}
+	if className != "" && class == nil {
+		problems = append(problems, fmt.Sprintf("storage class %s does not exist", className))
+	}
+	for _, condition := range claim.Status.Conditions {
+		if condition.Type == corev1.PersistentVolumeClaimFileSystemResizePending && condition.Status == corev1.ConditionTrue {
+			problems = append(problems, "file system resize is pending a pod restart")
+		}
+	}
+
+	return map[string]interface{}{
+		"name":                    claim.Name,
+		"namespace":               claim.Namespace,
+		"phase":                   claim.Status.Phase,
+		"requested":               requested.String(),
+		"capacity":                capacity.String(),
+		"accessModes":             claim.Spec.AccessModes,
+		"storageClass":            className,
+		"volumeName":              claim.Spec.VolumeName,
+		"expandable":              claim.Status.Phase == corev1.ClaimBound && class != nil && class.AllowVolumeExpansion != nil && *class.AllowVolumeExpansion,
+		"mountedBy":               mounts,
+		"waitingForFirstConsumer": waitingForConsumer,
+		"problems":                problems,
+		"healthy":                 len(problems) == 0,
+		"creationTime":            claim.CreationTimestamp,
+	}
+}
+
+func getVolumeSummary(volume *corev1.PersistentVolume) map[string]interface{} {
+	capacity := volume.Spec.Capacity[corev1.ResourceStorage]
+
+	var claim string
+	if volume.Spec.ClaimRef != nil {
+		claim = volume.Spec.ClaimRef.Namespace + "/" + volume.Spec.ClaimRef.Name
+	}
+
+	problems := []string{}
+	switch volume.Status.Phase {
+	case corev1.VolumeReleased:
+		problems = append(problems, fmt.Sprintf("claim %s was deleted and the volume was not reclaimed", claim))
+	case corev1.VolumeFailed:
+		problems = append(problems, fmt.Sprintf("reclamation failed: %s", volume.Status.Message))
+	}
+
+	return map[string]interface{}{
+		"name":          volume.Name,
+		"phase":         volume.Status.Phase,
+		"capacity":      capacity.String(),
+		"accessModes":   volume.Spec.AccessModes,
+		"reclaimPolicy": volume.Spec.PersistentVolumeReclaimPolicy,
+		"storageClass":  volume.Spec.StorageClassName,
+		"volumeMode":    volume.Spec.VolumeMode,
+		"claim":         claim,
+		"reason":        volume.Status.Reason,
+		"problems":      problems,
+		"healthy":       len(problems) == 0,
+		"creationTime":  volume.CreationTimestamp,
+	}
+}
+
+func isDefaultClass(class *storagev1.StorageClass) bool {
+	return class.Annotations[defaultClassAnnotation] == "true"
+}
+
+func getClaimConditions(conditions []corev1.PersistentVolumeClaimCondition) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, condition := range conditions {
+		result = append(result, map[string]interface{}{
+			"type":               condition.Type,
+			"status":             condition.Status,
+			"reason":             condition.Reason,
+			"message":            condition.Message,
+			"lastTransitionTime": condition.LastTransitionTime,
+		})
+	}
+	return result
+}
+
+func getEvents(events []corev1.Event) []map[string]interface{} {
+	sort.Slice(events, func(i, j int) bool {
+		return events[j].LastTimestamp.Before(&events[i].LastTimestamp)
+	})
+
+	result := make([]map[string]interface{}, 0, len(events))
+	for _, event := range events {
+		result = append(result, map[string]interface{}{
+			"type":          event.Type,
+			"reason":        event.Reason,
+			"message":       event.Message,
+			"count":         event.Count,
+			"lastTimestamp": event.LastTimestamp,
+		})
+	}
+	return result
+}
@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestClass(name string, expandable bool, mode storagev1.VolumeBindingMode) *storagev1.StorageClass {
+	return &storagev1.StorageClass{
+		ObjectMeta:           metav1.ObjectMeta{Name: name},
+		Provisioner:          "example.com/csi",
+		AllowVolumeExpansion: &expandable,
+		VolumeBindingMode:    &mode,
+	}
+}
+
+func newTestClaim(name, class string, phase corev1.PersistentVolumeClaimPhase) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: &class,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{Phase: phase},
+	}
+}
+
+func TestListPersistentVolumeClaims(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-0", Namespace: "team-a"},
+		Spec: corev1.PodSpec{Volumes: []corev1.Volume{
+			{Name: "data", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "bound"}}},
+			{Name: "scratch", VolumeSource: corev1.VolumeSource{Ephemeral: &corev1.EphemeralVolumeSource{}}},
+		}},
+	}
+	api := NewStorageAPI(fake.NewSimpleClientset(
+		newTestClass("fast", true, storagev1.VolumeBindingImmediate),
+		newTestClass("local", false, storagev1.VolumeBindingWaitForFirstConsumer),
+		newTestClaim("bound", "fast", corev1.ClaimBound),
+		newTestClaim("db-0-scratch", "fast", corev1.ClaimBound),
+		newTestClaim("lost", "fast", corev1.ClaimLost),
+		newTestClaim("pending", "fast", corev1.ClaimPending),
+		newTestClaim("waiting", "local", corev1.ClaimPending),
+		newTestClaim("orphan", "missing", corev1.ClaimBound),
+		pod,
+	), log.New(io.Discard, "", 0))
+
+	claims, err := api.ListPersistentVolumeClaims(context.Background(), "team-a")
+	if err != nil {
+		t.Fatalf("ListPersistentVolumeClaims: %v", err)
+	}
+
+	byName := make(map[string]map[string]interface{})
+	for _, claim := range claims {
+		byName[claim["name"].(string)] = claim
+	}
+
+	for name, healthy := range map[string]bool{"bound": true, "db-0-scratch": true, "lost": false, "pending": false, "waiting": true, "orphan": false} {
+		if byName[name]["healthy"] != healthy {
+			t.Errorf("%s healthy = %v, want %v (problems %v)", name, byName[name]["healthy"], healthy, byName[name]["problems"])
+		}
+	}
+	if byName["waiting"]["waitingForFirstConsumer"] != true {
+		t.Error("waiting claim should be waiting for its first consumer")
+	}
+	if mounts := byName["bound"]["mountedBy"].([]map[string]interface{}); len(mounts) != 1 || mounts[0]["pod"] != "db-0" {
+		t.Errorf("bound mountedBy = %v", mounts)
+	}
+	if mounts := byName["db-0-scratch"]["mountedBy"].([]map[string]interface{}); len(mounts) != 1 {
+		t.Errorf("ephemeral claim mountedBy = %v", mounts)
+	}
+	if byName["bound"]["expandable"] != true || byName["waiting"]["expandable"] != false {
+		t.Error("expandable should follow the storage class")
+	}
+}
+
+func TestExpandPersistentVolumeClaim(t *testing.T) {
+	api := NewStorageAPI(fake.NewSimpleClientset(
+		newTestClass("fast", true, storagev1.VolumeBindingImmediate),
+		newTestClass("fixed", false, storagev1.VolumeBindingImmediate),
+		newTestClaim("data", "fast", corev1.ClaimBound),
+		newTestClaim("static", "fixed", corev1.ClaimBound),
+		newTestClaim("pending", "fast", corev1.ClaimPending),
+	), log.New(io.Discard, "", 0))
+	ctx := context.Background()
+
+	result, err := api.ExpandPersistentVolumeClaim(ctx, "team-a", "data", resource.MustParse("20Gi"))
+	if err != nil {
+		t.Fatalf("ExpandPersistentVolumeClaim: %v", err)
+	}
+	if got := result.Spec.Resources.Requests[corev1.ResourceStorage]; got.String() != "20Gi" {
+		t.Errorf("requested = %s, want 20Gi", got.String())
+	}
+
+	rejected := []struct {
+		claim string
+		size  string
+	}{
+		{"data", "5Gi"},
+		{"static", "20Gi"},
+		{"pending", "20Gi"},
+	}
+	for _, tt := range rejected {
+		if _, err := api.ExpandPersistentVolumeClaim(ctx, "team-a", tt.claim, resource.MustParse(tt.size)); !errors.Is(err, ErrExpansionNotAllowed) {
+			t.Errorf("expand %s to %s: err = %v, want ErrExpansionNotAllowed", tt.claim, tt.size, err)
+		}
+	}
+}
@@ -4,10 +4,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
+// systemNamespaces are always protected, whatever PROTECTED_NAMESPACES contains
+var systemNamespaces = []string{"default", "kube-system", "kube-public", "kube-node-lease"}
+
 // Config holds all configuration for the application
 type Config struct {
 	ServerAddress string
@@ -15,6 +19,8 @@ type Config struct {
 	LogLevel      string
 	Environment   string
 	K8sHost       string
+	// ProtectedNamespaces can never be deleted through the API. PROTECTED_NAMESPACES adds to the system namespaces.
+	ProtectedNamespaces []string
 }
 
 // Load returns a Config struct populated with values from environment variables
@@ -44,11 +50,12 @@ func Load() (*Config, error) {
 	}
 
 	return &Config{
-		ServerAddress: getEnv("SERVER_ADDRESS", ":8080"),
-		KubeConfig:    kubeconfig,
-		LogLevel:      getEnv("LOG_LEVEL", "info"),
-		Environment:   getEnv("ENV", "development"),
-		K8sHost:       getEnv("K8S_HOST", "http://localhost:9000"),
+		ServerAddress:       getEnv("SERVER_ADDRESS", ":8080"),
+		KubeConfig:          kubeconfig,
+		LogLevel:            getEnv("LOG_LEVEL", "info"),
+		Environment:         getEnv("ENV", "development"),
+		K8sHost:             getEnv("K8S_HOST", "http://localhost:9000"),
+		ProtectedNamespaces: getEnvList("PROTECTED_NAMESPACES", systemNamespaces),
 	}, nil
 }
 
@@ -59,3 +66,20 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvList appends the items of a comma-separated environment variable to base, skipping duplicates
+func getEnvList(key string, base []string) []string {
+	result := append([]string(nil), base...)
+	seen := make(map[string]bool, len(base))
+	for _, item := range base {
+		seen[item] = true
+	}
+
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" && !seen[item] {
+			seen[item] = true
+			result = append(result, item)
+		}
+	}
+	return result
+}
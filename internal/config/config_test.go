@@ -0,0 +1,26 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetEnvListKeepsSystemNamespaces(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{"unset", "", systemNamespaces},
+		{"added", "team-a, team-b", append(append([]string(nil), systemNamespaces...), "team-a", "team-b")},
+		{"duplicates", "kube-system,team-a,team-a", append(append([]string(nil), systemNamespaces...), "team-a")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PROTECTED_NAMESPACES", tt.value)
+			if got := getEnvList("PROTECTED_NAMESPACES", systemNamespaces); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getEnvList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
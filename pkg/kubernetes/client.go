@@ -5,14 +5,16 @@ import (
 	"log"
 	"os"
 
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 )
 
-// Client wraps the Kubernetes clientset
+// Client wraps the Kubernetes clientset and a dynamic client sharing the same config
 type Client struct {
 	*kubernetes.Clientset
-	logger *log.Logger
+	Dynamic dynamic.Interface
+	logger  *log.Logger
 }
 
 // NewClient creates a new Kubernetes client
@@ -49,8 +51,15 @@ func NewClient(kubeconfigPath string) (*Client, error) {
 		return nil, fmt.Errorf("failed to create client: %v", err)
 	}
 
+	// Create the dynamic client for resources without typed clients, such as CRDs
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %v", err)
+	}
+
 	client := &Client{
 		Clientset: clientset,
+		Dynamic:   dynamicClient,
 		logger:    logger,
 	}
 